@@ -0,0 +1,32 @@
+package enums
+
+// Moderation actions recorded in the audit log of a chat
+const (
+	AUDIT_ROLE_CHANGED        = 0
+	AUDIT_MEMBER_KICKED       = 1
+	AUDIT_CHAT_EDITED         = 2
+	AUDIT_CHAT_DELETED        = 3
+	AUDIT_OWNER_CHANGED       = 4
+	AUDIT_MEMBER_BANNED       = 5
+	AUDIT_MEMBER_MUTED        = 6
+	AUDIT_RESTRICTION_LIFTED  = 7
+	AUDIT_PERMISSIONS_CHANGED = 8
+	AUDIT_ROLE_CREATED        = 9
+	AUDIT_ROLE_EDITED         = 10
+	AUDIT_ROLE_DELETED        = 11
+)
+
+var AuditActionsToLabels map[int]string = map[int]string{
+	AUDIT_ROLE_CHANGED:        "role_changed",
+	AUDIT_MEMBER_KICKED:       "member_kicked",
+	AUDIT_CHAT_EDITED:         "chat_edited",
+	AUDIT_CHAT_DELETED:        "chat_deleted",
+	AUDIT_OWNER_CHANGED:       "ownership_transferred",
+	AUDIT_MEMBER_BANNED:       "member_banned",
+	AUDIT_MEMBER_MUTED:        "member_muted",
+	AUDIT_RESTRICTION_LIFTED:  "restriction_lifted",
+	AUDIT_PERMISSIONS_CHANGED: "role_permissions_changed",
+	AUDIT_ROLE_CREATED:        "custom_role_created",
+	AUDIT_ROLE_EDITED:         "custom_role_edited",
+	AUDIT_ROLE_DELETED:        "custom_role_deleted",
+}
@@ -0,0 +1,10 @@
+package enums
+
+// Avatar sizes in pixels, avatars are stored as squares in each of them
+const (
+	AVATAR_SMALL  = 64
+	AVATAR_MEDIUM = 256
+	AVATAR_LARGE  = 512
+)
+
+var AvatarSizes = []int{AVATAR_SMALL, AVATAR_MEDIUM, AVATAR_LARGE}
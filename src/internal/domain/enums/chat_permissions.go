@@ -0,0 +1,41 @@
+package enums
+
+// Permissions of a chat role, a role's permission set is a bitmask of them
+const (
+	SEND_MESSAGES   = 1 << 0
+	INVITE_MEMBERS  = 1 << 1
+	KICK_MEMBERS    = 1 << 2
+	EDIT_CHAT       = 1 << 3
+	PIN_MESSAGES    = 1 << 4
+	DELETE_MESSAGES = 1 << 5
+	MANAGE_ROLES    = 1 << 6
+
+	ALL_PERMISSIONS = SEND_MESSAGES | INVITE_MEMBERS | KICK_MEMBERS | EDIT_CHAT | PIN_MESSAGES | DELETE_MESSAGES | MANAGE_ROLES
+)
+
+var PermissionsToLabels map[int]string = map[int]string{
+	SEND_MESSAGES:   "send_messages",
+	INVITE_MEMBERS:  "invite",
+	KICK_MEMBERS:    "kick",
+	EDIT_CHAT:       "edit_chat",
+	PIN_MESSAGES:    "pin",
+	DELETE_MESSAGES: "delete_messages",
+	MANAGE_ROLES:    "manage_roles",
+}
+
+var LabelsToPermissions map[string]int = map[string]int{
+	"send_messages":   SEND_MESSAGES,
+	"invite":          INVITE_MEMBERS,
+	"kick":            KICK_MEMBERS,
+	"edit_chat":       EDIT_CHAT,
+	"pin":             PIN_MESSAGES,
+	"delete_messages": DELETE_MESSAGES,
+	"manage_roles":    MANAGE_ROLES,
+}
+
+// DefaultRolePermissions are used by chats which have not customised the role
+var DefaultRolePermissions map[int]int = map[int]int{
+	MEMBER:     SEND_MESSAGES,
+	CHAT_ADMIN: SEND_MESSAGES | INVITE_MEMBERS | KICK_MEMBERS | PIN_MESSAGES | DELETE_MESSAGES,
+	OWNER:      ALL_PERMISSIONS,
+}
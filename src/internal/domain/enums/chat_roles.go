@@ -17,3 +17,17 @@ var ChatLabelsToRoles map[string]int = map[string]int{
 	"admin":  CHAT_ADMIN,
 	"owner":  OWNER,
 }
+
+// Ranks of the built-in roles. Custom roles of a chat rank between the member
+// and the owner, and members can only act on the members ranked below them.
+const (
+	MEMBER_RANK     = 0
+	CHAT_ADMIN_RANK = 100
+	OWNER_RANK      = 200
+)
+
+var ChatRolesToRanks map[int]int = map[int]int{
+	MEMBER:     MEMBER_RANK,
+	CHAT_ADMIN: CHAT_ADMIN_RANK,
+	OWNER:      OWNER_RANK,
+}
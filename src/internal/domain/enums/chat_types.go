@@ -0,0 +1,11 @@
+package enums
+
+const (
+	GROUP  = 0
+	DIRECT = 1
+)
+
+var ChatTypesToLabels map[int]string = map[int]string{
+	GROUP:  "group",
+	DIRECT: "direct",
+}
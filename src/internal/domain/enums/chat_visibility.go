@@ -0,0 +1,16 @@
+package enums
+
+const (
+	PRIVATE = 0
+	PUBLIC  = 1
+)
+
+var ChatVisibilitiesToLabels map[int]string = map[int]string{
+	PRIVATE: "private",
+	PUBLIC:  "public",
+}
+
+var ChatLabelsToVisibilities map[string]int = map[string]int{
+	"private": PRIVATE,
+	"public":  PUBLIC,
+}
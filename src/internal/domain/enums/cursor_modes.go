@@ -0,0 +1,13 @@
+package enums
+
+const (
+	BEFORE = 0
+	AFTER  = 1
+	AROUND = 2
+)
+
+var CursorLabelsToModes map[string]int = map[string]int{
+	"before": BEFORE,
+	"after":  AFTER,
+	"around": AROUND,
+}
@@ -0,0 +1,17 @@
+package enums
+
+// Who can add the user to a chat without the user accepting an invitation
+const (
+	DIRECT_ADDS_NOBODY   = 0
+	DIRECT_ADDS_CONTACTS = 1
+)
+
+var DirectAddsToLabels map[int]string = map[int]string{
+	DIRECT_ADDS_NOBODY:   "nobody",
+	DIRECT_ADDS_CONTACTS: "contacts",
+}
+
+var LabelsToDirectAdds map[string]int = map[string]int{
+	"nobody":   DIRECT_ADDS_NOBODY,
+	"contacts": DIRECT_ADDS_CONTACTS,
+}
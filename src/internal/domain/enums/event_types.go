@@ -0,0 +1,30 @@
+package enums
+
+const (
+	MESSAGE_CREATED  = 0
+	MESSAGE_UPDATED  = 1
+	MESSAGE_DELETED  = 2
+	MEMBER_JOINED    = 3
+	MEMBER_LEFT      = 4
+	CHAT_DELETED     = 5
+	MESSAGES_READ    = 6
+	REACTION_ADDED   = 7
+	REACTION_REMOVED = 8
+	OWNER_CHANGED    = 9
+	// MENTIONED goes to the mentioned user only, not to the whole chat
+	MENTIONED = 10
+)
+
+var EventTypesToLabels map[int]string = map[int]string{
+	MESSAGE_CREATED:  "message_created",
+	MESSAGE_UPDATED:  "message_updated",
+	MESSAGE_DELETED:  "message_deleted",
+	MEMBER_JOINED:    "member_joined",
+	MEMBER_LEFT:      "member_left",
+	CHAT_DELETED:     "chat_deleted",
+	MESSAGES_READ:    "messages_read",
+	REACTION_ADDED:   "reaction_added",
+	REACTION_REMOVED: "reaction_removed",
+	OWNER_CHANGED:    "owner_changed",
+	MENTIONED:        "mentioned",
+}
@@ -0,0 +1,13 @@
+package enums
+
+const (
+	INVITATION_PENDING  = 0
+	INVITATION_ACCEPTED = 1
+	INVITATION_DECLINED = 2
+)
+
+var InvitationStatusesToLabels map[int]string = map[int]string{
+	INVITATION_PENDING:  "pending",
+	INVITATION_ACCEPTED: "accepted",
+	INVITATION_DECLINED: "declined",
+}
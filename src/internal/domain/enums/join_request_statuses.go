@@ -0,0 +1,13 @@
+package enums
+
+const (
+	JOIN_REQUEST_PENDING  = 0
+	JOIN_REQUEST_APPROVED = 1
+	JOIN_REQUEST_REJECTED = 2
+)
+
+var JoinRequestStatusesToLabels map[int]string = map[int]string{
+	JOIN_REQUEST_PENDING:  "pending",
+	JOIN_REQUEST_APPROVED: "approved",
+	JOIN_REQUEST_REJECTED: "rejected",
+}
@@ -0,0 +1,40 @@
+package enums
+
+const (
+	// USER_MESSAGE is written by a member, SYSTEM_MESSAGE reports an event of
+	// the chat in its history
+	USER_MESSAGE   = 0
+	SYSTEM_MESSAGE = 1
+)
+
+var MessageTypesToLabels map[int]string = map[int]string{
+	USER_MESSAGE:   "user",
+	SYSTEM_MESSAGE: "system",
+}
+
+// Events reported by system messages
+const (
+	SYSTEM_MESSAGE_PINNED   = 0
+	SYSTEM_MESSAGE_UNPINNED = 1
+	SYSTEM_MEMBER_JOINED    = 2
+	SYSTEM_MEMBER_ADDED     = 3
+	SYSTEM_MEMBER_LEFT      = 4
+	SYSTEM_MEMBER_KICKED    = 5
+	SYSTEM_MEMBER_BANNED    = 6
+	SYSTEM_ROLE_CHANGED     = 7
+	SYSTEM_TITLE_CHANGED    = 8
+	SYSTEM_OWNER_CHANGED    = 9
+)
+
+var SystemEventsToLabels map[int]string = map[int]string{
+	SYSTEM_MESSAGE_PINNED:   "message_pinned",
+	SYSTEM_MESSAGE_UNPINNED: "message_unpinned",
+	SYSTEM_MEMBER_JOINED:    "member_joined",
+	SYSTEM_MEMBER_ADDED:     "member_added",
+	SYSTEM_MEMBER_LEFT:      "member_left",
+	SYSTEM_MEMBER_KICKED:    "member_kicked",
+	SYSTEM_MEMBER_BANNED:    "member_banned",
+	SYSTEM_ROLE_CHANGED:     "role_changed",
+	SYSTEM_TITLE_CHANGED:    "title_changed",
+	SYSTEM_OWNER_CHANGED:    "owner_changed",
+}
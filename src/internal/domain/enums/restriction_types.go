@@ -0,0 +1,12 @@
+package enums
+
+const (
+	// BAN keeps the user out of the chat, MUTE keeps a member from writing to it
+	BAN  = 0
+	MUTE = 1
+)
+
+var RestrictionTypesToLabels map[int]string = map[int]string{
+	BAN:  "ban",
+	MUTE: "mute",
+}
@@ -1,12 +1,23 @@
 package domain
 
-import "libs/src/internal/dto"
+import (
+	"fmt"
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	"time"
+)
 
 type Chat struct {
 	BaseModel
-	Title       string `gorm:"unique;size:40;not null;"`
+	// Titles are unique among group chats only, direct chats have none
+	Title       string `gorm:"size:40;not null;index:idx_chats_group_title,unique,where:type = 0"`
 	Description string `gorm:"size:255;"`
 	OwnerID     int64  `gorm:"not null;"`
+	Type        byte   `gorm:"not null;default:0"`
+	// Visibility of group chats, anyone can find and join a public chat
+	Visibility byte `gorm:"not null;default:0;index"`
+	// DirectKey identifies the pair of users of a direct chat, see DirectChatKey
+	DirectKey *string `gorm:"size:41;uniqueIndex"`
 
 	Owner User `gorm:"foreignKey:OwnerID;references:ID;constraint:OnDelete:CASCADE;"`
 
@@ -16,20 +27,39 @@ type Chat struct {
 func (c *Chat) ToDTO() dto.ChatDTO {
 	return dto.ChatDTO{
 		ID:          c.ID,
+		Type:        enums.ChatTypesToLabels[int(c.Type)],
+		Visibility:  enums.ChatVisibilitiesToLabels[int(c.Visibility)],
 		Title:       c.Title,
 		OwnerID:     c.OwnerID,
 		Description: c.Description,
 	}
 }
 
+// DirectChatKey is the same for both orders of the users, so there is at
+// most one direct chat per pair.
+func DirectChatKey(firstUserId, secondUserId int64) string {
+	if firstUserId > secondUserId {
+		firstUserId, secondUserId = secondUserId, firstUserId
+	}
+	return fmt.Sprintf("%d:%d", firstUserId, secondUserId)
+}
+
 type ChatMember struct {
 	BaseModel
 	ChatID     int64 `gorm:"not null;"`
 	UserID     int64 `gorm:"not null;"`
 	MemberRole byte  `gorm:"not null;"`
+	// Custom role of the chat, nil for the built-in roles
+	RoleID *int64 `gorm:""`
+
+	// Read cursor: the newest message the member has read. Until the first
+	// read it points at the moment the member joined the chat.
+	LastReadMessageId string    `gorm:"size:24;not null;default:''"`
+	LastReadAt        time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
 
-	Chat Chat `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
-	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE;"`
+	Chat Chat      `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
+	User User      `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE;"`
+	Role *ChatRole `gorm:"foreignKey:RoleID;references:ID;constraint:OnDelete:SET NULL;"`
 }
 
 func (cm *ChatMember) ToDTO() dto.ChatMemberDTO {
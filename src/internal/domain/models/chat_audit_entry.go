@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+)
+
+// ChatAuditEntry records a moderation action in a chat. Entries are never
+// updated, and they outlive the chat so deletions stay on record.
+type ChatAuditEntry struct {
+	BaseModel
+	ChatID   int64          `gorm:"not null;index"`
+	ActorID  int64          `gorm:"not null"`
+	Action   byte           `gorm:"not null"`
+	TargetID *int64         `gorm:""`
+	Before   map[string]any `gorm:"type:jsonb;serializer:json"`
+	After    map[string]any `gorm:"type:jsonb;serializer:json"`
+
+	Actor  User  `gorm:"foreignKey:ActorID;references:ID;constraint:OnDelete:CASCADE;"`
+	Target *User `gorm:"foreignKey:TargetID;references:ID;constraint:OnDelete:SET NULL;"`
+}
+
+func (e *ChatAuditEntry) ToDTO() dto.ChatAuditEntryDTO {
+	result := dto.ChatAuditEntryDTO{
+		ID:        e.ID,
+		Actor:     e.Actor.Username,
+		Action:    enums.AuditActionsToLabels[int(e.Action)],
+		Before:    e.Before,
+		After:     e.After,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.Target != nil {
+		result.Target = e.Target.Username
+	}
+	return result
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	"time"
+)
+
+// ChatInvitation is an invitation of a user to a chat, made by one of its
+// admins. The invitee becomes a member only after accepting it. There is at
+// most one pending invitation per user and chat.
+type ChatInvitation struct {
+	BaseModel
+	ChatID      int64      `gorm:"not null;index:idx_chat_invitations_pending,unique,where:status = 0"`
+	InviterID   int64      `gorm:"not null;index"`
+	InviteeID   int64      `gorm:"not null;index:idx_chat_invitations_pending;index"`
+	Status      byte       `gorm:"not null;default:0"`
+	RespondedAt *time.Time `gorm:""`
+
+	Chat    Chat `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
+	Inviter User `gorm:"foreignKey:InviterID;references:ID;constraint:OnDelete:CASCADE;"`
+	Invitee User `gorm:"foreignKey:InviteeID;references:ID;constraint:OnDelete:CASCADE;"`
+}
+
+func (i *ChatInvitation) ToDTO() dto.ChatInvitationDTO {
+	return dto.ChatInvitationDTO{
+		ID:          i.ID,
+		ChatID:      i.ChatID,
+		ChatTitle:   i.Chat.Title,
+		Inviter:     i.Inviter.Username,
+		Invitee:     i.Invitee.Username,
+		Status:      enums.InvitationStatusesToLabels[int(i.Status)],
+		CreatedAt:   i.CreatedAt,
+		RespondedAt: i.RespondedAt,
+	}
+}
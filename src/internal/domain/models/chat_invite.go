@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"libs/src/internal/dto"
+	"time"
+)
+
+// ChatInvite is a shareable link that lets anyone holding the token join the
+// chat until it expires, runs out of uses or is revoked.
+type ChatInvite struct {
+	BaseModel
+	ChatID    int64  `gorm:"not null;index"`
+	CreatorID int64  `gorm:"not null;"`
+	Token     string `gorm:"size:32;not null;uniqueIndex"`
+	// MaxUses of zero and a nil ExpiresAt mean no limit
+	MaxUses   int        `gorm:"not null;default:0"`
+	Uses      int        `gorm:"not null;default:0"`
+	ExpiresAt *time.Time `gorm:""`
+	RevokedAt *time.Time `gorm:""`
+
+	Chat    Chat `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
+	Creator User `gorm:"foreignKey:CreatorID;references:ID;constraint:OnDelete:CASCADE;"`
+}
+
+// IsActive reports whether the invite can still be redeemed at the moment
+func (i *ChatInvite) IsActive(now time.Time) bool {
+	if i.RevokedAt != nil {
+		return false
+	}
+	if i.ExpiresAt != nil && !now.Before(*i.ExpiresAt) {
+		return false
+	}
+	return i.MaxUses == 0 || i.Uses < i.MaxUses
+}
+
+func (i *ChatInvite) ToDTO(link string) dto.ChatInviteDTO {
+	return dto.ChatInviteDTO{
+		ID:        i.ID,
+		ChatID:    i.ChatID,
+		Token:     i.Token,
+		Link:      link,
+		Creator:   i.Creator.Username,
+		MaxUses:   i.MaxUses,
+		Uses:      i.Uses,
+		ExpiresAt: i.ExpiresAt,
+		RevokedAt: i.RevokedAt,
+		IsActive:  i.IsActive(time.Now()),
+		CreatedAt: i.CreatedAt,
+	}
+}
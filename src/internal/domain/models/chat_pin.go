@@ -0,0 +1,13 @@
+package domain
+
+// ChatPin marks a message of the chat as pinned. The message lives in mongo,
+// MessageID is its hex object id.
+type ChatPin struct {
+	BaseModel
+	ChatID     int64  `gorm:"not null;uniqueIndex:idx_chat_pins_message"`
+	MessageID  string `gorm:"size:24;not null;uniqueIndex:idx_chat_pins_message"`
+	PinnedByID int64  `gorm:"not null"`
+
+	Chat     Chat `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
+	PinnedBy User `gorm:"foreignKey:PinnedByID;references:ID;constraint:OnDelete:CASCADE;"`
+}
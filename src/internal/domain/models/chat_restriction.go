@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	"time"
+)
+
+// ChatRestriction is a ban or a mute of a user in a chat. A nil ExpiresAt
+// means it lasts until it is lifted. There is at most one restriction of each
+// type per user and chat.
+type ChatRestriction struct {
+	BaseModel
+	ChatID      int64      `gorm:"not null;uniqueIndex:idx_chat_restrictions_target"`
+	UserID      int64      `gorm:"not null;uniqueIndex:idx_chat_restrictions_target"`
+	Type        byte       `gorm:"not null;uniqueIndex:idx_chat_restrictions_target"`
+	Reason      string     `gorm:"size:255;not null;default:''"`
+	ExpiresAt   *time.Time `gorm:""`
+	CreatedByID int64      `gorm:"not null"`
+
+	Chat      Chat `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
+	User      User `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE;"`
+	CreatedBy User `gorm:"foreignKey:CreatedByID;references:ID;constraint:OnDelete:CASCADE;"`
+}
+
+func (r *ChatRestriction) ToDTO() dto.ChatRestrictionDTO {
+	return dto.ChatRestrictionDTO{
+		Username:  r.User.Username,
+		Avatar:    r.User.Image,
+		Type:      enums.RestrictionTypesToLabels[int(r.Type)],
+		Reason:    r.Reason,
+		ExpiresAt: r.ExpiresAt,
+		CreatedBy: r.CreatedBy.Username,
+		CreatedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,14 @@
+package domain
+
+// ChatRole is a role a chat defines besides the built-in ones. Members with a
+// custom role keep MEMBER as their member role, the custom role sets their
+// rank and permissions.
+type ChatRole struct {
+	BaseModel
+	ChatID      int64  `gorm:"not null;uniqueIndex:idx_chat_roles_name"`
+	Name        string `gorm:"size:32;not null;uniqueIndex:idx_chat_roles_name"`
+	Rank        int    `gorm:"not null"`
+	Permissions int    `gorm:"not null"`
+
+	Chat Chat `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
+}
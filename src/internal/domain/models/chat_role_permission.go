@@ -0,0 +1,12 @@
+package domain
+
+// ChatRolePermission overrides the default permission set of a role in a
+// chat. Roles without a row use enums.DefaultRolePermissions.
+type ChatRolePermission struct {
+	BaseModel
+	ChatID      int64 `gorm:"not null;uniqueIndex:idx_chat_role_permissions_role"`
+	Role        byte  `gorm:"not null;uniqueIndex:idx_chat_role_permissions_role"`
+	Permissions int   `gorm:"not null"`
+
+	Chat Chat `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
+}
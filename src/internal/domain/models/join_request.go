@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	"time"
+)
+
+// JoinRequest is a request of a user to become a member of a private chat,
+// reviewed by the admins of the chat. There is at most one pending request
+// per user and chat.
+type JoinRequest struct {
+	BaseModel
+	ChatID     int64      `gorm:"not null;index:idx_join_requests_pending,unique,where:status = 0"`
+	UserID     int64      `gorm:"not null;index:idx_join_requests_pending;index"`
+	Status     byte       `gorm:"not null;default:0"`
+	Reason     string     `gorm:"size:255;not null;default:''"`
+	ReviewerID *int64     `gorm:""`
+	ReviewedAt *time.Time `gorm:""`
+
+	Chat     Chat  `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
+	User     User  `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE;"`
+	Reviewer *User `gorm:"foreignKey:ReviewerID;references:ID;constraint:OnDelete:SET NULL;"`
+}
+
+func (r *JoinRequest) ToDTO() dto.JoinRequestDTO {
+	return dto.JoinRequestDTO{
+		ID:         r.ID,
+		ChatID:     r.ChatID,
+		ChatTitle:  r.Chat.Title,
+		Username:   r.User.Username,
+		Avatar:     r.User.Image,
+		Status:     enums.JoinRequestStatusesToLabels[int(r.Status)],
+		Reason:     r.Reason,
+		CreatedAt:  r.CreatedAt,
+		ReviewedAt: r.ReviewedAt,
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+// Mention is an entry of the mentions inbox of a user: a message that
+// mentioned them, directly or with @all.
+type Mention struct {
+	BaseModel
+	UserID    int64  `gorm:"not null;uniqueIndex:idx_mentions_message;index:idx_mentions_inbox"`
+	ChatID    int64  `gorm:"not null"`
+	MessageID string `gorm:"size:24;not null;uniqueIndex:idx_mentions_message"`
+	SenderID  int64  `gorm:"not null"`
+	IsRead    bool   `gorm:"not null;default:false;index:idx_mentions_inbox"`
+
+	User   User `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE;"`
+	Chat   Chat `gorm:"foreignKey:ChatID;references:ID;constraint:OnDelete:CASCADE;"`
+	Sender User `gorm:"foreignKey:SenderID;references:ID;constraint:OnDelete:CASCADE;"`
+}
@@ -1,7 +1,11 @@
 package domain
 
 import (
+	"fmt"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	"slices"
 	"time"
 )
 
@@ -10,9 +14,65 @@ type Message struct {
 	SenderId  int64  `bson:"sender_id" json:"sender_id"`
 	ChatId    int64  `bson:"chat_id" json:"chat_id"`
 	Content   string `bson:"content" json:"content"`
-	IsRead    bool   `bson:"is_read" json:"is_read"`
 	IsUpdated bool   `bson:"is_updated" json:"is_updated"`
 	IsDeleted bool   `bson:"is_deleted" json:"is_deleted"`
+	Type      byte   `bson:"type" json:"type"`
+
+	// Event describes what a system message reports, the sender is the member
+	// who caused it
+	Event *SystemEvent `bson:"event,omitempty" json:"event,omitempty"`
+
+	// ReplyTo is the root of the thread the message belongs to, threads are one level deep
+	ReplyTo     *primitive.ObjectID `bson:"reply_to,omitempty" json:"reply_to,omitempty"`
+	ReplyCount  int64               `bson:"reply_count,omitempty" json:"reply_count,omitempty"`
+	LastReplyAt time.Time           `bson:"last_reply_at,omitempty" json:"last_reply_at,omitempty"`
+	LastReplyBy int64               `bson:"last_reply_by,omitempty" json:"last_reply_by,omitempty"`
+
+	Mentions    []MessageMention    `bson:"mentions,omitempty" json:"mentions,omitempty"`
+	Attachments []MessageAttachment `bson:"attachments,omitempty" json:"attachments,omitempty"`
+	Reactions   []MessageReaction   `bson:"reactions,omitempty" json:"reactions,omitempty"`
+	Revisions   []MessageRevision   `bson:"revisions,omitempty" json:"revisions,omitempty"`
+}
+
+// MessageAttachment describes a file uploaded with the message, Key locates
+// it in the storage.
+type MessageAttachment struct {
+	Id           string `bson:"id" json:"id"`
+	Key          string `bson:"key" json:"key"`
+	OriginalName string `bson:"original_name" json:"original_name"`
+	MimeType     string `bson:"mime_type" json:"mime_type"`
+	Size         int64  `bson:"size" json:"size"`
+}
+
+type MessageReaction struct {
+	Emoji   string  `bson:"emoji" json:"emoji"`
+	UserIds []int64 `bson:"user_ids" json:"user_ids"`
+}
+
+// MessageMention locates an @username of a chat member in the content, Offset
+// and Length count characters. @all has no UserId.
+type MessageMention struct {
+	UserId   int64  `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	Username string `bson:"username" json:"username"`
+	Offset   int    `bson:"offset" json:"offset"`
+	Length   int    `bson:"length" json:"length"`
+}
+
+// SystemEvent keeps the username of the target as it was at the time of the
+// event, like the content does. Value is the new role or title.
+type SystemEvent struct {
+	Action    byte   `bson:"action" json:"action"`
+	TargetId  int64  `bson:"target_id,omitempty" json:"target_id,omitempty"`
+	Target    string `bson:"target,omitempty" json:"target,omitempty"`
+	MessageId string `bson:"message_id,omitempty" json:"message_id,omitempty"`
+	Value     string `bson:"value,omitempty" json:"value,omitempty"`
+}
+
+// MessageRevision keeps the content a message had before it was edited or
+// deleted, EditedAt is the moment it was replaced.
+type MessageRevision struct {
+	Content  string    `bson:"content" json:"content"`
+	EditedAt time.Time `bson:"edited_at" json:"edited_at"`
 }
 
 func NewMessageObject(senderId, chatId int64, content string) *Message {
@@ -27,3 +87,91 @@ func NewMessageObject(senderId, chatId int64, content string) *Message {
 		Content:  content,
 	}
 }
+
+// NewSystemMessage creates a message reporting the event in the history of
+// the chat, content is its readable form.
+func NewSystemMessage(actorId, chatId int64, content string, event SystemEvent) *Message {
+	message := NewMessageObject(actorId, chatId, content)
+	message.Type = enums.SYSTEM_MESSAGE
+	message.Event = &event
+	return message
+}
+
+// ToPreview renders deleted messages as tombstones: they keep their place in
+// the history but lose the content. LastReplyBy is left for the caller, it
+// needs another user than the sender.
+func (m *Message) ToPreview(senderUsername string) dto.MessagePreviewDTO {
+	preview := dto.MessagePreviewDTO{
+		Id:             m.Id.Hex(),
+		Content:        m.Content,
+		SenderUsername: senderUsername,
+		IsEdited:       m.IsUpdated,
+		IsDeleted:      m.IsDeleted,
+		Type:           enums.MessageTypesToLabels[int(m.Type)],
+		ReplyCount:     m.ReplyCount,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+	if m.IsDeleted {
+		preview.Content = ""
+	} else {
+		for _, attachment := range m.Attachments {
+			preview.Attachments = append(preview.Attachments, attachment.ToDTO(m.ChatId, m.Id.Hex()))
+		}
+		for _, mention := range m.Mentions {
+			preview.Mentions = append(preview.Mentions, dto.MentionEntityDTO{
+				Username: mention.Username,
+				Offset:   mention.Offset,
+				Length:   mention.Length,
+			})
+		}
+	}
+	if m.Event != nil {
+		preview.Event = &dto.SystemEventDTO{
+			Action:    enums.SystemEventsToLabels[int(m.Event.Action)],
+			Target:    m.Event.Target,
+			MessageId: m.Event.MessageId,
+			Value:     m.Event.Value,
+		}
+	}
+	if m.ReplyTo != nil {
+		preview.ReplyTo = m.ReplyTo.Hex()
+	}
+	if m.ReplyCount > 0 {
+		preview.LastReplyAt = &m.LastReplyAt
+	}
+	return preview
+}
+
+// ReactionsFor aggregates the reactions of the message as seen by the viewer
+func (m *Message) ReactionsFor(viewerId int64) []dto.ReactionDTO {
+	reactions := make([]dto.ReactionDTO, 0, len(m.Reactions))
+	for _, reaction := range m.Reactions {
+		if len(reaction.UserIds) == 0 {
+			continue
+		}
+		reactions = append(reactions, dto.ReactionDTO{
+			Emoji:       reaction.Emoji,
+			Count:       len(reaction.UserIds),
+			ReactedByMe: slices.Contains(reaction.UserIds, viewerId),
+		})
+	}
+	return reactions
+}
+
+func (a *MessageAttachment) ToDTO(chatId int64, messageId string) dto.AttachmentDTO {
+	return dto.AttachmentDTO{
+		Id:       a.Id,
+		Name:     a.OriginalName,
+		MimeType: a.MimeType,
+		Size:     a.Size,
+		Url:      fmt.Sprintf("/messenger/chat/%d/message/%s/attachments/%s", chatId, messageId, a.Id),
+	}
+}
+
+func (r *MessageRevision) ToDTO() dto.MessageRevisionDTO {
+	return dto.MessageRevisionDTO{
+		Content:  r.Content,
+		EditedAt: r.EditedAt,
+	}
+}
@@ -1,9 +1,14 @@
 package domain
 
 import (
+	"fmt"
 	"libs/src/internal/dto"
+	"path"
+	"strings"
 )
 
+const AvatarsPrefix = "avatars/"
+
 type User struct {
 	BaseModel
 	Username    string `gorm:"unique;size:40;not null;"`
@@ -13,6 +18,8 @@ type User struct {
 	IsActive    bool   `gorm:"not null;default:false;"`
 	Role        byte   `gorm:"not null;default:0"`
 	Image       string
+	// DirectAdds tells who can add the user to chats without an invitation
+	DirectAdds byte `gorm:"not null;default:0"`
 
 	OwnerChats []Chat       `gorm:"foreignKey:OwnerID;"`
 	Chats      []ChatMember `gorm:"foreignKey:UserID;"`
@@ -29,3 +36,14 @@ func (u *User) ToDTO() dto.UserDTO {
 		Image:       u.Image,
 	}
 }
+
+// AvatarKey is the storage key of the avatar in the size, User.Image keeps the
+// key without the size. Avatars uploaded before the thumbnails existed have
+// a single size.
+func AvatarKey(image string, size int) string {
+	if !strings.HasPrefix(image, AvatarsPrefix) {
+		return image
+	}
+	ext := path.Ext(image)
+	return fmt.Sprintf("%s_%d%s", strings.TrimSuffix(image, ext), size, ext)
+}
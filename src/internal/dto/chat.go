@@ -2,20 +2,29 @@ package dto
 
 type ChatDTO struct {
 	ID          int64  `json:"id"`
+	Type        string `json:"type"`
+	Visibility  string `json:"visibility"`
 	Title       string `json:"title"`
 	OwnerID     int64  `json:"owner_id"`
 	Description string `json:"description"`
+
+	// PinnedMessages are listed with the chat info only, the latest pinned first
+	PinnedMessages []PinnedMessageDTO `json:"pinned_messages,omitempty"`
 }
 
 type CreateChatRequest struct {
 	Title       string `json:"title" binding:"required,min=1,max=38"`
 	Description string `json:"description" binding:"required,min=1,max=254"`
+	// Visibility is "private" when omitted
+	Visibility string `json:"visibility" binding:"omitempty,oneof=public private"`
 }
 
 type ChatPreview struct {
-	Title       string `json:"title"`
-	Owner       string `json:"owner"`
-	Description string `json:"description"`
+	ID           int64  `json:"id" gorm:"column:id"`
+	Title        string `json:"title" gorm:"column:title"`
+	Owner        string `json:"owner" gorm:"column:owner"`
+	Description  string `json:"description" gorm:"column:description"`
+	MembersCount int64  `json:"members_count" gorm:"column:members_count"`
 }
 
 type FilterChatsResponse struct {
@@ -25,8 +34,21 @@ type FilterChatsResponse struct {
 type ChangeChatRequest struct {
 	NewTitle       *string `json:"new_title" binding:"omitempty,min=1,max=38"`
 	NewDescription *string `json:"new_description" binding:"omitempty,min=1,max=254"`
+	NewVisibility  *string `json:"new_visibility" binding:"omitempty,oneof=public private"`
+}
+
+// ChatPeerDTO is the other participant of a direct chat
+type ChatPeerDTO struct {
+	Username string `json:"username" gorm:"column:username"`
+	Avatar   string `json:"avatar" gorm:"column:avatar"`
+}
+
+type ChatListItemDTO struct {
+	ChatDTO
+	UnreadCount int64        `json:"unread_count"`
+	Peer        *ChatPeerDTO `json:"peer,omitempty"`
 }
 
 type ChatsForUserResponse struct {
-	Chats []ChatDTO `json:"chats"`
+	Chats []ChatListItemDTO `json:"chats"`
 }
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type ChatAuditEntryDTO struct {
+	ID        int64          `json:"id"`
+	Actor     string         `json:"actor"`
+	Action    string         `json:"action"`
+	Target    string         `json:"target,omitempty"`
+	Before    map[string]any `json:"before"`
+	After     map[string]any `json:"after"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type ChatAuditLogResponse struct {
+	Entries []ChatAuditEntryDTO `json:"entries"`
+}
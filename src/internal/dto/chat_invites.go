@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type CreateInviteRequest struct {
+	// Lifetime of the link in seconds, the link never expires when omitted
+	ExpiresIn int `json:"expires_in" binding:"omitempty,min=60,max=31536000"`
+	// How many times the link can be redeemed, unlimited when omitted
+	MaxUses int `json:"max_uses" binding:"omitempty,min=1,max=100000"`
+}
+
+type ChatInviteDTO struct {
+	ID        int64      `json:"id"`
+	ChatID    int64      `json:"chat_id"`
+	Token     string     `json:"token"`
+	Link      string     `json:"link"`
+	Creator   string     `json:"creator"`
+	MaxUses   int        `json:"max_uses"`
+	Uses      int        `json:"uses"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	IsActive  bool       `json:"is_active"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type ChatInviteListResponse struct {
+	Invites []ChatInviteDTO `json:"invites"`
+}
+
+type InvitePreviewDTO struct {
+	Chat      ChatPreview `json:"chat"`
+	ExpiresAt *time.Time  `json:"expires_at"`
+	IsMember  bool        `json:"is_member"`
+}
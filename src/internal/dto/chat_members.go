@@ -14,12 +14,19 @@ type ChatMemberDTO struct {
 }
 
 type MemberInfo struct {
-	ChatID     int64     `json:"chat_id" gorm:"column:chat_id"`
-	ChatTitle  string    `json:"chat_title" gorm:"column:chat_title"`
-	MemberID   int64     `json:"member_id" gorm:"column:member_id"`
-	MemberRole byte      `json:"member_role" gorm:"column:member_role"`
-	DateJoined time.Time `json:"date_joined" gorm:"column:date_joined"`
-	UpdateAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+	ChatID     int64  `json:"chat_id" gorm:"column:chat_id"`
+	ChatTitle  string `json:"chat_title" gorm:"column:chat_title"`
+	ChatType   byte   `json:"chat_type" gorm:"column:chat_type"`
+	MemberID   int64  `json:"member_id" gorm:"column:member_id"`
+	MemberRole byte   `json:"member_role" gorm:"column:member_role"`
+	// Custom role of the member, empty for the built-in roles
+	RoleName string `json:"role_name" gorm:"column:role_name"`
+	// Rank of the custom role, nil for the built-in roles
+	Rank *int `json:"-" gorm:"column:rank"`
+	// Permissions the chat set for the role, nil when it uses the defaults
+	Permissions *int      `json:"-" gorm:"column:permissions"`
+	DateJoined  time.Time `json:"date_joined" gorm:"column:date_joined"`
+	UpdateAt    time.Time `json:"updated_at" gorm:"column:updated_at"`
 }
 
 type MemberPreview struct {
@@ -33,3 +40,89 @@ type MemberPreview struct {
 type MemberListPreview struct {
 	Members []MemberPreview `json:"members"`
 }
+
+type MessageReaderDTO struct {
+	Username string    `json:"username" gorm:"column:username"`
+	Avatar   string    `json:"avatar" gorm:"column:avatar"`
+	ReadUpTo time.Time `json:"read_up_to" gorm:"column:read_up_to"`
+}
+
+type ChatInvitationDTO struct {
+	ID          int64      `json:"id"`
+	ChatID      int64      `json:"chat_id"`
+	ChatTitle   string     `json:"chat_title"`
+	Inviter     string     `json:"inviter"`
+	Invitee     string     `json:"invitee"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RespondedAt *time.Time `json:"responded_at"`
+}
+
+type ChatInvitationListResponse struct {
+	Invitations []ChatInvitationDTO `json:"invitations"`
+}
+
+type JoinRequestDTO struct {
+	ID         int64      `json:"id"`
+	ChatID     int64      `json:"chat_id"`
+	ChatTitle  string     `json:"chat_title"`
+	Username   string     `json:"username"`
+	Avatar     string     `json:"avatar"`
+	Status     string     `json:"status"`
+	Reason     string     `json:"reason"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+}
+
+type JoinRequestListResponse struct {
+	Requests []JoinRequestDTO `json:"requests"`
+}
+
+type RejectJoinRequestRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+type TransferOwnershipRequest struct {
+	NewOwner string `json:"new_owner" binding:"required"`
+}
+
+type LeaveChatRequest struct {
+	// What happens to the chat when its owner leaves: "transfer" hands it to
+	// NewOwner, "delete" deletes it. Other members leave without it.
+	OwnerAction string `json:"owner_action" binding:"omitempty,oneof=transfer delete"`
+	NewOwner    string `json:"new_owner"`
+}
+
+type RestrictMemberRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+	// Duration in seconds, bans without it last until they are lifted
+	Duration int `json:"duration" binding:"omitempty,min=60,max=31536000"`
+}
+
+type ChatRestrictionDTO struct {
+	Username  string     `json:"username"`
+	Avatar    string     `json:"avatar"`
+	Type      string     `json:"type"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type ChatRestrictionListResponse struct {
+	Restrictions []ChatRestrictionDTO `json:"restrictions"`
+}
+
+type RolePermissionsDTO struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+	IsDefault   bool     `json:"is_default"`
+}
+
+type RolePermissionsListResponse struct {
+	Roles []RolePermissionsDTO `json:"roles"`
+}
+
+type ChangeRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"required,dive,oneof=send_messages invite kick edit_chat pin delete_messages manage_roles"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+type ChatRoleDTO struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Rank        int      `json:"rank"`
+	Permissions []string `json:"permissions"`
+}
+
+type ChatRoleListResponse struct {
+	Roles []ChatRoleDTO `json:"roles"`
+}
+
+type CreateChatRoleRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=32"`
+	// Rank between the member (0) and the owner (200), admins rank 100
+	Rank        int      `json:"rank" binding:"required,min=1,max=199"`
+	Permissions []string `json:"permissions" binding:"required,dive,oneof=send_messages invite kick edit_chat pin delete_messages manage_roles"`
+}
+
+// ChangeChatRoleRequest changes the fields which are set
+type ChangeChatRoleRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,min=1,max=32"`
+	Rank        *int     `json:"rank" binding:"omitempty,min=1,max=199"`
+	Permissions []string `json:"permissions" binding:"omitempty,dive,oneof=send_messages invite kick edit_chat pin delete_messages manage_roles"`
+}
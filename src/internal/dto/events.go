@@ -0,0 +1,8 @@
+package dto
+
+type EventDTO struct {
+	Type    string `json:"type"`
+	ChatId  int64  `json:"chat_id"`
+	UserId  int64  `json:"user_id,omitempty"`
+	Payload any    `json:"payload,omitempty"`
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"mime/multipart"
+	"time"
+)
 
 type BaseMessageDTO struct {
 	Id        string    `json:"id"`
@@ -9,7 +12,6 @@ type BaseMessageDTO struct {
 	ChatId    int64     `json:"chat_id"`
 	SenderId  string    `json:"sender_id"`
 	IsEdited  bool      `json:"is_edited"`
-	IsRead    bool      `json:"is_read"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -19,11 +21,148 @@ type MessagePreviewDTO struct {
 	Content        string    `json:"content"`
 	SenderUsername string    `json:"sender_username"`
 	IsEdited       bool      `json:"is_edited"`
-	IsRead         bool      `json:"is_read"`
+	IsDeleted      bool      `json:"is_deleted"`
 	UpdatedAt      time.Time `json:"updated_at"`
 	CreatedAt      time.Time `json:"created_at"`
+
+	// Type is "user" or "system", Event is set for system messages
+	Type  string          `json:"type"`
+	Event *SystemEventDTO `json:"event,omitempty"`
+
+	ReplyTo     string     `json:"reply_to,omitempty"`
+	ReplyCount  int64      `json:"reply_count"`
+	LastReplyAt *time.Time `json:"last_reply_at,omitempty"`
+	LastReplyBy string     `json:"last_reply_by,omitempty"`
+
+	Mentions    []MentionEntityDTO `json:"mentions,omitempty"`
+	Attachments []AttachmentDTO    `json:"attachments,omitempty"`
+	Reactions   []ReactionDTO      `json:"reactions,omitempty"`
+}
+
+// MentionEntityDTO locates a mention in the content, Offset and Length count
+// characters and include the "@"
+type MentionEntityDTO struct {
+	Username string `json:"username"`
+	Offset   int    `json:"offset"`
+	Length   int    `json:"length"`
+}
+
+type AttachmentDTO struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+	Url      string `json:"url"`
+}
+
+type ReactionDTO struct {
+	Emoji       string `json:"emoji"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
+}
+
+type ReactionEventDTO struct {
+	MessageId string `json:"message_id"`
+	Emoji     string `json:"emoji"`
 }
 
 type SendMessageRequest struct {
+	Message string `json:"message" form:"message"`
+	// ReplyTo is the id of the message to answer in a thread
+	ReplyTo string `json:"reply_to,omitempty" form:"reply_to"`
+	// Attachments can only be sent as multipart/form-data
+	Attachments []*multipart.FileHeader `json:"-" form:"attachments" swaggerignore:"true"`
+}
+
+type EditMessageRequest struct {
 	Message string `json:"message"`
 }
+
+type MarkReadRequest struct {
+	MessageId string `json:"message_id" binding:"required"`
+}
+
+type MessageRevisionDTO struct {
+	Content  string    `json:"content"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+type MessageHistoryResponse struct {
+	Messages    []MessagePreviewDTO `json:"messages"`
+	OlderCursor string              `json:"older_cursor"`
+	NewerCursor string              `json:"newer_cursor"`
+}
+
+type MessageThreadResponse struct {
+	Parent MessagePreviewDTO `json:"parent"`
+	MessageHistoryResponse
+}
+
+type SystemEventDTO struct {
+	Action    string `json:"action"`
+	Target    string `json:"target,omitempty"`
+	MessageId string `json:"message_id,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
+
+type PinnedMessageDTO struct {
+	Message  MessagePreviewDTO `json:"message"`
+	PinnedBy string            `json:"pinned_by"`
+	PinnedAt time.Time         `json:"pinned_at"`
+}
+
+// MentionDTO is an entry of the mentions inbox, ChatTitle is empty for direct
+// chats
+type MentionDTO struct {
+	Id        int64             `json:"id"`
+	ChatId    int64             `json:"chat_id"`
+	ChatTitle string            `json:"chat_title"`
+	Message   MessagePreviewDTO `json:"message"`
+	IsRead    bool              `json:"is_read"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type MentionListResponse struct {
+	Mentions    []MentionDTO `json:"mentions"`
+	UnreadCount int64        `json:"unread_count"`
+}
+
+// MarkMentionsReadRequest marks the given mentions as read, all of them when
+// Ids is empty
+type MarkMentionsReadRequest struct {
+	Ids []int64 `json:"ids"`
+}
+
+// SearchMessagesRequest is a full-text search in the chats of the user, the
+// optional filters narrow it to a chat, a sender or a period
+type SearchMessagesRequest struct {
+	Query  string
+	ChatId int64
+	Sender string
+	From   time.Time
+	To     time.Time
+	Cursor string
+	Limit  int
+}
+
+// MessageSearchResultDTO is a found message with the part of its content
+// around the match, Highlights locate the searched words in the snippet
+type MessageSearchResultDTO struct {
+	ChatId     int64             `json:"chat_id"`
+	ChatTitle  string            `json:"chat_title"`
+	Message    MessagePreviewDTO `json:"message"`
+	Snippet    string            `json:"snippet"`
+	Highlights []TextRangeDTO    `json:"highlights"`
+}
+
+type TextRangeDTO struct {
+	Offset int `json:"offset"`
+	Length int `json:"length"`
+}
+
+// MessageSearchResponse lists the results newest first, NextCursor is empty
+// on the last page
+type MessageSearchResponse struct {
+	Results    []MessageSearchResultDTO `json:"results"`
+	NextCursor string                   `json:"next_cursor"`
+}
@@ -56,3 +56,12 @@ type ChangePasswordRequest struct {
 	NewPassword        string `json:"new_password" binding:"required,password"`
 	ConfirmNewPassword string `json:"confirm_new_password" binding:"required,password"`
 }
+
+type UserSettingsDTO struct {
+	DirectAdds string `json:"direct_adds"`
+}
+
+type ChangeUserSettingsRequest struct {
+	// Who can add the user to chats without an invitation: nobody or contacts
+	DirectAdds *string `json:"direct_adds" binding:"omitempty,oneof=nobody contacts"`
+}
@@ -114,7 +114,7 @@ func ChangeChat(c *gin.Context) {
 }
 
 // @Summary Get chats for user
-// @Description get all the chats in which the user consists
+// @Description get all the chats in which the user consists, with the number of unread messages in each
 // @Tags Chat
 // @Accept json
 // @Produce json
@@ -139,7 +139,7 @@ func GetChatsForUser(c *gin.Context) {
 	service := services.NewChatService(app)
 
 	var (
-		chats []dto.ChatDTO
+		chats []dto.ChatListItemDTO
 		err   error
 	)
 
@@ -157,7 +157,7 @@ func GetChatsForUser(c *gin.Context) {
 }
 
 // @Summary Get chat info
-// @Description get chat info by id
+// @Description get chat info by id, with the pinned messages
 // @Tags Chat
 // @Accept json
 // @Produce json
@@ -187,3 +187,102 @@ func GetChatInfo(c *gin.Context) {
 
 	c.JSON(http.StatusOK, chat)
 }
+
+// @Summary Open direct chat
+// @Description Get the one-to-one chat with the user, it is created on the first request
+// @Tags Chat
+// @Produce json
+// @Param username path string true "Username of the other participant"
+// @Success 200 {object} dto.ChatListItemDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/direct/{username} [post]
+func OpenDirectChat(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	user := c.MustGet("user").(dto.UserDTO)
+
+	service := services.NewChatService(app)
+	chat, err := service.OpenDirect(c.Request.Context(), user, c.Param("username"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, chat)
+}
+
+// @Summary Discover chats
+// @Description List the public chats anyone can join, the most populated first
+// @Tags Chat
+// @Produce json
+// @Param search query string false "Search in titles and descriptions"
+// @Param page query int false "Page"
+// @Success 200 {object} dto.FilterChatsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/discover [get]
+func DiscoverChats(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	user := c.MustGet("user").(dto.UserDTO)
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid page"})
+		return
+	}
+
+	service := services.NewChatService(app)
+	chats, err := service.Discover(c.Request.Context(), user, c.Query("search"), pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, chats)
+}
+
+// @Summary Chat audit log
+// @Description List the moderation actions taken in the chat, newest first, visible to admins
+// @Tags Chat
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param page query int false "Page"
+// @Success 200 {object} dto.ChatAuditLogResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/audit-log [get]
+func GetAuditLog(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	user := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid page"})
+		return
+	}
+
+	service := services.NewChatService(app)
+	entries, err := service.GetAuditLog(c.Request.Context(), user, int64(chatId), pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,165 @@
+package handler_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"libs/src/internal/dto"
+	services "libs/src/internal/usecase"
+	usecase_errors "libs/src/internal/usecase/errors"
+	"libs/src/settings"
+	"net/http"
+	"strconv"
+)
+
+// @Summary Create invite link
+// @Description Create a shareable link to join the chat, only admins can do it
+// @Tags ChatInvites
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param CreateInviteRequest body dto.CreateInviteRequest true "Invite limits"
+// @Success 200 {object} dto.ChatInviteDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/invites [post]
+func CreateInvite(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	var request dto.CreateInviteRequest
+	if err = c.ShouldBindJSON(&request); err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+		return
+	}
+
+	service := services.NewChatInviteService(app)
+	invite, err := service.CreateInvite(c.Request.Context(), caller, int64(chatId), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, invite)
+}
+
+// @Summary Get invite links
+// @Description Get all invite links of the chat, including expired and revoked ones
+// @Tags ChatInvites
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Success 200 {object} dto.ChatInviteListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/invites [get]
+func GetInvites(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	service := services.NewChatInviteService(app)
+	invites, err := service.GetInvites(c.Request.Context(), caller, int64(chatId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, invites)
+}
+
+// @Summary Revoke invite link
+// @Description Revoke an invite link, it can not be used afterwards
+// @Tags ChatInvites
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param InviteId path int true "Invite ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/invites/{InviteId} [delete]
+func RevokeInvite(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+	inviteId, err := strconv.Atoi(c.Param("invite_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid invite ID"})
+		return
+	}
+
+	service := services.NewChatInviteService(app)
+	err = service.RevokeInvite(c.Request.Context(), caller, int64(chatId), int64(inviteId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Preview invite link
+// @Description Show the chat behind an invite link without joining it
+// @Tags ChatInvites
+// @Produce json
+// @Param Token path string true "Invite token"
+// @Success 200 {object} dto.InvitePreviewDTO
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/invite/{Token} [get]
+func PreviewInvite(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	service := services.NewChatInviteService(app)
+	preview, err := service.PreviewInvite(c.Request.Context(), caller, c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, preview)
+}
+
+// @Summary Redeem invite link
+// @Description Join the chat behind an invite link
+// @Tags ChatInvites
+// @Produce json
+// @Param Token path string true "Invite token"
+// @Success 200 {object} dto.ChatDTO
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/invite/{Token} [post]
+func RedeemInvite(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	service := services.NewChatInviteService(app)
+	chat, err := service.RedeemInvite(c.Request.Context(), caller, c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, chat)
+}
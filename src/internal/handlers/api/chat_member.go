@@ -2,6 +2,7 @@ package handler_api
 
 import (
 	"github.com/gin-gonic/gin"
+	"libs/src/internal/domain/enums"
 	"libs/src/internal/dto"
 	services "libs/src/internal/usecase"
 	usecase_errors "libs/src/internal/usecase/errors"
@@ -11,14 +12,15 @@ import (
 )
 
 // @Summary Invite to chat
-// @Description inviting a user to an existing chat
+// @Description inviting a user to an existing chat, the user joins after accepting the invitation
 // @Tags ChatMembers
 // @Accept json
 // @Produce json
 // @Param invitee query string true "Invitee username"
 // @Param chat_id query int true "chat id to invite to"
-// @Success 200 {object} dto.MessageResponse
+// @Success 200 {object} dto.ChatInvitationDTO
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /messenger/chat/invite [post]
 func InviteToChat(c *gin.Context) {
@@ -36,16 +38,16 @@ func InviteToChat(c *gin.Context) {
 
 	chatIDInt, _ := strconv.Atoi(chatID)
 
-	err := service.InviteToChat(c.Request.Context(), &inviter, invitee, int64(chatIDInt))
+	invitation, err := service.InviteToChat(c.Request.Context(), &inviter, invitee, int64(chatIDInt))
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+	c.JSON(http.StatusOK, invitation)
 }
 
 // @Summary Change member role
-// @Description сhanges the role of the user if possible
+// @Description сhanges the role of the user if possible, the role is a built-in one or a custom role of the chat
 // @Tags ChatMembers
 // @Accept json
 // @Produce json
@@ -157,3 +159,762 @@ func GetMemberList(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, members)
 }
+
+// @Summary Join chat
+// @Description Join a public chat
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/join [post]
+func JoinChat(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.JoinChat(c.Request.Context(), caller, int64(chatId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Invitations inbox
+// @Description Get the pending invitations of the user
+// @Tags ChatMembers
+// @Produce json
+// @Param page query int false "Page"
+// @Success 200 {object} dto.ChatInvitationListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/invitations [get]
+func GetInvitations(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid page"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	invitations, err := service.GetInvitations(c.Request.Context(), caller, pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, invitations)
+}
+
+// @Summary Sent invitations
+// @Description Get the invitations made by the user with their status
+// @Tags ChatMembers
+// @Produce json
+// @Param page query int false "Page"
+// @Success 200 {object} dto.ChatInvitationListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/invitations/sent [get]
+func GetSentInvitations(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid page"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	invitations, err := service.GetSentInvitations(c.Request.Context(), caller, pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, invitations)
+}
+
+// @Summary Accept invitation
+// @Description Accept an invitation and join the chat
+// @Tags ChatMembers
+// @Produce json
+// @Param InvitationId path int true "Invitation ID"
+// @Success 200 {object} dto.ChatDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/invitations/{InvitationId}/accept [post]
+func AcceptInvitation(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	invitationId, err := strconv.Atoi(c.Param("invitation_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid invitation ID"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	chat, err := service.AcceptInvitation(c.Request.Context(), caller, int64(invitationId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, chat)
+}
+
+// @Summary Decline invitation
+// @Description Decline an invitation to a chat
+// @Tags ChatMembers
+// @Produce json
+// @Param InvitationId path int true "Invitation ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/invitations/{InvitationId}/decline [post]
+func DeclineInvitation(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	invitationId, err := strconv.Atoi(c.Param("invitation_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid invitation ID"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.DeclineInvitation(c.Request.Context(), caller, int64(invitationId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Request to join chat
+// @Description Ask the admins of a private chat to let the user in
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Success 200 {object} dto.JoinRequestDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/join-requests [post]
+func RequestToJoin(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	request, err := service.RequestToJoin(c.Request.Context(), caller, int64(chatId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary Join requests queue
+// @Description Get the pending requests to join the chat, only admins can see them
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param page query int false "Page"
+// @Success 200 {object} dto.JoinRequestListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/join-requests [get]
+func GetJoinRequests(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid page"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	requests, err := service.GetJoinRequests(c.Request.Context(), caller, int64(chatId), pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary My join requests
+// @Description Get the requests to join chats made by the user with their status
+// @Tags ChatMembers
+// @Produce json
+// @Param page query int false "Page"
+// @Success 200 {object} dto.JoinRequestListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/join-requests [get]
+func GetMyJoinRequests(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid page"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	requests, err := service.GetMyJoinRequests(c.Request.Context(), caller, pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Approve join request
+// @Description Approve a request to join the chat, the requester becomes a member
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param RequestId path int true "Join request ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/join-requests/{RequestId}/approve [post]
+func ApproveJoinRequest(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+	requestId, err := strconv.Atoi(c.Param("request_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid join request ID"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.ApproveJoinRequest(c.Request.Context(), caller, int64(chatId), int64(requestId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Reject join request
+// @Description Reject a request to join the chat, the reason is shown to the requester
+// @Tags ChatMembers
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param RequestId path int true "Join request ID"
+// @Param RejectJoinRequestRequest body dto.RejectJoinRequestRequest false "Reason of the rejection"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/join-requests/{RequestId}/reject [post]
+func RejectJoinRequest(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+	requestId, err := strconv.Atoi(c.Param("request_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid join request ID"})
+		return
+	}
+
+	var request dto.RejectJoinRequestRequest
+	if c.Request.ContentLength != 0 {
+		if err = c.ShouldBindJSON(&request); err != nil {
+			c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+			return
+		}
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.RejectJoinRequest(c.Request.Context(), caller, int64(chatId), int64(requestId), request.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Leave chat
+// @Description Leave the chat. The owner has to hand the chat to another member or delete it.
+// @Tags ChatMembers
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param LeaveChatRequest body dto.LeaveChatRequest false "What happens to the chat when the owner leaves"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/leave [post]
+func LeaveChat(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	var request dto.LeaveChatRequest
+	if c.Request.ContentLength != 0 {
+		if err = c.ShouldBindJSON(&request); err != nil {
+			c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+			return
+		}
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.LeaveChat(c.Request.Context(), caller, int64(chatId), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Transfer ownership
+// @Description Make another member the owner of the chat, the current owner becomes an admin
+// @Tags ChatMembers
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param TransferOwnershipRequest body dto.TransferOwnershipRequest true "Username of the new owner"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/transfer-ownership [post]
+func TransferOwnership(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	var request dto.TransferOwnershipRequest
+	if err = c.ShouldBindJSON(&request); err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.TransferOwnership(c.Request.Context(), caller, int64(chatId), request.NewOwner)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Bans
+// @Description Get the bans in force in the chat
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param page query int false "Page"
+// @Success 200 {object} dto.ChatRestrictionListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/bans [get]
+func GetBans(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid page"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	restrictions, err := service.GetRestrictions(c.Request.Context(), caller, int64(chatId), enums.BAN, pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, restrictions)
+}
+
+// @Summary Ban member
+// @Description Ban the user from the chat, removing them if they are a member. Without a duration the ban lasts until it is lifted.
+// @Tags ChatMembers
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param member_username path string true "Target username"
+// @Param RestrictMemberRequest body dto.RestrictMemberRequest false "Reason and duration of the ban"
+// @Success 200 {object} dto.ChatRestrictionDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/bans/{member_username} [put]
+func BanMember(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	var request dto.RestrictMemberRequest
+	if c.Request.ContentLength != 0 {
+		if err = c.ShouldBindJSON(&request); err != nil {
+			c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+			return
+		}
+	}
+
+	service := services.NewChatMemberService(app)
+	restriction, err := service.Restrict(c.Request.Context(), caller, int64(chatId), c.Param("member_username"), enums.BAN, request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, restriction)
+}
+
+// @Summary Unban member
+// @Description Lift the ban of the user in the chat
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param member_username path string true "Target username"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/bans/{member_username} [delete]
+func UnbanMember(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.LiftRestriction(c.Request.Context(), caller, int64(chatId), c.Param("member_username"), enums.BAN)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Mutes
+// @Description Get the mutes in force in the chat
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param page query int false "Page"
+// @Success 200 {object} dto.ChatRestrictionListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/mutes [get]
+func GetMutes(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid page"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	restrictions, err := service.GetRestrictions(c.Request.Context(), caller, int64(chatId), enums.MUTE, pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, restrictions)
+}
+
+// @Summary Mute member
+// @Description Stop the member from sending messages to the chat for the given duration
+// @Tags ChatMembers
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param member_username path string true "Target username"
+// @Param RestrictMemberRequest body dto.RestrictMemberRequest true "Reason and duration of the mute"
+// @Success 200 {object} dto.ChatRestrictionDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/mutes/{member_username} [put]
+func MuteMember(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	var request dto.RestrictMemberRequest
+	if err = c.ShouldBindJSON(&request); err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	restriction, err := service.Restrict(c.Request.Context(), caller, int64(chatId), c.Param("member_username"), enums.MUTE, request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, restriction)
+}
+
+// @Summary Unmute member
+// @Description Lift the mute of the user in the chat
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param member_username path string true "Target username"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/mutes/{member_username} [delete]
+func UnmuteMember(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.LiftRestriction(c.Request.Context(), caller, int64(chatId), c.Param("member_username"), enums.MUTE)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Role permissions
+// @Description Get the permissions of every role in the chat
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Success 200 {object} dto.RolePermissionsListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/permissions [get]
+func GetRolePermissions(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	permissions, err := service.GetRolePermissions(c.Request.Context(), caller, int64(chatId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, permissions)
+}
+
+// @Summary Change role permissions
+// @Description Replace the permissions of a role in the chat, only the owner can do it
+// @Tags ChatMembers
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param role path string true "Role (member or admin)"
+// @Param ChangeRolePermissionsRequest body dto.ChangeRolePermissionsRequest true "New permissions of the role"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/permissions/{role} [put]
+func ChangeRolePermissions(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	var request dto.ChangeRolePermissionsRequest
+	if err = c.ShouldBindJSON(&request); err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.ChangeRolePermissions(c.Request.Context(), caller, int64(chatId), c.Param("role"), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Reset role permissions
+// @Description Bring a role of the chat back to its default permissions, only the owner can do it
+// @Tags ChatMembers
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param role path string true "Role (member or admin)"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/permissions/{role} [delete]
+func ResetRolePermissions(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	service := services.NewChatMemberService(app)
+	err = service.ResetRolePermissions(c.Request.Context(), caller, int64(chatId), c.Param("role"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
@@ -0,0 +1,164 @@
+package handler_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"libs/src/internal/dto"
+	services "libs/src/internal/usecase"
+	usecase_errors "libs/src/internal/usecase/errors"
+	"libs/src/settings"
+	"net/http"
+	"strconv"
+)
+
+// @Summary Chat roles
+// @Description Get the custom roles of the chat, highest rank first
+// @Tags ChatRoles
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Success 200 {object} dto.ChatRoleListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/roles [get]
+func GetChatRoles(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	service := services.NewChatRoleService(app)
+	roles, err := service.GetRoles(c.Request.Context(), caller, int64(chatId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, roles)
+}
+
+// @Summary Create chat role
+// @Description Create a custom role with a rank and a permission set, only the owner can do it
+// @Tags ChatRoles
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param CreateChatRoleRequest body dto.CreateChatRoleRequest true "Role"
+// @Success 200 {object} dto.ChatRoleDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/roles [post]
+func CreateChatRole(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	var request dto.CreateChatRoleRequest
+	if err = c.ShouldBindJSON(&request); err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+		return
+	}
+
+	service := services.NewChatRoleService(app)
+	role, err := service.CreateRole(c.Request.Context(), caller, int64(chatId), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// @Summary Change chat role
+// @Description Change the name, rank or permissions of a custom role, only the owner can do it
+// @Tags ChatRoles
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param RoleId path int true "Role ID"
+// @Param ChangeChatRoleRequest body dto.ChangeChatRoleRequest true "Fields to change"
+// @Success 200 {object} dto.ChatRoleDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/roles/{RoleId} [patch]
+func ChangeChatRole(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+	roleId, err := strconv.Atoi(c.Param("role_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid role ID"})
+		return
+	}
+
+	var request dto.ChangeChatRoleRequest
+	if err = c.ShouldBindJSON(&request); err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+		return
+	}
+
+	service := services.NewChatRoleService(app)
+	role, err := service.ChangeRole(c.Request.Context(), caller, int64(chatId), int64(roleId), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// @Summary Delete chat role
+// @Description Delete a custom role, the members who had it become plain members. Only the owner can do it.
+// @Tags ChatRoles
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param RoleId path int true "Role ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/roles/{RoleId} [delete]
+func DeleteChatRole(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+	roleId, err := strconv.Atoi(c.Param("role_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid role ID"})
+		return
+	}
+
+	service := services.NewChatRoleService(app)
+	err = service.DeleteRole(c.Request.Context(), caller, int64(chatId), int64(roleId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
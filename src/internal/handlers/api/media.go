@@ -0,0 +1,116 @@
+package handler_api
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	domain "libs/src/internal/domain/models"
+	"libs/src/internal/storage"
+	usecase_errors "libs/src/internal/usecase/errors"
+	"libs/src/settings"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// serveObject streams a file of the storage, http.ServeContent answers the
+// conditional and range requests from the ETag and the modification time.
+func serveObject(c *gin.Context, app *settings.App, key string, cacheControl string) error {
+	info, err := app.Storage.Stat(c.Request.Context(), key)
+	if err != nil {
+		return err
+	}
+	object, err := app.Storage.Open(c.Request.Context(), key)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	c.Header("ETag", strconv.Quote(info.ETag))
+	c.Header("Cache-Control", cacheControl)
+	// Attachments are stored without extension, ServeContent sniffs the same
+	// type as the one detected on upload. The browser must not guess another one.
+	c.Header("X-Content-Type-Options", "nosniff")
+	if info.ContentType != "" {
+		c.Header("Content-Type", info.ContentType)
+	}
+	http.ServeContent(c.Writer, c.Request, path.Base(key), info.ModTime, object)
+	return nil
+}
+
+// @Summary Download file
+// @Description Serve a file of the local storage through a signed link, the links are given by other endpoints
+// @Tags Media
+// @Param Key path string true "File key"
+// @Param expires query int true "Expiration unix time"
+// @Param name query string false "Download file name"
+// @Param signature query string true "Signature"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Success 304
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /media/{Key} [get]
+func ServeMedia(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+
+	localStorage, ok := app.Storage.(*storage.LocalStorage)
+	if !ok {
+		c.Error(usecase_errors.NotFoundError{Msg: "File not found"})
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	name := c.Query("name")
+	expiresAt, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if !localStorage.Verify(key, name, expiresAt, c.Query("signature")) {
+		c.Error(usecase_errors.PermissionError{Msg: "The link is invalid or expired"})
+		return
+	}
+
+	if name != "" {
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	}
+	err := serveObject(c, app, key, "private, max-age="+strconv.FormatInt(max(expiresAt-time.Now().Unix(), 0), 10))
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			c.Error(usecase_errors.NotFoundError{Msg: "File not found"})
+			return
+		}
+		c.Error(err)
+	}
+}
+
+// @Summary Get avatar
+// @Description Serve an avatar thumbnail, the links are given in user profiles and member lists.
+// @Description An avatar link never changes content, so it can be cached forever
+// @Tags Media
+// @Produce jpeg,png
+// @Param Key path string true "Avatar key"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Success 304
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /avatars/{Key} [get]
+func ServeAvatar(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+
+	// Only the avatars are public, the other files of the storage stay out of reach
+	name := path.Clean("/" + strings.TrimPrefix(c.Param("key"), "/"))[1:]
+	cacheControl := "public, max-age=31536000, immutable"
+
+	err := serveObject(c, app, domain.AvatarsPrefix+name, cacheControl)
+	if errors.Is(err, storage.ErrObjectNotFound) && !strings.Contains(name, "/") {
+		// Avatars uploaded before the thumbnails existed lie at the root
+		err = serveObject(c, app, name, cacheControl)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			c.Error(usecase_errors.NotFoundError{Msg: "Avatar not found"})
+			return
+		}
+		c.Error(err)
+	}
+}
@@ -2,17 +2,21 @@ package handler_api
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"libs/src/internal/dto"
 	services "libs/src/internal/usecase"
 	usecase_errors "libs/src/internal/usecase/errors"
 	"libs/src/settings"
+	"net/http"
 	"strconv"
+	"time"
 )
 
 // @Summary Send message
-// @Description Send a message to a chat
+// @Description Send a message to a chat, or to the thread of a message with reply_to.
+// @Description Files are attached by sending the same fields as multipart/form-data with one or more "attachments" files
 // @Tags Messages
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Param ChatId path int true "Chat ID"
 // @Param user body dto.SendMessageRequest true "Data"
@@ -33,7 +37,11 @@ func SendMessage(c *gin.Context) {
 	}
 
 	var messageRequest dto.SendMessageRequest
-	if err := c.ShouldBindJSON(&messageRequest); err != nil {
+	bind := c.ShouldBindJSON
+	if c.ContentType() == binding.MIMEMultipartPOSTForm {
+		bind = func(obj any) error { return c.ShouldBindWith(obj, binding.FormMultipart) }
+	}
+	if err := bind(&messageRequest); err != nil {
 		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
 		return
 	}
@@ -46,3 +54,550 @@ func SendMessage(c *gin.Context) {
 	}
 	c.JSON(200, messagePreview)
 }
+
+// @Summary Get messages
+// @Description Get chat history page by page, the cursor is taken from the older_cursor/newer_cursor of a previous page. Thread replies are not included
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param cursor query string false "Position in the history, the newest messages are returned without it"
+// @Param mode query string false "before, after or around the cursor" default(before)
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.MessageHistoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/messages [get]
+func GetMessages(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	mode := c.Query("mode")
+	if mode == "" {
+		mode = "before"
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	messageService := services.NewMessageService(app)
+	history, err := messageService.GetHistory(c.Request.Context(), caller, int64(chatId), c.Query("cursor"), mode, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// @Summary Get thread
+// @Description Get a message with a page of its thread replies, paginated like the chat history
+// @Tags Messages
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Param cursor query string false "Position in the thread, the newest replies are returned without it"
+// @Param mode query string false "before, after or around the cursor" default(before)
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.MessageThreadResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId}/thread [get]
+func GetThread(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	mode := c.Query("mode")
+	if mode == "" {
+		mode = "before"
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	messageService := services.NewMessageService(app)
+	thread, err := messageService.GetThread(c.Request.Context(), caller, int64(chatId), c.Param("message_id"), c.Query("cursor"), mode, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, thread)
+}
+
+// @Summary Edit message
+// @Description Edit own message, the previous content is kept in the message revisions
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Param message body dto.EditMessageRequest true "Data"
+// @Success 200 {object} dto.MessagePreviewDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId} [patch]
+func EditMessage(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	var editRequest dto.EditMessageRequest
+	if err := c.ShouldBindJSON(&editRequest); err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+		return
+	}
+
+	messageService := services.NewMessageService(app)
+	messagePreview, err := messageService.EditMessage(c.Request.Context(), caller, int64(chatId), c.Param("message_id"), editRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, messagePreview)
+}
+
+// @Summary Delete message
+// @Description Delete a message, it stays in the history as a tombstone. Allowed to the sender and chat admins
+// @Tags Messages
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId} [delete]
+func DeleteMessage(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	messageService := services.NewMessageService(app)
+	err = messageService.DeleteMessage(c.Request.Context(), caller, int64(chatId), c.Param("message_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Get message revisions
+// @Description Get the previous contents of a message, oldest first. Only for chat admins
+// @Tags Messages
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Success 200 {array} dto.MessageRevisionDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId}/revisions [get]
+func GetMessageRevisions(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	messageService := services.NewMessageService(app)
+	revisions, err := messageService.GetRevisions(c.Request.Context(), caller, int64(chatId), c.Param("message_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, revisions)
+}
+
+// @Summary Mark messages as read
+// @Description Move the read cursor of the user to the message, everything up to it counts as read
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param data body dto.MarkReadRequest true "Data"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/read [post]
+func MarkRead(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	var readRequest dto.MarkReadRequest
+	if err := c.ShouldBindJSON(&readRequest); err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+		return
+	}
+
+	messageService := services.NewMessageService(app)
+	err = messageService.MarkRead(c.Request.Context(), caller, int64(chatId), readRequest.MessageId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Get message readers
+// @Description Get the members who have read the message
+// @Tags Messages
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Param page query int false "Page"
+// @Success 200 {array} dto.MessageReaderDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId}/seen [get]
+func GetMessageReaders(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, _ := strconv.Atoi(page)
+
+	messageService := services.NewMessageService(app)
+	readers, err := messageService.GetReaders(c.Request.Context(), caller, int64(chatId), c.Param("message_id"), pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, readers)
+}
+
+// @Summary Add reaction
+// @Description React to a message with an emoji, reacting twice with the same emoji is a no-op
+// @Tags Messages
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Param Emoji path string true "URL encoded emoji"
+// @Success 200 {array} dto.ReactionDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId}/reactions/{Emoji} [put]
+func AddReaction(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	messageService := services.NewMessageService(app)
+	reactions, err := messageService.AddReaction(c.Request.Context(), caller, int64(chatId), c.Param("message_id"), c.Param("emoji"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, reactions)
+}
+
+// @Summary Remove reaction
+// @Description Take back the reaction of the user to a message
+// @Tags Messages
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Param Emoji path string true "URL encoded emoji"
+// @Success 200 {array} dto.ReactionDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId}/reactions/{Emoji} [delete]
+func RemoveReaction(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	messageService := services.NewMessageService(app)
+	reactions, err := messageService.RemoveReaction(c.Request.Context(), caller, int64(chatId), c.Param("message_id"), c.Param("emoji"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, reactions)
+}
+
+// @Summary Download attachment
+// @Description Redirect to a short-lived signed link to a file attached to a message, only for chat members
+// @Tags Messages
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Param AttachmentId path string true "Attachment ID"
+// @Success 302
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId}/attachments/{AttachmentId} [get]
+func DownloadAttachment(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	messageService := services.NewMessageService(app)
+	url, err := messageService.GetAttachmentURL(c.Request.Context(), caller, int64(chatId), c.Param("message_id"), c.Param("attachment_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+// @Summary Pin message
+// @Description Pin a message of the chat, the pinned messages are listed with the chat info
+// @Tags Messages
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId}/pin [put]
+func PinMessage(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	messageService := services.NewMessageService(app)
+	err = messageService.PinMessage(c.Request.Context(), caller, int64(chatId), c.Param("message_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Unpin message
+// @Description Unpin a pinned message of the chat
+// @Tags Messages
+// @Produce json
+// @Param ChatId path int true "Chat ID"
+// @Param MessageId path string true "Message ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/chat/{ChatId}/message/{MessageId}/pin [delete]
+func UnpinMessage(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	chatId, err := strconv.Atoi(c.Param("chat_id"))
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+		return
+	}
+
+	messageService := services.NewMessageService(app)
+	err = messageService.UnpinMessage(c.Request.Context(), caller, int64(chatId), c.Param("message_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary My mentions
+// @Description Get the messages that mentioned the user, newest first, with the number of unread mentions
+// @Tags Messages
+// @Produce json
+// @Param page query int false "Page"
+// @Param unread query bool false "Only unread mentions"
+// @Success 200 {object} dto.MentionListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/mentions [get]
+func GetMentions(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	page := c.Query("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: "Invalid page"})
+		return
+	}
+
+	unreadOnly := false
+	if unread := c.Query("unread"); unread != "" {
+		unreadOnly, err = strconv.ParseBool(unread)
+		if err != nil {
+			c.Error(usecase_errors.BadRequestError{Msg: "Invalid unread filter"})
+			return
+		}
+	}
+
+	messageService := services.NewMessageService(app)
+	mentions, err := messageService.GetMentions(c.Request.Context(), caller, unreadOnly, pageInt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mentions)
+}
+
+// @Summary Mark mentions as read
+// @Description Mark the given mentions of the user as read, all of them when no ids are given
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param request body dto.MarkMentionsReadRequest false "Mentions to mark as read"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/mentions/read [post]
+func MarkMentionsRead(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	var request dto.MarkMentionsReadRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+			return
+		}
+	}
+
+	messageService := services.NewMessageService(app)
+	err := messageService.MarkMentionsRead(c.Request.Context(), caller, request.Ids)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "success"})
+}
+
+// @Summary Search messages
+// @Description Full-text search in the messages of the chats the user is a member of, newest first. The cursor is the next_cursor of a previous page
+// @Tags Messages
+// @Produce json
+// @Param q query string true "Words to search, \"-word\" excludes a word"
+// @Param chat_id query int false "Search only in this chat"
+// @Param sender query string false "Username of the sender"
+// @Param from query string false "Start of the period, RFC 3339"
+// @Param to query string false "End of the period, RFC 3339"
+// @Param cursor query string false "Position in the results"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.MessageSearchResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/messages/search [get]
+func SearchMessages(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	request := dto.SearchMessagesRequest{
+		Query:  c.Query("q"),
+		Sender: c.Query("sender"),
+		Cursor: c.Query("cursor"),
+	}
+	request.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	if chatId := c.Query("chat_id"); chatId != "" {
+		id, err := strconv.Atoi(chatId)
+		if err != nil {
+			c.Error(usecase_errors.BadRequestError{Msg: "Invalid chat ID"})
+			return
+		}
+		request.ChatId = int64(id)
+	}
+
+	var err error
+	if from := c.Query("from"); from != "" {
+		if request.From, err = time.Parse(time.RFC3339, from); err != nil {
+			c.Error(usecase_errors.BadRequestError{Msg: "Invalid start of the period"})
+			return
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if request.To, err = time.Parse(time.RFC3339, to); err != nil {
+			c.Error(usecase_errors.BadRequestError{Msg: "Invalid end of the period"})
+			return
+		}
+	}
+
+	messageService := services.NewMessageService(app)
+	results, err := messageService.SearchMessages(c.Request.Context(), caller, request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
@@ -66,6 +66,57 @@ func ChangeUserProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.ChangeUserProfileResponse{ChangedFields: requestData, Message: "success"})
 }
 
+// @Summary Settings
+// @Description Get the settings of the user
+// @Tags profile
+// @Produce json
+// @Success 200 {object} dto.UserSettingsDTO
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /accounts/settings [get]
+func GetUserSettings(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	user := c.MustGet("user").(dto.UserDTO)
+
+	service := services.NewUserService(app)
+	userSettings, err := service.GetSettings(c.Request.Context(), user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, userSettings)
+}
+
+// @Summary Edit settings
+// @Description Change the settings of the user, omitted settings are kept
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param settings body dto.ChangeUserSettingsRequest true "Settings"
+// @Success 200 {object} dto.UserSettingsDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /accounts/settings [patch]
+func ChangeUserSettings(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	user := c.MustGet("user").(dto.UserDTO)
+
+	var request dto.ChangeUserSettingsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(usecase_errors.BadRequestError{Msg: err.Error()})
+		return
+	}
+
+	service := services.NewUserService(app)
+	userSettings, err := service.ChangeSettings(c.Request.Context(), user, request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, userSettings)
+}
+
 // @Summary Reset password
 // @Description Reset user password
 // @Tags profile
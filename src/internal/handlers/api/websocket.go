@@ -0,0 +1,45 @@
+package handler_api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"libs/src/internal/dto"
+	"libs/src/internal/realtime"
+	services "libs/src/internal/usecase"
+	"libs/src/settings"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// @Summary Websocket
+// @Description Opens a websocket that streams message events of every chat the user is a member of
+// @Tags Websocket
+// @Success 101
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messenger/ws [get]
+func Websocket(c *gin.Context) {
+	app := c.MustGet("app").(*settings.App)
+	caller := c.MustGet("user").(dto.UserDTO)
+
+	service := services.NewChatMemberService(app)
+	chatIds, err := service.GetChatIdsForUser(c.Request.Context(), caller)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		app.Logger.Error(fmt.Sprintf("Error upgrading websocket for user %d: %v", caller.ID, err))
+		return
+	}
+
+	client := realtime.NewClient(app.Hub, conn, caller.ID)
+	app.Hub.Register(client, chatIds)
+	client.Run()
+}
@@ -0,0 +1,598 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IChatAuditRepository is an autogenerated mock type for the IChatAuditRepository type
+type IChatAuditRepository struct {
+	mock.Mock
+}
+
+type IChatAuditRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IChatAuditRepository) EXPECT() *IChatAuditRepository_Expecter {
+	return &IChatAuditRepository_Expecter{mock: &_m.Mock}
+}
+
+// Count provides a mock function with given fields: Ctx, filter, args
+func (_m *IChatAuditRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(Ctx, filter, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(Ctx, filter, args...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, filter, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatAuditRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IChatAuditRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter string
+//   - args ...interface{}
+func (_e *IChatAuditRepository_Expecter) Count(Ctx interface{}, filter interface{}, args ...interface{}) *IChatAuditRepository_Count_Call {
+	return &IChatAuditRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{Ctx, filter}, args...)...)}
+}
+
+func (_c *IChatAuditRepository_Count_Call) Run(run func(Ctx context.Context, filter string, args ...interface{})) *IChatAuditRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_Count_Call) Return(_a0 int64, _a1 error) *IChatAuditRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatAuditRepository_Count_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *IChatAuditRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IChatAuditRepository) Create(Ctx context.Context, obj *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatAuditRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IChatAuditRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.ChatAuditEntry
+func (_e *IChatAuditRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IChatAuditRepository_Create_Call {
+	return &IChatAuditRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IChatAuditRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.ChatAuditEntry)) *IChatAuditRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_Create_Call) Return(_a0 error) *IChatAuditRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatAuditRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.ChatAuditEntry) error) *IChatAuditRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IChatAuditRepository) DeleteById(Ctx context.Context, id int64) error {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatAuditRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IChatAuditRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatAuditRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IChatAuditRepository_DeleteById_Call {
+	return &IChatAuditRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IChatAuditRepository_DeleteById_Call) Run(run func(Ctx context.Context, id int64)) *IChatAuditRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_DeleteById_Call) Return(_a0 error) *IChatAuditRepository_DeleteById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatAuditRepository_DeleteById_Call) RunAndReturn(run func(context.Context, int64) error) *IChatAuditRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteQuery provides a mock function with given fields: Ctx, query, args
+func (_m *IChatAuditRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatAuditRepository_ExecuteQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteQuery'
+type IChatAuditRepository_ExecuteQuery_Call struct {
+	*mock.Call
+}
+
+// ExecuteQuery is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatAuditRepository_Expecter) ExecuteQuery(Ctx interface{}, query interface{}, args ...interface{}) *IChatAuditRepository_ExecuteQuery_Call {
+	return &IChatAuditRepository_ExecuteQuery_Call{Call: _e.mock.On("ExecuteQuery",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatAuditRepository_ExecuteQuery_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatAuditRepository_ExecuteQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_ExecuteQuery_Call) Return(_a0 error) *IChatAuditRepository_ExecuteQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatAuditRepository_ExecuteQuery_Call) RunAndReturn(run func(context.Context, string, ...interface{}) error) *IChatAuditRepository_ExecuteQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with given fields: Ctx, query, args
+func (_m *IChatAuditRepository) Filter(Ctx context.Context, query string, args ...interface{}) ([]domain.ChatAuditEntry, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 []domain.ChatAuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) ([]domain.ChatAuditEntry, error)); ok {
+		return rf(Ctx, query, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) []domain.ChatAuditEntry); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatAuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatAuditRepository_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type IChatAuditRepository_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatAuditRepository_Expecter) Filter(Ctx interface{}, query interface{}, args ...interface{}) *IChatAuditRepository_Filter_Call {
+	return &IChatAuditRepository_Filter_Call{Call: _e.mock.On("Filter",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatAuditRepository_Filter_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatAuditRepository_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_Filter_Call) Return(_a0 []domain.ChatAuditEntry, _a1 error) *IChatAuditRepository_Filter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatAuditRepository_Filter_Call) RunAndReturn(run func(context.Context, string, ...interface{}) ([]domain.ChatAuditEntry, error)) *IChatAuditRepository_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx
+func (_m *IChatAuditRepository) GetAll(Ctx context.Context) ([]domain.ChatAuditEntry, error) {
+	ret := _m.Called(Ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.ChatAuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ChatAuditEntry, error)); ok {
+		return rf(Ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ChatAuditEntry); ok {
+		r0 = rf(Ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatAuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(Ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatAuditRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IChatAuditRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+func (_e *IChatAuditRepository_Expecter) GetAll(Ctx interface{}) *IChatAuditRepository_GetAll_Call {
+	return &IChatAuditRepository_GetAll_Call{Call: _e.mock.On("GetAll", Ctx)}
+}
+
+func (_c *IChatAuditRepository_GetAll_Call) Run(run func(Ctx context.Context)) *IChatAuditRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_GetAll_Call) Return(_a0 []domain.ChatAuditEntry, _a1 error) *IChatAuditRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatAuditRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.ChatAuditEntry, error)) *IChatAuditRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: Ctx, id
+func (_m *IChatAuditRepository) GetById(Ctx context.Context, id int64) (domain.ChatAuditEntry, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 domain.ChatAuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.ChatAuditEntry, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.ChatAuditEntry); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.ChatAuditEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatAuditRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IChatAuditRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatAuditRepository_Expecter) GetById(Ctx interface{}, id interface{}) *IChatAuditRepository_GetById_Call {
+	return &IChatAuditRepository_GetById_Call{Call: _e.mock.On("GetById", Ctx, id)}
+}
+
+func (_c *IChatAuditRepository_GetById_Call) Run(run func(Ctx context.Context, id int64)) *IChatAuditRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_GetById_Call) Return(_a0 domain.ChatAuditEntry, _a1 error) *IChatAuditRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatAuditRepository_GetById_Call) RunAndReturn(run func(context.Context, int64) (domain.ChatAuditEntry, error)) *IChatAuditRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetForChat provides a mock function with given fields: Ctx, chatId, limit, offset
+func (_m *IChatAuditRepository) GetForChat(Ctx context.Context, chatId int64, limit int, offset int) ([]domain.ChatAuditEntry, error) {
+	ret := _m.Called(Ctx, chatId, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForChat")
+	}
+
+	var r0 []domain.ChatAuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) ([]domain.ChatAuditEntry, error)); ok {
+		return rf(Ctx, chatId, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.ChatAuditEntry); ok {
+		r0 = rf(Ctx, chatId, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatAuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(Ctx, chatId, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatAuditRepository_GetForChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForChat'
+type IChatAuditRepository_GetForChat_Call struct {
+	*mock.Call
+}
+
+// GetForChat is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - limit int
+//   - offset int
+func (_e *IChatAuditRepository_Expecter) GetForChat(Ctx interface{}, chatId interface{}, limit interface{}, offset interface{}) *IChatAuditRepository_GetForChat_Call {
+	return &IChatAuditRepository_GetForChat_Call{Call: _e.mock.On("GetForChat", Ctx, chatId, limit, offset)}
+}
+
+func (_c *IChatAuditRepository_GetForChat_Call) Run(run func(Ctx context.Context, chatId int64, limit int, offset int)) *IChatAuditRepository_GetForChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_GetForChat_Call) Return(_a0 []domain.ChatAuditEntry, _a1 error) *IChatAuditRepository_GetForChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatAuditRepository_GetForChat_Call) RunAndReturn(run func(context.Context, int64, int, int) ([]domain.ChatAuditEntry, error)) *IChatAuditRepository_GetForChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ManyToCreate provides a mock function with given fields: Ctx, objects
+func (_m *IChatAuditRepository) ManyToCreate(Ctx context.Context, objects []domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManyToCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatAuditRepository_ManyToCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManyToCreate'
+type IChatAuditRepository_ManyToCreate_Call struct {
+	*mock.Call
+}
+
+// ManyToCreate is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - objects []domain.ChatAuditEntry
+func (_e *IChatAuditRepository_Expecter) ManyToCreate(Ctx interface{}, objects interface{}) *IChatAuditRepository_ManyToCreate_Call {
+	return &IChatAuditRepository_ManyToCreate_Call{Call: _e.mock.On("ManyToCreate", Ctx, objects)}
+}
+
+func (_c *IChatAuditRepository_ManyToCreate_Call) Run(run func(Ctx context.Context, objects []domain.ChatAuditEntry)) *IChatAuditRepository_ManyToCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_ManyToCreate_Call) Return(_a0 error) *IChatAuditRepository_ManyToCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatAuditRepository_ManyToCreate_Call) RunAndReturn(run func(context.Context, []domain.ChatAuditEntry) error) *IChatAuditRepository_ManyToCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IChatAuditRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatAuditRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IChatAuditRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+//   - updateFields map[string]interface{}
+func (_e *IChatAuditRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IChatAuditRepository_UpdateById_Call {
+	return &IChatAuditRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IChatAuditRepository_UpdateById_Call) Run(run func(Ctx context.Context, id int64, updateFields map[string]interface{})) *IChatAuditRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *IChatAuditRepository_UpdateById_Call) Return(_a0 error) *IChatAuditRepository_UpdateById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatAuditRepository_UpdateById_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}) error) *IChatAuditRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIChatAuditRepository creates a new instance of IChatAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChatAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChatAuditRepository {
+	mock := &IChatAuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,766 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IChatInvitationRepository is an autogenerated mock type for the IChatInvitationRepository type
+type IChatInvitationRepository struct {
+	mock.Mock
+}
+
+type IChatInvitationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IChatInvitationRepository) EXPECT() *IChatInvitationRepository_Expecter {
+	return &IChatInvitationRepository_Expecter{mock: &_m.Mock}
+}
+
+// Count provides a mock function with given fields: Ctx, filter, args
+func (_m *IChatInvitationRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(Ctx, filter, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(Ctx, filter, args...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, filter, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInvitationRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IChatInvitationRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter string
+//   - args ...interface{}
+func (_e *IChatInvitationRepository_Expecter) Count(Ctx interface{}, filter interface{}, args ...interface{}) *IChatInvitationRepository_Count_Call {
+	return &IChatInvitationRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{Ctx, filter}, args...)...)}
+}
+
+func (_c *IChatInvitationRepository_Count_Call) Run(run func(Ctx context.Context, filter string, args ...interface{})) *IChatInvitationRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_Count_Call) Return(_a0 int64, _a1 error) *IChatInvitationRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_Count_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *IChatInvitationRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IChatInvitationRepository) Create(Ctx context.Context, obj *domain.ChatInvitation) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatInvitation) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInvitationRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IChatInvitationRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.ChatInvitation
+func (_e *IChatInvitationRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IChatInvitationRepository_Create_Call {
+	return &IChatInvitationRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IChatInvitationRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.ChatInvitation)) *IChatInvitationRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatInvitation))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_Create_Call) Return(_a0 error) *IChatInvitationRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.ChatInvitation) error) *IChatInvitationRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IChatInvitationRepository) DeleteById(Ctx context.Context, id int64) error {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInvitationRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IChatInvitationRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatInvitationRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IChatInvitationRepository_DeleteById_Call {
+	return &IChatInvitationRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IChatInvitationRepository_DeleteById_Call) Run(run func(Ctx context.Context, id int64)) *IChatInvitationRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_DeleteById_Call) Return(_a0 error) *IChatInvitationRepository_DeleteById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_DeleteById_Call) RunAndReturn(run func(context.Context, int64) error) *IChatInvitationRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteQuery provides a mock function with given fields: Ctx, query, args
+func (_m *IChatInvitationRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInvitationRepository_ExecuteQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteQuery'
+type IChatInvitationRepository_ExecuteQuery_Call struct {
+	*mock.Call
+}
+
+// ExecuteQuery is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatInvitationRepository_Expecter) ExecuteQuery(Ctx interface{}, query interface{}, args ...interface{}) *IChatInvitationRepository_ExecuteQuery_Call {
+	return &IChatInvitationRepository_ExecuteQuery_Call{Call: _e.mock.On("ExecuteQuery",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatInvitationRepository_ExecuteQuery_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatInvitationRepository_ExecuteQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_ExecuteQuery_Call) Return(_a0 error) *IChatInvitationRepository_ExecuteQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_ExecuteQuery_Call) RunAndReturn(run func(context.Context, string, ...interface{}) error) *IChatInvitationRepository_ExecuteQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with given fields: Ctx, query, args
+func (_m *IChatInvitationRepository) Filter(Ctx context.Context, query string, args ...interface{}) ([]domain.ChatInvitation, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 []domain.ChatInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) ([]domain.ChatInvitation, error)); ok {
+		return rf(Ctx, query, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) []domain.ChatInvitation); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInvitationRepository_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type IChatInvitationRepository_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatInvitationRepository_Expecter) Filter(Ctx interface{}, query interface{}, args ...interface{}) *IChatInvitationRepository_Filter_Call {
+	return &IChatInvitationRepository_Filter_Call{Call: _e.mock.On("Filter",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatInvitationRepository_Filter_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatInvitationRepository_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_Filter_Call) Return(_a0 []domain.ChatInvitation, _a1 error) *IChatInvitationRepository_Filter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_Filter_Call) RunAndReturn(run func(context.Context, string, ...interface{}) ([]domain.ChatInvitation, error)) *IChatInvitationRepository_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx
+func (_m *IChatInvitationRepository) GetAll(Ctx context.Context) ([]domain.ChatInvitation, error) {
+	ret := _m.Called(Ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.ChatInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ChatInvitation, error)); ok {
+		return rf(Ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ChatInvitation); ok {
+		r0 = rf(Ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(Ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInvitationRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IChatInvitationRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+func (_e *IChatInvitationRepository_Expecter) GetAll(Ctx interface{}) *IChatInvitationRepository_GetAll_Call {
+	return &IChatInvitationRepository_GetAll_Call{Call: _e.mock.On("GetAll", Ctx)}
+}
+
+func (_c *IChatInvitationRepository_GetAll_Call) Run(run func(Ctx context.Context)) *IChatInvitationRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetAll_Call) Return(_a0 []domain.ChatInvitation, _a1 error) *IChatInvitationRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.ChatInvitation, error)) *IChatInvitationRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: Ctx, id
+func (_m *IChatInvitationRepository) GetById(Ctx context.Context, id int64) (domain.ChatInvitation, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 domain.ChatInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.ChatInvitation, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.ChatInvitation); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.ChatInvitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInvitationRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IChatInvitationRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatInvitationRepository_Expecter) GetById(Ctx interface{}, id interface{}) *IChatInvitationRepository_GetById_Call {
+	return &IChatInvitationRepository_GetById_Call{Call: _e.mock.On("GetById", Ctx, id)}
+}
+
+func (_c *IChatInvitationRepository_GetById_Call) Run(run func(Ctx context.Context, id int64)) *IChatInvitationRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetById_Call) Return(_a0 domain.ChatInvitation, _a1 error) *IChatInvitationRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetById_Call) RunAndReturn(run func(context.Context, int64) (domain.ChatInvitation, error)) *IChatInvitationRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInbox provides a mock function with given fields: Ctx, inviteeId, limit, offset
+func (_m *IChatInvitationRepository) GetInbox(Ctx context.Context, inviteeId int64, limit int, offset int) ([]domain.ChatInvitation, error) {
+	ret := _m.Called(Ctx, inviteeId, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInbox")
+	}
+
+	var r0 []domain.ChatInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) ([]domain.ChatInvitation, error)); ok {
+		return rf(Ctx, inviteeId, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.ChatInvitation); ok {
+		r0 = rf(Ctx, inviteeId, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(Ctx, inviteeId, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInvitationRepository_GetInbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInbox'
+type IChatInvitationRepository_GetInbox_Call struct {
+	*mock.Call
+}
+
+// GetInbox is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - inviteeId int64
+//   - limit int
+//   - offset int
+func (_e *IChatInvitationRepository_Expecter) GetInbox(Ctx interface{}, inviteeId interface{}, limit interface{}, offset interface{}) *IChatInvitationRepository_GetInbox_Call {
+	return &IChatInvitationRepository_GetInbox_Call{Call: _e.mock.On("GetInbox", Ctx, inviteeId, limit, offset)}
+}
+
+func (_c *IChatInvitationRepository_GetInbox_Call) Run(run func(Ctx context.Context, inviteeId int64, limit int, offset int)) *IChatInvitationRepository_GetInbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetInbox_Call) Return(_a0 []domain.ChatInvitation, _a1 error) *IChatInvitationRepository_GetInbox_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetInbox_Call) RunAndReturn(run func(context.Context, int64, int, int) ([]domain.ChatInvitation, error)) *IChatInvitationRepository_GetInbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPending provides a mock function with given fields: Ctx, invitationId, inviteeId
+func (_m *IChatInvitationRepository) GetPending(Ctx context.Context, invitationId int64, inviteeId int64) (domain.ChatInvitation, error) {
+	ret := _m.Called(Ctx, invitationId, inviteeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPending")
+	}
+
+	var r0 domain.ChatInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.ChatInvitation, error)); ok {
+		return rf(Ctx, invitationId, inviteeId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.ChatInvitation); ok {
+		r0 = rf(Ctx, invitationId, inviteeId)
+	} else {
+		r0 = ret.Get(0).(domain.ChatInvitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(Ctx, invitationId, inviteeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInvitationRepository_GetPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPending'
+type IChatInvitationRepository_GetPending_Call struct {
+	*mock.Call
+}
+
+// GetPending is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - invitationId int64
+//   - inviteeId int64
+func (_e *IChatInvitationRepository_Expecter) GetPending(Ctx interface{}, invitationId interface{}, inviteeId interface{}) *IChatInvitationRepository_GetPending_Call {
+	return &IChatInvitationRepository_GetPending_Call{Call: _e.mock.On("GetPending", Ctx, invitationId, inviteeId)}
+}
+
+func (_c *IChatInvitationRepository_GetPending_Call) Run(run func(Ctx context.Context, invitationId int64, inviteeId int64)) *IChatInvitationRepository_GetPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetPending_Call) Return(_a0 domain.ChatInvitation, _a1 error) *IChatInvitationRepository_GetPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetPending_Call) RunAndReturn(run func(context.Context, int64, int64) (domain.ChatInvitation, error)) *IChatInvitationRepository_GetPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSent provides a mock function with given fields: Ctx, inviterId, limit, offset
+func (_m *IChatInvitationRepository) GetSent(Ctx context.Context, inviterId int64, limit int, offset int) ([]domain.ChatInvitation, error) {
+	ret := _m.Called(Ctx, inviterId, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSent")
+	}
+
+	var r0 []domain.ChatInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) ([]domain.ChatInvitation, error)); ok {
+		return rf(Ctx, inviterId, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.ChatInvitation); ok {
+		r0 = rf(Ctx, inviterId, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(Ctx, inviterId, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInvitationRepository_GetSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSent'
+type IChatInvitationRepository_GetSent_Call struct {
+	*mock.Call
+}
+
+// GetSent is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - inviterId int64
+//   - limit int
+//   - offset int
+func (_e *IChatInvitationRepository_Expecter) GetSent(Ctx interface{}, inviterId interface{}, limit interface{}, offset interface{}) *IChatInvitationRepository_GetSent_Call {
+	return &IChatInvitationRepository_GetSent_Call{Call: _e.mock.On("GetSent", Ctx, inviterId, limit, offset)}
+}
+
+func (_c *IChatInvitationRepository_GetSent_Call) Run(run func(Ctx context.Context, inviterId int64, limit int, offset int)) *IChatInvitationRepository_GetSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetSent_Call) Return(_a0 []domain.ChatInvitation, _a1 error) *IChatInvitationRepository_GetSent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_GetSent_Call) RunAndReturn(run func(context.Context, int64, int, int) ([]domain.ChatInvitation, error)) *IChatInvitationRepository_GetSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ManyToCreate provides a mock function with given fields: Ctx, objects
+func (_m *IChatInvitationRepository) ManyToCreate(Ctx context.Context, objects []domain.ChatInvitation) error {
+	ret := _m.Called(Ctx, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManyToCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ChatInvitation) error); ok {
+		r0 = rf(Ctx, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInvitationRepository_ManyToCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManyToCreate'
+type IChatInvitationRepository_ManyToCreate_Call struct {
+	*mock.Call
+}
+
+// ManyToCreate is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - objects []domain.ChatInvitation
+func (_e *IChatInvitationRepository_Expecter) ManyToCreate(Ctx interface{}, objects interface{}) *IChatInvitationRepository_ManyToCreate_Call {
+	return &IChatInvitationRepository_ManyToCreate_Call{Call: _e.mock.On("ManyToCreate", Ctx, objects)}
+}
+
+func (_c *IChatInvitationRepository_ManyToCreate_Call) Run(run func(Ctx context.Context, objects []domain.ChatInvitation)) *IChatInvitationRepository_ManyToCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.ChatInvitation))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_ManyToCreate_Call) Return(_a0 error) *IChatInvitationRepository_ManyToCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_ManyToCreate_Call) RunAndReturn(run func(context.Context, []domain.ChatInvitation) error) *IChatInvitationRepository_ManyToCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Respond provides a mock function with given fields: Ctx, invitationId, inviteeId, status
+func (_m *IChatInvitationRepository) Respond(Ctx context.Context, invitationId int64, inviteeId int64, status byte) error {
+	ret := _m.Called(Ctx, invitationId, inviteeId, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Respond")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, byte) error); ok {
+		r0 = rf(Ctx, invitationId, inviteeId, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInvitationRepository_Respond_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Respond'
+type IChatInvitationRepository_Respond_Call struct {
+	*mock.Call
+}
+
+// Respond is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - invitationId int64
+//   - inviteeId int64
+//   - status byte
+func (_e *IChatInvitationRepository_Expecter) Respond(Ctx interface{}, invitationId interface{}, inviteeId interface{}, status interface{}) *IChatInvitationRepository_Respond_Call {
+	return &IChatInvitationRepository_Respond_Call{Call: _e.mock.On("Respond", Ctx, invitationId, inviteeId, status)}
+}
+
+func (_c *IChatInvitationRepository_Respond_Call) Run(run func(Ctx context.Context, invitationId int64, inviteeId int64, status byte)) *IChatInvitationRepository_Respond_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(byte))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_Respond_Call) Return(_a0 error) *IChatInvitationRepository_Respond_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_Respond_Call) RunAndReturn(run func(context.Context, int64, int64, byte) error) *IChatInvitationRepository_Respond_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IChatInvitationRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInvitationRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IChatInvitationRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+//   - updateFields map[string]interface{}
+func (_e *IChatInvitationRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IChatInvitationRepository_UpdateById_Call {
+	return &IChatInvitationRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IChatInvitationRepository_UpdateById_Call) Run(run func(Ctx context.Context, id int64, updateFields map[string]interface{})) *IChatInvitationRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *IChatInvitationRepository_UpdateById_Call) Return(_a0 error) *IChatInvitationRepository_UpdateById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInvitationRepository_UpdateById_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}) error) *IChatInvitationRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIChatInvitationRepository creates a new instance of IChatInvitationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChatInvitationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChatInvitationRepository {
+	mock := &IChatInvitationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,795 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IChatInviteRepository is an autogenerated mock type for the IChatInviteRepository type
+type IChatInviteRepository struct {
+	mock.Mock
+}
+
+type IChatInviteRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IChatInviteRepository) EXPECT() *IChatInviteRepository_Expecter {
+	return &IChatInviteRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: Ctx, inviteId
+func (_m *IChatInviteRepository) Consume(Ctx context.Context, inviteId int64) error {
+	ret := _m.Called(Ctx, inviteId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, inviteId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInviteRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type IChatInviteRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - inviteId int64
+func (_e *IChatInviteRepository_Expecter) Consume(Ctx interface{}, inviteId interface{}) *IChatInviteRepository_Consume_Call {
+	return &IChatInviteRepository_Consume_Call{Call: _e.mock.On("Consume", Ctx, inviteId)}
+}
+
+func (_c *IChatInviteRepository_Consume_Call) Run(run func(Ctx context.Context, inviteId int64)) *IChatInviteRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_Consume_Call) Return(_a0 error) *IChatInviteRepository_Consume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInviteRepository_Consume_Call) RunAndReturn(run func(context.Context, int64) error) *IChatInviteRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function with given fields: Ctx, filter, args
+func (_m *IChatInviteRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(Ctx, filter, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(Ctx, filter, args...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, filter, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInviteRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IChatInviteRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter string
+//   - args ...interface{}
+func (_e *IChatInviteRepository_Expecter) Count(Ctx interface{}, filter interface{}, args ...interface{}) *IChatInviteRepository_Count_Call {
+	return &IChatInviteRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{Ctx, filter}, args...)...)}
+}
+
+func (_c *IChatInviteRepository_Count_Call) Run(run func(Ctx context.Context, filter string, args ...interface{})) *IChatInviteRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_Count_Call) Return(_a0 int64, _a1 error) *IChatInviteRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInviteRepository_Count_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *IChatInviteRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IChatInviteRepository) Create(Ctx context.Context, obj *domain.ChatInvite) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatInvite) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInviteRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IChatInviteRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.ChatInvite
+func (_e *IChatInviteRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IChatInviteRepository_Create_Call {
+	return &IChatInviteRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IChatInviteRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.ChatInvite)) *IChatInviteRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatInvite))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_Create_Call) Return(_a0 error) *IChatInviteRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInviteRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.ChatInvite) error) *IChatInviteRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IChatInviteRepository) DeleteById(Ctx context.Context, id int64) error {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInviteRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IChatInviteRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatInviteRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IChatInviteRepository_DeleteById_Call {
+	return &IChatInviteRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IChatInviteRepository_DeleteById_Call) Run(run func(Ctx context.Context, id int64)) *IChatInviteRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_DeleteById_Call) Return(_a0 error) *IChatInviteRepository_DeleteById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInviteRepository_DeleteById_Call) RunAndReturn(run func(context.Context, int64) error) *IChatInviteRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteQuery provides a mock function with given fields: Ctx, query, args
+func (_m *IChatInviteRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInviteRepository_ExecuteQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteQuery'
+type IChatInviteRepository_ExecuteQuery_Call struct {
+	*mock.Call
+}
+
+// ExecuteQuery is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatInviteRepository_Expecter) ExecuteQuery(Ctx interface{}, query interface{}, args ...interface{}) *IChatInviteRepository_ExecuteQuery_Call {
+	return &IChatInviteRepository_ExecuteQuery_Call{Call: _e.mock.On("ExecuteQuery",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatInviteRepository_ExecuteQuery_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatInviteRepository_ExecuteQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_ExecuteQuery_Call) Return(_a0 error) *IChatInviteRepository_ExecuteQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInviteRepository_ExecuteQuery_Call) RunAndReturn(run func(context.Context, string, ...interface{}) error) *IChatInviteRepository_ExecuteQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with given fields: Ctx, query, args
+func (_m *IChatInviteRepository) Filter(Ctx context.Context, query string, args ...interface{}) ([]domain.ChatInvite, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 []domain.ChatInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) ([]domain.ChatInvite, error)); ok {
+		return rf(Ctx, query, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) []domain.ChatInvite); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatInvite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInviteRepository_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type IChatInviteRepository_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatInviteRepository_Expecter) Filter(Ctx interface{}, query interface{}, args ...interface{}) *IChatInviteRepository_Filter_Call {
+	return &IChatInviteRepository_Filter_Call{Call: _e.mock.On("Filter",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatInviteRepository_Filter_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatInviteRepository_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_Filter_Call) Return(_a0 []domain.ChatInvite, _a1 error) *IChatInviteRepository_Filter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInviteRepository_Filter_Call) RunAndReturn(run func(context.Context, string, ...interface{}) ([]domain.ChatInvite, error)) *IChatInviteRepository_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx
+func (_m *IChatInviteRepository) GetAll(Ctx context.Context) ([]domain.ChatInvite, error) {
+	ret := _m.Called(Ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.ChatInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ChatInvite, error)); ok {
+		return rf(Ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ChatInvite); ok {
+		r0 = rf(Ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatInvite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(Ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInviteRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IChatInviteRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+func (_e *IChatInviteRepository_Expecter) GetAll(Ctx interface{}) *IChatInviteRepository_GetAll_Call {
+	return &IChatInviteRepository_GetAll_Call{Call: _e.mock.On("GetAll", Ctx)}
+}
+
+func (_c *IChatInviteRepository_GetAll_Call) Run(run func(Ctx context.Context)) *IChatInviteRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_GetAll_Call) Return(_a0 []domain.ChatInvite, _a1 error) *IChatInviteRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInviteRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.ChatInvite, error)) *IChatInviteRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: Ctx, id
+func (_m *IChatInviteRepository) GetById(Ctx context.Context, id int64) (domain.ChatInvite, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 domain.ChatInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.ChatInvite, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.ChatInvite); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.ChatInvite)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInviteRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IChatInviteRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatInviteRepository_Expecter) GetById(Ctx interface{}, id interface{}) *IChatInviteRepository_GetById_Call {
+	return &IChatInviteRepository_GetById_Call{Call: _e.mock.On("GetById", Ctx, id)}
+}
+
+func (_c *IChatInviteRepository_GetById_Call) Run(run func(Ctx context.Context, id int64)) *IChatInviteRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_GetById_Call) Return(_a0 domain.ChatInvite, _a1 error) *IChatInviteRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInviteRepository_GetById_Call) RunAndReturn(run func(context.Context, int64) (domain.ChatInvite, error)) *IChatInviteRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByToken provides a mock function with given fields: Ctx, token
+func (_m *IChatInviteRepository) GetByToken(Ctx context.Context, token string) (domain.ChatInvite, error) {
+	ret := _m.Called(Ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByToken")
+	}
+
+	var r0 domain.ChatInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.ChatInvite, error)); ok {
+		return rf(Ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.ChatInvite); ok {
+		r0 = rf(Ctx, token)
+	} else {
+		r0 = ret.Get(0).(domain.ChatInvite)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(Ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInviteRepository_GetByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByToken'
+type IChatInviteRepository_GetByToken_Call struct {
+	*mock.Call
+}
+
+// GetByToken is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - token string
+func (_e *IChatInviteRepository_Expecter) GetByToken(Ctx interface{}, token interface{}) *IChatInviteRepository_GetByToken_Call {
+	return &IChatInviteRepository_GetByToken_Call{Call: _e.mock.On("GetByToken", Ctx, token)}
+}
+
+func (_c *IChatInviteRepository_GetByToken_Call) Run(run func(Ctx context.Context, token string)) *IChatInviteRepository_GetByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_GetByToken_Call) Return(_a0 domain.ChatInvite, _a1 error) *IChatInviteRepository_GetByToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInviteRepository_GetByToken_Call) RunAndReturn(run func(context.Context, string) (domain.ChatInvite, error)) *IChatInviteRepository_GetByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListForChat provides a mock function with given fields: Ctx, chatId
+func (_m *IChatInviteRepository) GetListForChat(Ctx context.Context, chatId int64) ([]domain.ChatInvite, error) {
+	ret := _m.Called(Ctx, chatId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListForChat")
+	}
+
+	var r0 []domain.ChatInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.ChatInvite, error)); ok {
+		return rf(Ctx, chatId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.ChatInvite); ok {
+		r0 = rf(Ctx, chatId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatInvite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, chatId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatInviteRepository_GetListForChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListForChat'
+type IChatInviteRepository_GetListForChat_Call struct {
+	*mock.Call
+}
+
+// GetListForChat is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+func (_e *IChatInviteRepository_Expecter) GetListForChat(Ctx interface{}, chatId interface{}) *IChatInviteRepository_GetListForChat_Call {
+	return &IChatInviteRepository_GetListForChat_Call{Call: _e.mock.On("GetListForChat", Ctx, chatId)}
+}
+
+func (_c *IChatInviteRepository_GetListForChat_Call) Run(run func(Ctx context.Context, chatId int64)) *IChatInviteRepository_GetListForChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_GetListForChat_Call) Return(_a0 []domain.ChatInvite, _a1 error) *IChatInviteRepository_GetListForChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatInviteRepository_GetListForChat_Call) RunAndReturn(run func(context.Context, int64) ([]domain.ChatInvite, error)) *IChatInviteRepository_GetListForChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ManyToCreate provides a mock function with given fields: Ctx, objects
+func (_m *IChatInviteRepository) ManyToCreate(Ctx context.Context, objects []domain.ChatInvite) error {
+	ret := _m.Called(Ctx, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManyToCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ChatInvite) error); ok {
+		r0 = rf(Ctx, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInviteRepository_ManyToCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManyToCreate'
+type IChatInviteRepository_ManyToCreate_Call struct {
+	*mock.Call
+}
+
+// ManyToCreate is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - objects []domain.ChatInvite
+func (_e *IChatInviteRepository_Expecter) ManyToCreate(Ctx interface{}, objects interface{}) *IChatInviteRepository_ManyToCreate_Call {
+	return &IChatInviteRepository_ManyToCreate_Call{Call: _e.mock.On("ManyToCreate", Ctx, objects)}
+}
+
+func (_c *IChatInviteRepository_ManyToCreate_Call) Run(run func(Ctx context.Context, objects []domain.ChatInvite)) *IChatInviteRepository_ManyToCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.ChatInvite))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_ManyToCreate_Call) Return(_a0 error) *IChatInviteRepository_ManyToCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInviteRepository_ManyToCreate_Call) RunAndReturn(run func(context.Context, []domain.ChatInvite) error) *IChatInviteRepository_ManyToCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: Ctx, inviteId
+func (_m *IChatInviteRepository) Release(Ctx context.Context, inviteId int64) error {
+	ret := _m.Called(Ctx, inviteId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, inviteId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInviteRepository_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type IChatInviteRepository_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - inviteId int64
+func (_e *IChatInviteRepository_Expecter) Release(Ctx interface{}, inviteId interface{}) *IChatInviteRepository_Release_Call {
+	return &IChatInviteRepository_Release_Call{Call: _e.mock.On("Release", Ctx, inviteId)}
+}
+
+func (_c *IChatInviteRepository_Release_Call) Run(run func(Ctx context.Context, inviteId int64)) *IChatInviteRepository_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_Release_Call) Return(_a0 error) *IChatInviteRepository_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInviteRepository_Release_Call) RunAndReturn(run func(context.Context, int64) error) *IChatInviteRepository_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: Ctx, chatId, inviteId
+func (_m *IChatInviteRepository) Revoke(Ctx context.Context, chatId int64, inviteId int64) error {
+	ret := _m.Called(Ctx, chatId, inviteId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(Ctx, chatId, inviteId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInviteRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type IChatInviteRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - inviteId int64
+func (_e *IChatInviteRepository_Expecter) Revoke(Ctx interface{}, chatId interface{}, inviteId interface{}) *IChatInviteRepository_Revoke_Call {
+	return &IChatInviteRepository_Revoke_Call{Call: _e.mock.On("Revoke", Ctx, chatId, inviteId)}
+}
+
+func (_c *IChatInviteRepository_Revoke_Call) Run(run func(Ctx context.Context, chatId int64, inviteId int64)) *IChatInviteRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_Revoke_Call) Return(_a0 error) *IChatInviteRepository_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInviteRepository_Revoke_Call) RunAndReturn(run func(context.Context, int64, int64) error) *IChatInviteRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IChatInviteRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatInviteRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IChatInviteRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+//   - updateFields map[string]interface{}
+func (_e *IChatInviteRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IChatInviteRepository_UpdateById_Call {
+	return &IChatInviteRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IChatInviteRepository_UpdateById_Call) Run(run func(Ctx context.Context, id int64, updateFields map[string]interface{})) *IChatInviteRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *IChatInviteRepository_UpdateById_Call) Return(_a0 error) *IChatInviteRepository_UpdateById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatInviteRepository_UpdateById_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}) error) *IChatInviteRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIChatInviteRepository creates a new instance of IChatInviteRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChatInviteRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChatInviteRepository {
+	mock := &IChatInviteRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
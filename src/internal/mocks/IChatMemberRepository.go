@@ -8,6 +8,8 @@ import (
 	dto "libs/src/internal/dto"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // IChatMemberRepository is an autogenerated mock type for the IChatMemberRepository type
@@ -23,6 +25,66 @@ func (_m *IChatMemberRepository) EXPECT() *IChatMemberRepository_Expecter {
 	return &IChatMemberRepository_Expecter{mock: &_m.Mock}
 }
 
+// AdvanceReadCursor provides a mock function with given fields: Ctx, chatId, userId, messageId, createdAt
+func (_m *IChatMemberRepository) AdvanceReadCursor(Ctx context.Context, chatId int64, userId int64, messageId string, createdAt time.Time) (bool, error) {
+	ret := _m.Called(Ctx, chatId, userId, messageId, createdAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdvanceReadCursor")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, time.Time) (bool, error)); ok {
+		return rf(Ctx, chatId, userId, messageId, createdAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, time.Time) bool); ok {
+		r0 = rf(Ctx, chatId, userId, messageId, createdAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, string, time.Time) error); ok {
+		r1 = rf(Ctx, chatId, userId, messageId, createdAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatMemberRepository_AdvanceReadCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdvanceReadCursor'
+type IChatMemberRepository_AdvanceReadCursor_Call struct {
+	*mock.Call
+}
+
+// AdvanceReadCursor is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - userId int64
+//   - messageId string
+//   - createdAt time.Time
+func (_e *IChatMemberRepository_Expecter) AdvanceReadCursor(Ctx interface{}, chatId interface{}, userId interface{}, messageId interface{}, createdAt interface{}) *IChatMemberRepository_AdvanceReadCursor_Call {
+	return &IChatMemberRepository_AdvanceReadCursor_Call{Call: _e.mock.On("AdvanceReadCursor", Ctx, chatId, userId, messageId, createdAt)}
+}
+
+func (_c *IChatMemberRepository_AdvanceReadCursor_Call) Run(run func(Ctx context.Context, chatId int64, userId int64, messageId string, createdAt time.Time)) *IChatMemberRepository_AdvanceReadCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IChatMemberRepository_AdvanceReadCursor_Call) Return(_a0 bool, _a1 error) *IChatMemberRepository_AdvanceReadCursor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatMemberRepository_AdvanceReadCursor_Call) RunAndReturn(run func(context.Context, int64, int64, string, time.Time) (bool, error)) *IChatMemberRepository_AdvanceReadCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Count provides a mock function with given fields: Ctx, filter, args
 func (_m *IChatMemberRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
 	var _ca []interface{}
@@ -185,17 +247,17 @@ func (_c *IChatMemberRepository_DeleteById_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
-// DeleteMember provides a mock function with given fields: Ctx, memberId, chatId
-func (_m *IChatMemberRepository) DeleteMember(Ctx context.Context, memberId int64, chatId int64) error {
-	ret := _m.Called(Ctx, memberId, chatId)
+// DeleteMember provides a mock function with given fields: Ctx, memberId, chatId, audit
+func (_m *IChatMemberRepository) DeleteMember(Ctx context.Context, memberId int64, chatId int64, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, memberId, chatId, audit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteMember")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
-		r0 = rf(Ctx, memberId, chatId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, memberId, chatId, audit)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -212,13 +274,14 @@ type IChatMemberRepository_DeleteMember_Call struct {
 //   - Ctx context.Context
 //   - memberId int64
 //   - chatId int64
-func (_e *IChatMemberRepository_Expecter) DeleteMember(Ctx interface{}, memberId interface{}, chatId interface{}) *IChatMemberRepository_DeleteMember_Call {
-	return &IChatMemberRepository_DeleteMember_Call{Call: _e.mock.On("DeleteMember", Ctx, memberId, chatId)}
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatMemberRepository_Expecter) DeleteMember(Ctx interface{}, memberId interface{}, chatId interface{}, audit interface{}) *IChatMemberRepository_DeleteMember_Call {
+	return &IChatMemberRepository_DeleteMember_Call{Call: _e.mock.On("DeleteMember", Ctx, memberId, chatId, audit)}
 }
 
-func (_c *IChatMemberRepository_DeleteMember_Call) Run(run func(Ctx context.Context, memberId int64, chatId int64)) *IChatMemberRepository_DeleteMember_Call {
+func (_c *IChatMemberRepository_DeleteMember_Call) Run(run func(Ctx context.Context, memberId int64, chatId int64, audit *domain.ChatAuditEntry)) *IChatMemberRepository_DeleteMember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(*domain.ChatAuditEntry))
 	})
 	return _c
 }
@@ -228,7 +291,7 @@ func (_c *IChatMemberRepository_DeleteMember_Call) Return(_a0 error) *IChatMembe
 	return _c
 }
 
-func (_c *IChatMemberRepository_DeleteMember_Call) RunAndReturn(run func(context.Context, int64, int64) error) *IChatMemberRepository_DeleteMember_Call {
+func (_c *IChatMemberRepository_DeleteMember_Call) RunAndReturn(run func(context.Context, int64, int64, *domain.ChatAuditEntry) error) *IChatMemberRepository_DeleteMember_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -476,6 +539,66 @@ func (_c *IChatMemberRepository_GetById_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// GetDirectPeers provides a mock function with given fields: Ctx, userId, chatIds
+func (_m *IChatMemberRepository) GetDirectPeers(Ctx context.Context, userId int64, chatIds []int64) (map[int64]dto.ChatPeerDTO, error) {
+	ret := _m.Called(Ctx, userId, chatIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDirectPeers")
+	}
+
+	var r0 map[int64]dto.ChatPeerDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64) (map[int64]dto.ChatPeerDTO, error)); ok {
+		return rf(Ctx, userId, chatIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64) map[int64]dto.ChatPeerDTO); ok {
+		r0 = rf(Ctx, userId, chatIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]dto.ChatPeerDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []int64) error); ok {
+		r1 = rf(Ctx, userId, chatIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatMemberRepository_GetDirectPeers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDirectPeers'
+type IChatMemberRepository_GetDirectPeers_Call struct {
+	*mock.Call
+}
+
+// GetDirectPeers is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - userId int64
+//   - chatIds []int64
+func (_e *IChatMemberRepository_Expecter) GetDirectPeers(Ctx interface{}, userId interface{}, chatIds interface{}) *IChatMemberRepository_GetDirectPeers_Call {
+	return &IChatMemberRepository_GetDirectPeers_Call{Call: _e.mock.On("GetDirectPeers", Ctx, userId, chatIds)}
+}
+
+func (_c *IChatMemberRepository_GetDirectPeers_Call) Run(run func(Ctx context.Context, userId int64, chatIds []int64)) *IChatMemberRepository_GetDirectPeers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]int64))
+	})
+	return _c
+}
+
+func (_c *IChatMemberRepository_GetDirectPeers_Call) Return(_a0 map[int64]dto.ChatPeerDTO, _a1 error) *IChatMemberRepository_GetDirectPeers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatMemberRepository_GetDirectPeers_Call) RunAndReturn(run func(context.Context, int64, []int64) (map[int64]dto.ChatPeerDTO, error)) *IChatMemberRepository_GetDirectPeers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMemberInfo provides a mock function with given fields: Ctx, memberId, chatId
 func (_m *IChatMemberRepository) GetMemberInfo(Ctx context.Context, memberId int64, chatId int64) (dto.MemberInfo, error) {
 	ret := _m.Called(Ctx, memberId, chatId)
@@ -596,6 +719,70 @@ func (_c *IChatMemberRepository_GetMembersPreview_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// GetReaders provides a mock function with given fields: Ctx, chatId, senderId, messageId, createdAt, limit, offset
+func (_m *IChatMemberRepository) GetReaders(Ctx context.Context, chatId int64, senderId int64, messageId string, createdAt time.Time, limit int, offset int) ([]dto.MessageReaderDTO, error) {
+	ret := _m.Called(Ctx, chatId, senderId, messageId, createdAt, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReaders")
+	}
+
+	var r0 []dto.MessageReaderDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, time.Time, int, int) ([]dto.MessageReaderDTO, error)); ok {
+		return rf(Ctx, chatId, senderId, messageId, createdAt, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, time.Time, int, int) []dto.MessageReaderDTO); ok {
+		r0 = rf(Ctx, chatId, senderId, messageId, createdAt, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.MessageReaderDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, string, time.Time, int, int) error); ok {
+		r1 = rf(Ctx, chatId, senderId, messageId, createdAt, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatMemberRepository_GetReaders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReaders'
+type IChatMemberRepository_GetReaders_Call struct {
+	*mock.Call
+}
+
+// GetReaders is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - senderId int64
+//   - messageId string
+//   - createdAt time.Time
+//   - limit int
+//   - offset int
+func (_e *IChatMemberRepository_Expecter) GetReaders(Ctx interface{}, chatId interface{}, senderId interface{}, messageId interface{}, createdAt interface{}, limit interface{}, offset interface{}) *IChatMemberRepository_GetReaders_Call {
+	return &IChatMemberRepository_GetReaders_Call{Call: _e.mock.On("GetReaders", Ctx, chatId, senderId, messageId, createdAt, limit, offset)}
+}
+
+func (_c *IChatMemberRepository_GetReaders_Call) Run(run func(Ctx context.Context, chatId int64, senderId int64, messageId string, createdAt time.Time, limit int, offset int)) *IChatMemberRepository_GetReaders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(time.Time), args[5].(int), args[6].(int))
+	})
+	return _c
+}
+
+func (_c *IChatMemberRepository_GetReaders_Call) Return(_a0 []dto.MessageReaderDTO, _a1 error) *IChatMemberRepository_GetReaders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatMemberRepository_GetReaders_Call) RunAndReturn(run func(context.Context, int64, int64, string, time.Time, int, int) ([]dto.MessageReaderDTO, error)) *IChatMemberRepository_GetReaders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ManyToCreate provides a mock function with given fields: Ctx, objects
 func (_m *IChatMemberRepository) ManyToCreate(Ctx context.Context, objects []domain.ChatMember) error {
 	ret := _m.Called(Ctx, objects)
@@ -643,17 +830,17 @@ func (_c *IChatMemberRepository_ManyToCreate_Call) RunAndReturn(run func(context
 	return _c
 }
 
-// SetNewRole provides a mock function with given fields: Ctx, chatId, userId, role
-func (_m *IChatMemberRepository) SetNewRole(Ctx context.Context, chatId int64, userId int64, role byte) error {
-	ret := _m.Called(Ctx, chatId, userId, role)
+// SetNewRole provides a mock function with given fields: Ctx, chatId, userId, role, roleId, audit
+func (_m *IChatMemberRepository) SetNewRole(Ctx context.Context, chatId int64, userId int64, role byte, roleId *int64, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, chatId, userId, role, roleId, audit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetNewRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, byte) error); ok {
-		r0 = rf(Ctx, chatId, userId, role)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, byte, *int64, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, chatId, userId, role, roleId, audit)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -671,13 +858,15 @@ type IChatMemberRepository_SetNewRole_Call struct {
 //   - chatId int64
 //   - userId int64
 //   - role byte
-func (_e *IChatMemberRepository_Expecter) SetNewRole(Ctx interface{}, chatId interface{}, userId interface{}, role interface{}) *IChatMemberRepository_SetNewRole_Call {
-	return &IChatMemberRepository_SetNewRole_Call{Call: _e.mock.On("SetNewRole", Ctx, chatId, userId, role)}
+//   - roleId *int64
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatMemberRepository_Expecter) SetNewRole(Ctx interface{}, chatId interface{}, userId interface{}, role interface{}, roleId interface{}, audit interface{}) *IChatMemberRepository_SetNewRole_Call {
+	return &IChatMemberRepository_SetNewRole_Call{Call: _e.mock.On("SetNewRole", Ctx, chatId, userId, role, roleId, audit)}
 }
 
-func (_c *IChatMemberRepository_SetNewRole_Call) Run(run func(Ctx context.Context, chatId int64, userId int64, role byte)) *IChatMemberRepository_SetNewRole_Call {
+func (_c *IChatMemberRepository_SetNewRole_Call) Run(run func(Ctx context.Context, chatId int64, userId int64, role byte, roleId *int64, audit *domain.ChatAuditEntry)) *IChatMemberRepository_SetNewRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(byte))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(byte), args[4].(*int64), args[5].(*domain.ChatAuditEntry))
 	})
 	return _c
 }
@@ -687,7 +876,7 @@ func (_c *IChatMemberRepository_SetNewRole_Call) Return(_a0 error) *IChatMemberR
 	return _c
 }
 
-func (_c *IChatMemberRepository_SetNewRole_Call) RunAndReturn(run func(context.Context, int64, int64, byte) error) *IChatMemberRepository_SetNewRole_Call {
+func (_c *IChatMemberRepository_SetNewRole_Call) RunAndReturn(run func(context.Context, int64, int64, byte, *int64, *domain.ChatAuditEntry) error) *IChatMemberRepository_SetNewRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
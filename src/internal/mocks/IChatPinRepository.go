@@ -0,0 +1,692 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IChatPinRepository is an autogenerated mock type for the IChatPinRepository type
+type IChatPinRepository struct {
+	mock.Mock
+}
+
+type IChatPinRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IChatPinRepository) EXPECT() *IChatPinRepository_Expecter {
+	return &IChatPinRepository_Expecter{mock: &_m.Mock}
+}
+
+// Count provides a mock function with given fields: Ctx, filter, args
+func (_m *IChatPinRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(Ctx, filter, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(Ctx, filter, args...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, filter, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatPinRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IChatPinRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter string
+//   - args ...interface{}
+func (_e *IChatPinRepository_Expecter) Count(Ctx interface{}, filter interface{}, args ...interface{}) *IChatPinRepository_Count_Call {
+	return &IChatPinRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{Ctx, filter}, args...)...)}
+}
+
+func (_c *IChatPinRepository_Count_Call) Run(run func(Ctx context.Context, filter string, args ...interface{})) *IChatPinRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_Count_Call) Return(_a0 int64, _a1 error) *IChatPinRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatPinRepository_Count_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *IChatPinRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IChatPinRepository) Create(Ctx context.Context, obj *domain.ChatPin) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatPin) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatPinRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IChatPinRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.ChatPin
+func (_e *IChatPinRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IChatPinRepository_Create_Call {
+	return &IChatPinRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IChatPinRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.ChatPin)) *IChatPinRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatPin))
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_Create_Call) Return(_a0 error) *IChatPinRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatPinRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.ChatPin) error) *IChatPinRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IChatPinRepository) DeleteById(Ctx context.Context, id int64) error {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatPinRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IChatPinRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatPinRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IChatPinRepository_DeleteById_Call {
+	return &IChatPinRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IChatPinRepository_DeleteById_Call) Run(run func(Ctx context.Context, id int64)) *IChatPinRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_DeleteById_Call) Return(_a0 error) *IChatPinRepository_DeleteById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatPinRepository_DeleteById_Call) RunAndReturn(run func(context.Context, int64) error) *IChatPinRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteQuery provides a mock function with given fields: Ctx, query, args
+func (_m *IChatPinRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatPinRepository_ExecuteQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteQuery'
+type IChatPinRepository_ExecuteQuery_Call struct {
+	*mock.Call
+}
+
+// ExecuteQuery is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatPinRepository_Expecter) ExecuteQuery(Ctx interface{}, query interface{}, args ...interface{}) *IChatPinRepository_ExecuteQuery_Call {
+	return &IChatPinRepository_ExecuteQuery_Call{Call: _e.mock.On("ExecuteQuery",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatPinRepository_ExecuteQuery_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatPinRepository_ExecuteQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_ExecuteQuery_Call) Return(_a0 error) *IChatPinRepository_ExecuteQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatPinRepository_ExecuteQuery_Call) RunAndReturn(run func(context.Context, string, ...interface{}) error) *IChatPinRepository_ExecuteQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with given fields: Ctx, query, args
+func (_m *IChatPinRepository) Filter(Ctx context.Context, query string, args ...interface{}) ([]domain.ChatPin, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 []domain.ChatPin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) ([]domain.ChatPin, error)); ok {
+		return rf(Ctx, query, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) []domain.ChatPin); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatPin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatPinRepository_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type IChatPinRepository_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatPinRepository_Expecter) Filter(Ctx interface{}, query interface{}, args ...interface{}) *IChatPinRepository_Filter_Call {
+	return &IChatPinRepository_Filter_Call{Call: _e.mock.On("Filter",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatPinRepository_Filter_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatPinRepository_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_Filter_Call) Return(_a0 []domain.ChatPin, _a1 error) *IChatPinRepository_Filter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatPinRepository_Filter_Call) RunAndReturn(run func(context.Context, string, ...interface{}) ([]domain.ChatPin, error)) *IChatPinRepository_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx
+func (_m *IChatPinRepository) GetAll(Ctx context.Context) ([]domain.ChatPin, error) {
+	ret := _m.Called(Ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.ChatPin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ChatPin, error)); ok {
+		return rf(Ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ChatPin); ok {
+		r0 = rf(Ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatPin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(Ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatPinRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IChatPinRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+func (_e *IChatPinRepository_Expecter) GetAll(Ctx interface{}) *IChatPinRepository_GetAll_Call {
+	return &IChatPinRepository_GetAll_Call{Call: _e.mock.On("GetAll", Ctx)}
+}
+
+func (_c *IChatPinRepository_GetAll_Call) Run(run func(Ctx context.Context)) *IChatPinRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_GetAll_Call) Return(_a0 []domain.ChatPin, _a1 error) *IChatPinRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatPinRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.ChatPin, error)) *IChatPinRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: Ctx, id
+func (_m *IChatPinRepository) GetById(Ctx context.Context, id int64) (domain.ChatPin, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 domain.ChatPin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.ChatPin, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.ChatPin); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.ChatPin)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatPinRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IChatPinRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatPinRepository_Expecter) GetById(Ctx interface{}, id interface{}) *IChatPinRepository_GetById_Call {
+	return &IChatPinRepository_GetById_Call{Call: _e.mock.On("GetById", Ctx, id)}
+}
+
+func (_c *IChatPinRepository_GetById_Call) Run(run func(Ctx context.Context, id int64)) *IChatPinRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_GetById_Call) Return(_a0 domain.ChatPin, _a1 error) *IChatPinRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatPinRepository_GetById_Call) RunAndReturn(run func(context.Context, int64) (domain.ChatPin, error)) *IChatPinRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetForChat provides a mock function with given fields: Ctx, chatId
+func (_m *IChatPinRepository) GetForChat(Ctx context.Context, chatId int64) ([]domain.ChatPin, error) {
+	ret := _m.Called(Ctx, chatId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForChat")
+	}
+
+	var r0 []domain.ChatPin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.ChatPin, error)); ok {
+		return rf(Ctx, chatId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.ChatPin); ok {
+		r0 = rf(Ctx, chatId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatPin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, chatId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatPinRepository_GetForChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForChat'
+type IChatPinRepository_GetForChat_Call struct {
+	*mock.Call
+}
+
+// GetForChat is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+func (_e *IChatPinRepository_Expecter) GetForChat(Ctx interface{}, chatId interface{}) *IChatPinRepository_GetForChat_Call {
+	return &IChatPinRepository_GetForChat_Call{Call: _e.mock.On("GetForChat", Ctx, chatId)}
+}
+
+func (_c *IChatPinRepository_GetForChat_Call) Run(run func(Ctx context.Context, chatId int64)) *IChatPinRepository_GetForChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_GetForChat_Call) Return(_a0 []domain.ChatPin, _a1 error) *IChatPinRepository_GetForChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatPinRepository_GetForChat_Call) RunAndReturn(run func(context.Context, int64) ([]domain.ChatPin, error)) *IChatPinRepository_GetForChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ManyToCreate provides a mock function with given fields: Ctx, objects
+func (_m *IChatPinRepository) ManyToCreate(Ctx context.Context, objects []domain.ChatPin) error {
+	ret := _m.Called(Ctx, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManyToCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ChatPin) error); ok {
+		r0 = rf(Ctx, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatPinRepository_ManyToCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManyToCreate'
+type IChatPinRepository_ManyToCreate_Call struct {
+	*mock.Call
+}
+
+// ManyToCreate is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - objects []domain.ChatPin
+func (_e *IChatPinRepository_Expecter) ManyToCreate(Ctx interface{}, objects interface{}) *IChatPinRepository_ManyToCreate_Call {
+	return &IChatPinRepository_ManyToCreate_Call{Call: _e.mock.On("ManyToCreate", Ctx, objects)}
+}
+
+func (_c *IChatPinRepository_ManyToCreate_Call) Run(run func(Ctx context.Context, objects []domain.ChatPin)) *IChatPinRepository_ManyToCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.ChatPin))
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_ManyToCreate_Call) Return(_a0 error) *IChatPinRepository_ManyToCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatPinRepository_ManyToCreate_Call) RunAndReturn(run func(context.Context, []domain.ChatPin) error) *IChatPinRepository_ManyToCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pin provides a mock function with given fields: Ctx, pin, maxPinned
+func (_m *IChatPinRepository) Pin(Ctx context.Context, pin *domain.ChatPin, maxPinned int) error {
+	ret := _m.Called(Ctx, pin, maxPinned)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatPin, int) error); ok {
+		r0 = rf(Ctx, pin, maxPinned)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatPinRepository_Pin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pin'
+type IChatPinRepository_Pin_Call struct {
+	*mock.Call
+}
+
+// Pin is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - pin *domain.ChatPin
+//   - maxPinned int
+func (_e *IChatPinRepository_Expecter) Pin(Ctx interface{}, pin interface{}, maxPinned interface{}) *IChatPinRepository_Pin_Call {
+	return &IChatPinRepository_Pin_Call{Call: _e.mock.On("Pin", Ctx, pin, maxPinned)}
+}
+
+func (_c *IChatPinRepository_Pin_Call) Run(run func(Ctx context.Context, pin *domain.ChatPin, maxPinned int)) *IChatPinRepository_Pin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatPin), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_Pin_Call) Return(_a0 error) *IChatPinRepository_Pin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatPinRepository_Pin_Call) RunAndReturn(run func(context.Context, *domain.ChatPin, int) error) *IChatPinRepository_Pin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unpin provides a mock function with given fields: Ctx, chatId, messageId
+func (_m *IChatPinRepository) Unpin(Ctx context.Context, chatId int64, messageId string) error {
+	ret := _m.Called(Ctx, chatId, messageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unpin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(Ctx, chatId, messageId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatPinRepository_Unpin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unpin'
+type IChatPinRepository_Unpin_Call struct {
+	*mock.Call
+}
+
+// Unpin is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - messageId string
+func (_e *IChatPinRepository_Expecter) Unpin(Ctx interface{}, chatId interface{}, messageId interface{}) *IChatPinRepository_Unpin_Call {
+	return &IChatPinRepository_Unpin_Call{Call: _e.mock.On("Unpin", Ctx, chatId, messageId)}
+}
+
+func (_c *IChatPinRepository_Unpin_Call) Run(run func(Ctx context.Context, chatId int64, messageId string)) *IChatPinRepository_Unpin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_Unpin_Call) Return(_a0 error) *IChatPinRepository_Unpin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatPinRepository_Unpin_Call) RunAndReturn(run func(context.Context, int64, string) error) *IChatPinRepository_Unpin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IChatPinRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatPinRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IChatPinRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+//   - updateFields map[string]interface{}
+func (_e *IChatPinRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IChatPinRepository_UpdateById_Call {
+	return &IChatPinRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IChatPinRepository_UpdateById_Call) Run(run func(Ctx context.Context, id int64, updateFields map[string]interface{})) *IChatPinRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *IChatPinRepository_UpdateById_Call) Return(_a0 error) *IChatPinRepository_UpdateById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatPinRepository_UpdateById_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}) error) *IChatPinRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIChatPinRepository creates a new instance of IChatPinRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChatPinRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChatPinRepository {
+	mock := &IChatPinRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 	domain "libs/src/internal/domain/models"
+	dto "libs/src/internal/dto"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -137,6 +138,54 @@ func (_c *IChatRepository_Create_Call) RunAndReturn(run func(context.Context, *d
 	return _c
 }
 
+// CreateDirect provides a mock function with given fields: Ctx, chat, peerId
+func (_m *IChatRepository) CreateDirect(Ctx context.Context, chat *domain.Chat, peerId int64) error {
+	ret := _m.Called(Ctx, chat, peerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDirect")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Chat, int64) error); ok {
+		r0 = rf(Ctx, chat, peerId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRepository_CreateDirect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDirect'
+type IChatRepository_CreateDirect_Call struct {
+	*mock.Call
+}
+
+// CreateDirect is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chat *domain.Chat
+//   - peerId int64
+func (_e *IChatRepository_Expecter) CreateDirect(Ctx interface{}, chat interface{}, peerId interface{}) *IChatRepository_CreateDirect_Call {
+	return &IChatRepository_CreateDirect_Call{Call: _e.mock.On("CreateDirect", Ctx, chat, peerId)}
+}
+
+func (_c *IChatRepository_CreateDirect_Call) Run(run func(Ctx context.Context, chat *domain.Chat, peerId int64)) *IChatRepository_CreateDirect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Chat), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRepository_CreateDirect_Call) Return(_a0 error) *IChatRepository_CreateDirect_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRepository_CreateDirect_Call) RunAndReturn(run func(context.Context, *domain.Chat, int64) error) *IChatRepository_CreateDirect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteById provides a mock function with given fields: Ctx, id
 func (_m *IChatRepository) DeleteById(Ctx context.Context, id int64) error {
 	ret := _m.Called(Ctx, id)
@@ -184,6 +233,115 @@ func (_c *IChatRepository_DeleteById_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// DeleteChat provides a mock function with given fields: Ctx, chatId, audit
+func (_m *IChatRepository) DeleteChat(Ctx context.Context, chatId int64, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, chatId, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, chatId, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRepository_DeleteChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChat'
+type IChatRepository_DeleteChat_Call struct {
+	*mock.Call
+}
+
+// DeleteChat is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRepository_Expecter) DeleteChat(Ctx interface{}, chatId interface{}, audit interface{}) *IChatRepository_DeleteChat_Call {
+	return &IChatRepository_DeleteChat_Call{Call: _e.mock.On("DeleteChat", Ctx, chatId, audit)}
+}
+
+func (_c *IChatRepository_DeleteChat_Call) Run(run func(Ctx context.Context, chatId int64, audit *domain.ChatAuditEntry)) *IChatRepository_DeleteChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRepository_DeleteChat_Call) Return(_a0 error) *IChatRepository_DeleteChat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRepository_DeleteChat_Call) RunAndReturn(run func(context.Context, int64, *domain.ChatAuditEntry) error) *IChatRepository_DeleteChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DiscoverPublic provides a mock function with given fields: Ctx, search, limit, offset
+func (_m *IChatRepository) DiscoverPublic(Ctx context.Context, search string, limit int, offset int) ([]dto.ChatPreview, error) {
+	ret := _m.Called(Ctx, search, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiscoverPublic")
+	}
+
+	var r0 []dto.ChatPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]dto.ChatPreview, error)); ok {
+		return rf(Ctx, search, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []dto.ChatPreview); ok {
+		r0 = rf(Ctx, search, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.ChatPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(Ctx, search, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRepository_DiscoverPublic_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiscoverPublic'
+type IChatRepository_DiscoverPublic_Call struct {
+	*mock.Call
+}
+
+// DiscoverPublic is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - search string
+//   - limit int
+//   - offset int
+func (_e *IChatRepository_Expecter) DiscoverPublic(Ctx interface{}, search interface{}, limit interface{}, offset interface{}) *IChatRepository_DiscoverPublic_Call {
+	return &IChatRepository_DiscoverPublic_Call{Call: _e.mock.On("DiscoverPublic", Ctx, search, limit, offset)}
+}
+
+func (_c *IChatRepository_DiscoverPublic_Call) Run(run func(Ctx context.Context, search string, limit int, offset int)) *IChatRepository_DiscoverPublic_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *IChatRepository_DiscoverPublic_Call) Return(_a0 []dto.ChatPreview, _a1 error) *IChatRepository_DiscoverPublic_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRepository_DiscoverPublic_Call) RunAndReturn(run func(context.Context, string, int, int) ([]dto.ChatPreview, error)) *IChatRepository_DiscoverPublic_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ExecuteQuery provides a mock function with given fields: Ctx, query, args
 func (_m *IChatRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
 	var _ca []interface{}
@@ -488,6 +646,63 @@ func (_c *IChatRepository_GetListForUser_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetPreview provides a mock function with given fields: Ctx, chatId
+func (_m *IChatRepository) GetPreview(Ctx context.Context, chatId int64) (dto.ChatPreview, error) {
+	ret := _m.Called(Ctx, chatId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreview")
+	}
+
+	var r0 dto.ChatPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (dto.ChatPreview, error)); ok {
+		return rf(Ctx, chatId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) dto.ChatPreview); ok {
+		r0 = rf(Ctx, chatId)
+	} else {
+		r0 = ret.Get(0).(dto.ChatPreview)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, chatId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRepository_GetPreview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreview'
+type IChatRepository_GetPreview_Call struct {
+	*mock.Call
+}
+
+// GetPreview is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+func (_e *IChatRepository_Expecter) GetPreview(Ctx interface{}, chatId interface{}) *IChatRepository_GetPreview_Call {
+	return &IChatRepository_GetPreview_Call{Call: _e.mock.On("GetPreview", Ctx, chatId)}
+}
+
+func (_c *IChatRepository_GetPreview_Call) Run(run func(Ctx context.Context, chatId int64)) *IChatRepository_GetPreview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRepository_GetPreview_Call) Return(_a0 dto.ChatPreview, _a1 error) *IChatRepository_GetPreview_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRepository_GetPreview_Call) RunAndReturn(run func(context.Context, int64) (dto.ChatPreview, error)) *IChatRepository_GetPreview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ManyToCreate provides a mock function with given fields: Ctx, objects
 func (_m *IChatRepository) ManyToCreate(Ctx context.Context, objects []domain.Chat) error {
 	ret := _m.Called(Ctx, objects)
@@ -597,6 +812,56 @@ func (_c *IChatRepository_SearchForUser_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// TransferOwnership provides a mock function with given fields: Ctx, chatId, ownerId, newOwnerId, audit
+func (_m *IChatRepository) TransferOwnership(Ctx context.Context, chatId int64, ownerId int64, newOwnerId int64, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, chatId, ownerId, newOwnerId, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferOwnership")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, chatId, ownerId, newOwnerId, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRepository_TransferOwnership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferOwnership'
+type IChatRepository_TransferOwnership_Call struct {
+	*mock.Call
+}
+
+// TransferOwnership is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - ownerId int64
+//   - newOwnerId int64
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRepository_Expecter) TransferOwnership(Ctx interface{}, chatId interface{}, ownerId interface{}, newOwnerId interface{}, audit interface{}) *IChatRepository_TransferOwnership_Call {
+	return &IChatRepository_TransferOwnership_Call{Call: _e.mock.On("TransferOwnership", Ctx, chatId, ownerId, newOwnerId, audit)}
+}
+
+func (_c *IChatRepository_TransferOwnership_Call) Run(run func(Ctx context.Context, chatId int64, ownerId int64, newOwnerId int64, audit *domain.ChatAuditEntry)) *IChatRepository_TransferOwnership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64), args[4].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRepository_TransferOwnership_Call) Return(_a0 error) *IChatRepository_TransferOwnership_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRepository_TransferOwnership_Call) RunAndReturn(run func(context.Context, int64, int64, int64, *domain.ChatAuditEntry) error) *IChatRepository_TransferOwnership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateById provides a mock function with given fields: Ctx, id, updateFields
 func (_m *IChatRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
 	ret := _m.Called(Ctx, id, updateFields)
@@ -645,6 +910,55 @@ func (_c *IChatRepository_UpdateById_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// UpdateChat provides a mock function with given fields: Ctx, chatId, updateFields, audit
+func (_m *IChatRepository) UpdateChat(Ctx context.Context, chatId int64, updateFields map[string]interface{}, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, chatId, updateFields, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, chatId, updateFields, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRepository_UpdateChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChat'
+type IChatRepository_UpdateChat_Call struct {
+	*mock.Call
+}
+
+// UpdateChat is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - updateFields map[string]interface{}
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRepository_Expecter) UpdateChat(Ctx interface{}, chatId interface{}, updateFields interface{}, audit interface{}) *IChatRepository_UpdateChat_Call {
+	return &IChatRepository_UpdateChat_Call{Call: _e.mock.On("UpdateChat", Ctx, chatId, updateFields, audit)}
+}
+
+func (_c *IChatRepository_UpdateChat_Call) Run(run func(Ctx context.Context, chatId int64, updateFields map[string]interface{}, audit *domain.ChatAuditEntry)) *IChatRepository_UpdateChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}), args[3].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRepository_UpdateChat_Call) Return(_a0 error) *IChatRepository_UpdateChat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRepository_UpdateChat_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}, *domain.ChatAuditEntry) error) *IChatRepository_UpdateChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewIChatRepository creates a new instance of IChatRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIChatRepository(t interface {
@@ -0,0 +1,756 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IChatRestrictionRepository is an autogenerated mock type for the IChatRestrictionRepository type
+type IChatRestrictionRepository struct {
+	mock.Mock
+}
+
+type IChatRestrictionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IChatRestrictionRepository) EXPECT() *IChatRestrictionRepository_Expecter {
+	return &IChatRestrictionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Count provides a mock function with given fields: Ctx, filter, args
+func (_m *IChatRestrictionRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(Ctx, filter, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(Ctx, filter, args...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, filter, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRestrictionRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IChatRestrictionRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter string
+//   - args ...interface{}
+func (_e *IChatRestrictionRepository_Expecter) Count(Ctx interface{}, filter interface{}, args ...interface{}) *IChatRestrictionRepository_Count_Call {
+	return &IChatRestrictionRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{Ctx, filter}, args...)...)}
+}
+
+func (_c *IChatRestrictionRepository_Count_Call) Run(run func(Ctx context.Context, filter string, args ...interface{})) *IChatRestrictionRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Count_Call) Return(_a0 int64, _a1 error) *IChatRestrictionRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Count_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *IChatRestrictionRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IChatRestrictionRepository) Create(Ctx context.Context, obj *domain.ChatRestriction) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatRestriction) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRestrictionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IChatRestrictionRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.ChatRestriction
+func (_e *IChatRestrictionRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IChatRestrictionRepository_Create_Call {
+	return &IChatRestrictionRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IChatRestrictionRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.ChatRestriction)) *IChatRestrictionRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatRestriction))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Create_Call) Return(_a0 error) *IChatRestrictionRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.ChatRestriction) error) *IChatRestrictionRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IChatRestrictionRepository) DeleteById(Ctx context.Context, id int64) error {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRestrictionRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IChatRestrictionRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatRestrictionRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IChatRestrictionRepository_DeleteById_Call {
+	return &IChatRestrictionRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IChatRestrictionRepository_DeleteById_Call) Run(run func(Ctx context.Context, id int64)) *IChatRestrictionRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_DeleteById_Call) Return(_a0 error) *IChatRestrictionRepository_DeleteById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_DeleteById_Call) RunAndReturn(run func(context.Context, int64) error) *IChatRestrictionRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteQuery provides a mock function with given fields: Ctx, query, args
+func (_m *IChatRestrictionRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRestrictionRepository_ExecuteQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteQuery'
+type IChatRestrictionRepository_ExecuteQuery_Call struct {
+	*mock.Call
+}
+
+// ExecuteQuery is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatRestrictionRepository_Expecter) ExecuteQuery(Ctx interface{}, query interface{}, args ...interface{}) *IChatRestrictionRepository_ExecuteQuery_Call {
+	return &IChatRestrictionRepository_ExecuteQuery_Call{Call: _e.mock.On("ExecuteQuery",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatRestrictionRepository_ExecuteQuery_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatRestrictionRepository_ExecuteQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_ExecuteQuery_Call) Return(_a0 error) *IChatRestrictionRepository_ExecuteQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_ExecuteQuery_Call) RunAndReturn(run func(context.Context, string, ...interface{}) error) *IChatRestrictionRepository_ExecuteQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with given fields: Ctx, query, args
+func (_m *IChatRestrictionRepository) Filter(Ctx context.Context, query string, args ...interface{}) ([]domain.ChatRestriction, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 []domain.ChatRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) ([]domain.ChatRestriction, error)); ok {
+		return rf(Ctx, query, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) []domain.ChatRestriction); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRestrictionRepository_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type IChatRestrictionRepository_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatRestrictionRepository_Expecter) Filter(Ctx interface{}, query interface{}, args ...interface{}) *IChatRestrictionRepository_Filter_Call {
+	return &IChatRestrictionRepository_Filter_Call{Call: _e.mock.On("Filter",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatRestrictionRepository_Filter_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatRestrictionRepository_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Filter_Call) Return(_a0 []domain.ChatRestriction, _a1 error) *IChatRestrictionRepository_Filter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Filter_Call) RunAndReturn(run func(context.Context, string, ...interface{}) ([]domain.ChatRestriction, error)) *IChatRestrictionRepository_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActive provides a mock function with given fields: Ctx, chatId, userId, restrictionType
+func (_m *IChatRestrictionRepository) GetActive(Ctx context.Context, chatId int64, userId int64, restrictionType byte) (domain.ChatRestriction, error) {
+	ret := _m.Called(Ctx, chatId, userId, restrictionType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActive")
+	}
+
+	var r0 domain.ChatRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, byte) (domain.ChatRestriction, error)); ok {
+		return rf(Ctx, chatId, userId, restrictionType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, byte) domain.ChatRestriction); ok {
+		r0 = rf(Ctx, chatId, userId, restrictionType)
+	} else {
+		r0 = ret.Get(0).(domain.ChatRestriction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, byte) error); ok {
+		r1 = rf(Ctx, chatId, userId, restrictionType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRestrictionRepository_GetActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActive'
+type IChatRestrictionRepository_GetActive_Call struct {
+	*mock.Call
+}
+
+// GetActive is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - userId int64
+//   - restrictionType byte
+func (_e *IChatRestrictionRepository_Expecter) GetActive(Ctx interface{}, chatId interface{}, userId interface{}, restrictionType interface{}) *IChatRestrictionRepository_GetActive_Call {
+	return &IChatRestrictionRepository_GetActive_Call{Call: _e.mock.On("GetActive", Ctx, chatId, userId, restrictionType)}
+}
+
+func (_c *IChatRestrictionRepository_GetActive_Call) Run(run func(Ctx context.Context, chatId int64, userId int64, restrictionType byte)) *IChatRestrictionRepository_GetActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(byte))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_GetActive_Call) Return(_a0 domain.ChatRestriction, _a1 error) *IChatRestrictionRepository_GetActive_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_GetActive_Call) RunAndReturn(run func(context.Context, int64, int64, byte) (domain.ChatRestriction, error)) *IChatRestrictionRepository_GetActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx
+func (_m *IChatRestrictionRepository) GetAll(Ctx context.Context) ([]domain.ChatRestriction, error) {
+	ret := _m.Called(Ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.ChatRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ChatRestriction, error)); ok {
+		return rf(Ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ChatRestriction); ok {
+		r0 = rf(Ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(Ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRestrictionRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IChatRestrictionRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+func (_e *IChatRestrictionRepository_Expecter) GetAll(Ctx interface{}) *IChatRestrictionRepository_GetAll_Call {
+	return &IChatRestrictionRepository_GetAll_Call{Call: _e.mock.On("GetAll", Ctx)}
+}
+
+func (_c *IChatRestrictionRepository_GetAll_Call) Run(run func(Ctx context.Context)) *IChatRestrictionRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_GetAll_Call) Return(_a0 []domain.ChatRestriction, _a1 error) *IChatRestrictionRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.ChatRestriction, error)) *IChatRestrictionRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: Ctx, id
+func (_m *IChatRestrictionRepository) GetById(Ctx context.Context, id int64) (domain.ChatRestriction, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 domain.ChatRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.ChatRestriction, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.ChatRestriction); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.ChatRestriction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRestrictionRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IChatRestrictionRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatRestrictionRepository_Expecter) GetById(Ctx interface{}, id interface{}) *IChatRestrictionRepository_GetById_Call {
+	return &IChatRestrictionRepository_GetById_Call{Call: _e.mock.On("GetById", Ctx, id)}
+}
+
+func (_c *IChatRestrictionRepository_GetById_Call) Run(run func(Ctx context.Context, id int64)) *IChatRestrictionRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_GetById_Call) Return(_a0 domain.ChatRestriction, _a1 error) *IChatRestrictionRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_GetById_Call) RunAndReturn(run func(context.Context, int64) (domain.ChatRestriction, error)) *IChatRestrictionRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListForChat provides a mock function with given fields: Ctx, chatId, restrictionType, limit, offset
+func (_m *IChatRestrictionRepository) GetListForChat(Ctx context.Context, chatId int64, restrictionType byte, limit int, offset int) ([]domain.ChatRestriction, error) {
+	ret := _m.Called(Ctx, chatId, restrictionType, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListForChat")
+	}
+
+	var r0 []domain.ChatRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, byte, int, int) ([]domain.ChatRestriction, error)); ok {
+		return rf(Ctx, chatId, restrictionType, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, byte, int, int) []domain.ChatRestriction); ok {
+		r0 = rf(Ctx, chatId, restrictionType, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, byte, int, int) error); ok {
+		r1 = rf(Ctx, chatId, restrictionType, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRestrictionRepository_GetListForChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListForChat'
+type IChatRestrictionRepository_GetListForChat_Call struct {
+	*mock.Call
+}
+
+// GetListForChat is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - restrictionType byte
+//   - limit int
+//   - offset int
+func (_e *IChatRestrictionRepository_Expecter) GetListForChat(Ctx interface{}, chatId interface{}, restrictionType interface{}, limit interface{}, offset interface{}) *IChatRestrictionRepository_GetListForChat_Call {
+	return &IChatRestrictionRepository_GetListForChat_Call{Call: _e.mock.On("GetListForChat", Ctx, chatId, restrictionType, limit, offset)}
+}
+
+func (_c *IChatRestrictionRepository_GetListForChat_Call) Run(run func(Ctx context.Context, chatId int64, restrictionType byte, limit int, offset int)) *IChatRestrictionRepository_GetListForChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(byte), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_GetListForChat_Call) Return(_a0 []domain.ChatRestriction, _a1 error) *IChatRestrictionRepository_GetListForChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_GetListForChat_Call) RunAndReturn(run func(context.Context, int64, byte, int, int) ([]domain.ChatRestriction, error)) *IChatRestrictionRepository_GetListForChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lift provides a mock function with given fields: Ctx, chatId, userId, restrictionType, audit
+func (_m *IChatRestrictionRepository) Lift(Ctx context.Context, chatId int64, userId int64, restrictionType byte, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, chatId, userId, restrictionType, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lift")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, byte, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, chatId, userId, restrictionType, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRestrictionRepository_Lift_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lift'
+type IChatRestrictionRepository_Lift_Call struct {
+	*mock.Call
+}
+
+// Lift is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - userId int64
+//   - restrictionType byte
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRestrictionRepository_Expecter) Lift(Ctx interface{}, chatId interface{}, userId interface{}, restrictionType interface{}, audit interface{}) *IChatRestrictionRepository_Lift_Call {
+	return &IChatRestrictionRepository_Lift_Call{Call: _e.mock.On("Lift", Ctx, chatId, userId, restrictionType, audit)}
+}
+
+func (_c *IChatRestrictionRepository_Lift_Call) Run(run func(Ctx context.Context, chatId int64, userId int64, restrictionType byte, audit *domain.ChatAuditEntry)) *IChatRestrictionRepository_Lift_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(byte), args[4].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Lift_Call) Return(_a0 error) *IChatRestrictionRepository_Lift_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Lift_Call) RunAndReturn(run func(context.Context, int64, int64, byte, *domain.ChatAuditEntry) error) *IChatRestrictionRepository_Lift_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ManyToCreate provides a mock function with given fields: Ctx, objects
+func (_m *IChatRestrictionRepository) ManyToCreate(Ctx context.Context, objects []domain.ChatRestriction) error {
+	ret := _m.Called(Ctx, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManyToCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ChatRestriction) error); ok {
+		r0 = rf(Ctx, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRestrictionRepository_ManyToCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManyToCreate'
+type IChatRestrictionRepository_ManyToCreate_Call struct {
+	*mock.Call
+}
+
+// ManyToCreate is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - objects []domain.ChatRestriction
+func (_e *IChatRestrictionRepository_Expecter) ManyToCreate(Ctx interface{}, objects interface{}) *IChatRestrictionRepository_ManyToCreate_Call {
+	return &IChatRestrictionRepository_ManyToCreate_Call{Call: _e.mock.On("ManyToCreate", Ctx, objects)}
+}
+
+func (_c *IChatRestrictionRepository_ManyToCreate_Call) Run(run func(Ctx context.Context, objects []domain.ChatRestriction)) *IChatRestrictionRepository_ManyToCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.ChatRestriction))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_ManyToCreate_Call) Return(_a0 error) *IChatRestrictionRepository_ManyToCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_ManyToCreate_Call) RunAndReturn(run func(context.Context, []domain.ChatRestriction) error) *IChatRestrictionRepository_ManyToCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IChatRestrictionRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRestrictionRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IChatRestrictionRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+//   - updateFields map[string]interface{}
+func (_e *IChatRestrictionRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IChatRestrictionRepository_UpdateById_Call {
+	return &IChatRestrictionRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IChatRestrictionRepository_UpdateById_Call) Run(run func(Ctx context.Context, id int64, updateFields map[string]interface{})) *IChatRestrictionRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_UpdateById_Call) Return(_a0 error) *IChatRestrictionRepository_UpdateById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_UpdateById_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}) error) *IChatRestrictionRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: Ctx, restriction, audit
+func (_m *IChatRestrictionRepository) Upsert(Ctx context.Context, restriction *domain.ChatRestriction, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, restriction, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatRestriction, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, restriction, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRestrictionRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type IChatRestrictionRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - restriction *domain.ChatRestriction
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRestrictionRepository_Expecter) Upsert(Ctx interface{}, restriction interface{}, audit interface{}) *IChatRestrictionRepository_Upsert_Call {
+	return &IChatRestrictionRepository_Upsert_Call{Call: _e.mock.On("Upsert", Ctx, restriction, audit)}
+}
+
+func (_c *IChatRestrictionRepository_Upsert_Call) Run(run func(Ctx context.Context, restriction *domain.ChatRestriction, audit *domain.ChatAuditEntry)) *IChatRestrictionRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatRestriction), args[2].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Upsert_Call) Return(_a0 error) *IChatRestrictionRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRestrictionRepository_Upsert_Call) RunAndReturn(run func(context.Context, *domain.ChatRestriction, *domain.ChatAuditEntry) error) *IChatRestrictionRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIChatRestrictionRepository creates a new instance of IChatRestrictionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChatRestrictionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChatRestrictionRepository {
+	mock := &IChatRestrictionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
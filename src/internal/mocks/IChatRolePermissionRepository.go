@@ -0,0 +1,693 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IChatRolePermissionRepository is an autogenerated mock type for the IChatRolePermissionRepository type
+type IChatRolePermissionRepository struct {
+	mock.Mock
+}
+
+type IChatRolePermissionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IChatRolePermissionRepository) EXPECT() *IChatRolePermissionRepository_Expecter {
+	return &IChatRolePermissionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Count provides a mock function with given fields: Ctx, filter, args
+func (_m *IChatRolePermissionRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(Ctx, filter, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(Ctx, filter, args...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, filter, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRolePermissionRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IChatRolePermissionRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter string
+//   - args ...interface{}
+func (_e *IChatRolePermissionRepository_Expecter) Count(Ctx interface{}, filter interface{}, args ...interface{}) *IChatRolePermissionRepository_Count_Call {
+	return &IChatRolePermissionRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{Ctx, filter}, args...)...)}
+}
+
+func (_c *IChatRolePermissionRepository_Count_Call) Run(run func(Ctx context.Context, filter string, args ...interface{})) *IChatRolePermissionRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Count_Call) Return(_a0 int64, _a1 error) *IChatRolePermissionRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Count_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *IChatRolePermissionRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IChatRolePermissionRepository) Create(Ctx context.Context, obj *domain.ChatRolePermission) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatRolePermission) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRolePermissionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IChatRolePermissionRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.ChatRolePermission
+func (_e *IChatRolePermissionRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IChatRolePermissionRepository_Create_Call {
+	return &IChatRolePermissionRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IChatRolePermissionRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.ChatRolePermission)) *IChatRolePermissionRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatRolePermission))
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Create_Call) Return(_a0 error) *IChatRolePermissionRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.ChatRolePermission) error) *IChatRolePermissionRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IChatRolePermissionRepository) DeleteById(Ctx context.Context, id int64) error {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRolePermissionRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IChatRolePermissionRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatRolePermissionRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IChatRolePermissionRepository_DeleteById_Call {
+	return &IChatRolePermissionRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IChatRolePermissionRepository_DeleteById_Call) Run(run func(Ctx context.Context, id int64)) *IChatRolePermissionRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_DeleteById_Call) Return(_a0 error) *IChatRolePermissionRepository_DeleteById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_DeleteById_Call) RunAndReturn(run func(context.Context, int64) error) *IChatRolePermissionRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteQuery provides a mock function with given fields: Ctx, query, args
+func (_m *IChatRolePermissionRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRolePermissionRepository_ExecuteQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteQuery'
+type IChatRolePermissionRepository_ExecuteQuery_Call struct {
+	*mock.Call
+}
+
+// ExecuteQuery is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatRolePermissionRepository_Expecter) ExecuteQuery(Ctx interface{}, query interface{}, args ...interface{}) *IChatRolePermissionRepository_ExecuteQuery_Call {
+	return &IChatRolePermissionRepository_ExecuteQuery_Call{Call: _e.mock.On("ExecuteQuery",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatRolePermissionRepository_ExecuteQuery_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatRolePermissionRepository_ExecuteQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_ExecuteQuery_Call) Return(_a0 error) *IChatRolePermissionRepository_ExecuteQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_ExecuteQuery_Call) RunAndReturn(run func(context.Context, string, ...interface{}) error) *IChatRolePermissionRepository_ExecuteQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with given fields: Ctx, query, args
+func (_m *IChatRolePermissionRepository) Filter(Ctx context.Context, query string, args ...interface{}) ([]domain.ChatRolePermission, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 []domain.ChatRolePermission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) ([]domain.ChatRolePermission, error)); ok {
+		return rf(Ctx, query, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) []domain.ChatRolePermission); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatRolePermission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRolePermissionRepository_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type IChatRolePermissionRepository_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatRolePermissionRepository_Expecter) Filter(Ctx interface{}, query interface{}, args ...interface{}) *IChatRolePermissionRepository_Filter_Call {
+	return &IChatRolePermissionRepository_Filter_Call{Call: _e.mock.On("Filter",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatRolePermissionRepository_Filter_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatRolePermissionRepository_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Filter_Call) Return(_a0 []domain.ChatRolePermission, _a1 error) *IChatRolePermissionRepository_Filter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Filter_Call) RunAndReturn(run func(context.Context, string, ...interface{}) ([]domain.ChatRolePermission, error)) *IChatRolePermissionRepository_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx
+func (_m *IChatRolePermissionRepository) GetAll(Ctx context.Context) ([]domain.ChatRolePermission, error) {
+	ret := _m.Called(Ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.ChatRolePermission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ChatRolePermission, error)); ok {
+		return rf(Ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ChatRolePermission); ok {
+		r0 = rf(Ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatRolePermission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(Ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRolePermissionRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IChatRolePermissionRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+func (_e *IChatRolePermissionRepository_Expecter) GetAll(Ctx interface{}) *IChatRolePermissionRepository_GetAll_Call {
+	return &IChatRolePermissionRepository_GetAll_Call{Call: _e.mock.On("GetAll", Ctx)}
+}
+
+func (_c *IChatRolePermissionRepository_GetAll_Call) Run(run func(Ctx context.Context)) *IChatRolePermissionRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_GetAll_Call) Return(_a0 []domain.ChatRolePermission, _a1 error) *IChatRolePermissionRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.ChatRolePermission, error)) *IChatRolePermissionRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: Ctx, id
+func (_m *IChatRolePermissionRepository) GetById(Ctx context.Context, id int64) (domain.ChatRolePermission, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 domain.ChatRolePermission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.ChatRolePermission, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.ChatRolePermission); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.ChatRolePermission)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRolePermissionRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IChatRolePermissionRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatRolePermissionRepository_Expecter) GetById(Ctx interface{}, id interface{}) *IChatRolePermissionRepository_GetById_Call {
+	return &IChatRolePermissionRepository_GetById_Call{Call: _e.mock.On("GetById", Ctx, id)}
+}
+
+func (_c *IChatRolePermissionRepository_GetById_Call) Run(run func(Ctx context.Context, id int64)) *IChatRolePermissionRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_GetById_Call) Return(_a0 domain.ChatRolePermission, _a1 error) *IChatRolePermissionRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_GetById_Call) RunAndReturn(run func(context.Context, int64) (domain.ChatRolePermission, error)) *IChatRolePermissionRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetForChat provides a mock function with given fields: Ctx, chatId
+func (_m *IChatRolePermissionRepository) GetForChat(Ctx context.Context, chatId int64) ([]domain.ChatRolePermission, error) {
+	ret := _m.Called(Ctx, chatId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForChat")
+	}
+
+	var r0 []domain.ChatRolePermission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.ChatRolePermission, error)); ok {
+		return rf(Ctx, chatId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.ChatRolePermission); ok {
+		r0 = rf(Ctx, chatId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatRolePermission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, chatId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRolePermissionRepository_GetForChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForChat'
+type IChatRolePermissionRepository_GetForChat_Call struct {
+	*mock.Call
+}
+
+// GetForChat is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+func (_e *IChatRolePermissionRepository_Expecter) GetForChat(Ctx interface{}, chatId interface{}) *IChatRolePermissionRepository_GetForChat_Call {
+	return &IChatRolePermissionRepository_GetForChat_Call{Call: _e.mock.On("GetForChat", Ctx, chatId)}
+}
+
+func (_c *IChatRolePermissionRepository_GetForChat_Call) Run(run func(Ctx context.Context, chatId int64)) *IChatRolePermissionRepository_GetForChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_GetForChat_Call) Return(_a0 []domain.ChatRolePermission, _a1 error) *IChatRolePermissionRepository_GetForChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_GetForChat_Call) RunAndReturn(run func(context.Context, int64) ([]domain.ChatRolePermission, error)) *IChatRolePermissionRepository_GetForChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ManyToCreate provides a mock function with given fields: Ctx, objects
+func (_m *IChatRolePermissionRepository) ManyToCreate(Ctx context.Context, objects []domain.ChatRolePermission) error {
+	ret := _m.Called(Ctx, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManyToCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ChatRolePermission) error); ok {
+		r0 = rf(Ctx, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRolePermissionRepository_ManyToCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManyToCreate'
+type IChatRolePermissionRepository_ManyToCreate_Call struct {
+	*mock.Call
+}
+
+// ManyToCreate is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - objects []domain.ChatRolePermission
+func (_e *IChatRolePermissionRepository_Expecter) ManyToCreate(Ctx interface{}, objects interface{}) *IChatRolePermissionRepository_ManyToCreate_Call {
+	return &IChatRolePermissionRepository_ManyToCreate_Call{Call: _e.mock.On("ManyToCreate", Ctx, objects)}
+}
+
+func (_c *IChatRolePermissionRepository_ManyToCreate_Call) Run(run func(Ctx context.Context, objects []domain.ChatRolePermission)) *IChatRolePermissionRepository_ManyToCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.ChatRolePermission))
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_ManyToCreate_Call) Return(_a0 error) *IChatRolePermissionRepository_ManyToCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_ManyToCreate_Call) RunAndReturn(run func(context.Context, []domain.ChatRolePermission) error) *IChatRolePermissionRepository_ManyToCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function with given fields: Ctx, chatId, role, audit
+func (_m *IChatRolePermissionRepository) Reset(Ctx context.Context, chatId int64, role byte, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, chatId, role, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, byte, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, chatId, role, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRolePermissionRepository_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type IChatRolePermissionRepository_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - role byte
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRolePermissionRepository_Expecter) Reset(Ctx interface{}, chatId interface{}, role interface{}, audit interface{}) *IChatRolePermissionRepository_Reset_Call {
+	return &IChatRolePermissionRepository_Reset_Call{Call: _e.mock.On("Reset", Ctx, chatId, role, audit)}
+}
+
+func (_c *IChatRolePermissionRepository_Reset_Call) Run(run func(Ctx context.Context, chatId int64, role byte, audit *domain.ChatAuditEntry)) *IChatRolePermissionRepository_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(byte), args[3].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Reset_Call) Return(_a0 error) *IChatRolePermissionRepository_Reset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Reset_Call) RunAndReturn(run func(context.Context, int64, byte, *domain.ChatAuditEntry) error) *IChatRolePermissionRepository_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IChatRolePermissionRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRolePermissionRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IChatRolePermissionRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+//   - updateFields map[string]interface{}
+func (_e *IChatRolePermissionRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IChatRolePermissionRepository_UpdateById_Call {
+	return &IChatRolePermissionRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IChatRolePermissionRepository_UpdateById_Call) Run(run func(Ctx context.Context, id int64, updateFields map[string]interface{})) *IChatRolePermissionRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_UpdateById_Call) Return(_a0 error) *IChatRolePermissionRepository_UpdateById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_UpdateById_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}) error) *IChatRolePermissionRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: Ctx, permission, audit
+func (_m *IChatRolePermissionRepository) Upsert(Ctx context.Context, permission *domain.ChatRolePermission, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, permission, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatRolePermission, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, permission, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRolePermissionRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type IChatRolePermissionRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - permission *domain.ChatRolePermission
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRolePermissionRepository_Expecter) Upsert(Ctx interface{}, permission interface{}, audit interface{}) *IChatRolePermissionRepository_Upsert_Call {
+	return &IChatRolePermissionRepository_Upsert_Call{Call: _e.mock.On("Upsert", Ctx, permission, audit)}
+}
+
+func (_c *IChatRolePermissionRepository_Upsert_Call) Run(run func(Ctx context.Context, permission *domain.ChatRolePermission, audit *domain.ChatAuditEntry)) *IChatRolePermissionRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatRolePermission), args[2].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Upsert_Call) Return(_a0 error) *IChatRolePermissionRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRolePermissionRepository_Upsert_Call) RunAndReturn(run func(context.Context, *domain.ChatRolePermission, *domain.ChatAuditEntry) error) *IChatRolePermissionRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIChatRolePermissionRepository creates a new instance of IChatRolePermissionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChatRolePermissionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChatRolePermissionRepository {
+	mock := &IChatRolePermissionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
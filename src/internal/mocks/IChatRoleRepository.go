@@ -0,0 +1,857 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IChatRoleRepository is an autogenerated mock type for the IChatRoleRepository type
+type IChatRoleRepository struct {
+	mock.Mock
+}
+
+type IChatRoleRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IChatRoleRepository) EXPECT() *IChatRoleRepository_Expecter {
+	return &IChatRoleRepository_Expecter{mock: &_m.Mock}
+}
+
+// Count provides a mock function with given fields: Ctx, filter, args
+func (_m *IChatRoleRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(Ctx, filter, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(Ctx, filter, args...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, filter, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRoleRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IChatRoleRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter string
+//   - args ...interface{}
+func (_e *IChatRoleRepository_Expecter) Count(Ctx interface{}, filter interface{}, args ...interface{}) *IChatRoleRepository_Count_Call {
+	return &IChatRoleRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{Ctx, filter}, args...)...)}
+}
+
+func (_c *IChatRoleRepository_Count_Call) Run(run func(Ctx context.Context, filter string, args ...interface{})) *IChatRoleRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_Count_Call) Return(_a0 int64, _a1 error) *IChatRoleRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRoleRepository_Count_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *IChatRoleRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IChatRoleRepository) Create(Ctx context.Context, obj *domain.ChatRole) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatRole) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRoleRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IChatRoleRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.ChatRole
+func (_e *IChatRoleRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IChatRoleRepository_Create_Call {
+	return &IChatRoleRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IChatRoleRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.ChatRole)) *IChatRoleRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatRole))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_Create_Call) Return(_a0 error) *IChatRoleRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRoleRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.ChatRole) error) *IChatRoleRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRole provides a mock function with given fields: Ctx, role, audit
+func (_m *IChatRoleRepository) CreateRole(Ctx context.Context, role *domain.ChatRole, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, role, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ChatRole, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, role, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRoleRepository_CreateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRole'
+type IChatRoleRepository_CreateRole_Call struct {
+	*mock.Call
+}
+
+// CreateRole is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - role *domain.ChatRole
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRoleRepository_Expecter) CreateRole(Ctx interface{}, role interface{}, audit interface{}) *IChatRoleRepository_CreateRole_Call {
+	return &IChatRoleRepository_CreateRole_Call{Call: _e.mock.On("CreateRole", Ctx, role, audit)}
+}
+
+func (_c *IChatRoleRepository_CreateRole_Call) Run(run func(Ctx context.Context, role *domain.ChatRole, audit *domain.ChatAuditEntry)) *IChatRoleRepository_CreateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ChatRole), args[2].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_CreateRole_Call) Return(_a0 error) *IChatRoleRepository_CreateRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRoleRepository_CreateRole_Call) RunAndReturn(run func(context.Context, *domain.ChatRole, *domain.ChatAuditEntry) error) *IChatRoleRepository_CreateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IChatRoleRepository) DeleteById(Ctx context.Context, id int64) error {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRoleRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IChatRoleRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatRoleRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IChatRoleRepository_DeleteById_Call {
+	return &IChatRoleRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IChatRoleRepository_DeleteById_Call) Run(run func(Ctx context.Context, id int64)) *IChatRoleRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_DeleteById_Call) Return(_a0 error) *IChatRoleRepository_DeleteById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRoleRepository_DeleteById_Call) RunAndReturn(run func(context.Context, int64) error) *IChatRoleRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRole provides a mock function with given fields: Ctx, roleId, audit
+func (_m *IChatRoleRepository) DeleteRole(Ctx context.Context, roleId int64, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, roleId, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, roleId, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRoleRepository_DeleteRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRole'
+type IChatRoleRepository_DeleteRole_Call struct {
+	*mock.Call
+}
+
+// DeleteRole is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - roleId int64
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRoleRepository_Expecter) DeleteRole(Ctx interface{}, roleId interface{}, audit interface{}) *IChatRoleRepository_DeleteRole_Call {
+	return &IChatRoleRepository_DeleteRole_Call{Call: _e.mock.On("DeleteRole", Ctx, roleId, audit)}
+}
+
+func (_c *IChatRoleRepository_DeleteRole_Call) Run(run func(Ctx context.Context, roleId int64, audit *domain.ChatAuditEntry)) *IChatRoleRepository_DeleteRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_DeleteRole_Call) Return(_a0 error) *IChatRoleRepository_DeleteRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRoleRepository_DeleteRole_Call) RunAndReturn(run func(context.Context, int64, *domain.ChatAuditEntry) error) *IChatRoleRepository_DeleteRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteQuery provides a mock function with given fields: Ctx, query, args
+func (_m *IChatRoleRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRoleRepository_ExecuteQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteQuery'
+type IChatRoleRepository_ExecuteQuery_Call struct {
+	*mock.Call
+}
+
+// ExecuteQuery is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatRoleRepository_Expecter) ExecuteQuery(Ctx interface{}, query interface{}, args ...interface{}) *IChatRoleRepository_ExecuteQuery_Call {
+	return &IChatRoleRepository_ExecuteQuery_Call{Call: _e.mock.On("ExecuteQuery",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatRoleRepository_ExecuteQuery_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatRoleRepository_ExecuteQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_ExecuteQuery_Call) Return(_a0 error) *IChatRoleRepository_ExecuteQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRoleRepository_ExecuteQuery_Call) RunAndReturn(run func(context.Context, string, ...interface{}) error) *IChatRoleRepository_ExecuteQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with given fields: Ctx, query, args
+func (_m *IChatRoleRepository) Filter(Ctx context.Context, query string, args ...interface{}) ([]domain.ChatRole, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 []domain.ChatRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) ([]domain.ChatRole, error)); ok {
+		return rf(Ctx, query, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) []domain.ChatRole); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRoleRepository_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type IChatRoleRepository_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IChatRoleRepository_Expecter) Filter(Ctx interface{}, query interface{}, args ...interface{}) *IChatRoleRepository_Filter_Call {
+	return &IChatRoleRepository_Filter_Call{Call: _e.mock.On("Filter",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IChatRoleRepository_Filter_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IChatRoleRepository_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_Filter_Call) Return(_a0 []domain.ChatRole, _a1 error) *IChatRoleRepository_Filter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRoleRepository_Filter_Call) RunAndReturn(run func(context.Context, string, ...interface{}) ([]domain.ChatRole, error)) *IChatRoleRepository_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx
+func (_m *IChatRoleRepository) GetAll(Ctx context.Context) ([]domain.ChatRole, error) {
+	ret := _m.Called(Ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.ChatRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ChatRole, error)); ok {
+		return rf(Ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ChatRole); ok {
+		r0 = rf(Ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(Ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRoleRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IChatRoleRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+func (_e *IChatRoleRepository_Expecter) GetAll(Ctx interface{}) *IChatRoleRepository_GetAll_Call {
+	return &IChatRoleRepository_GetAll_Call{Call: _e.mock.On("GetAll", Ctx)}
+}
+
+func (_c *IChatRoleRepository_GetAll_Call) Run(run func(Ctx context.Context)) *IChatRoleRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetAll_Call) Return(_a0 []domain.ChatRole, _a1 error) *IChatRoleRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.ChatRole, error)) *IChatRoleRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: Ctx, id
+func (_m *IChatRoleRepository) GetById(Ctx context.Context, id int64) (domain.ChatRole, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 domain.ChatRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.ChatRole, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.ChatRole); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.ChatRole)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRoleRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IChatRoleRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IChatRoleRepository_Expecter) GetById(Ctx interface{}, id interface{}) *IChatRoleRepository_GetById_Call {
+	return &IChatRoleRepository_GetById_Call{Call: _e.mock.On("GetById", Ctx, id)}
+}
+
+func (_c *IChatRoleRepository_GetById_Call) Run(run func(Ctx context.Context, id int64)) *IChatRoleRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetById_Call) Return(_a0 domain.ChatRole, _a1 error) *IChatRoleRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetById_Call) RunAndReturn(run func(context.Context, int64) (domain.ChatRole, error)) *IChatRoleRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByName provides a mock function with given fields: Ctx, chatId, name
+func (_m *IChatRoleRepository) GetByName(Ctx context.Context, chatId int64, name string) (domain.ChatRole, error) {
+	ret := _m.Called(Ctx, chatId, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByName")
+	}
+
+	var r0 domain.ChatRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (domain.ChatRole, error)); ok {
+		return rf(Ctx, chatId, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) domain.ChatRole); ok {
+		r0 = rf(Ctx, chatId, name)
+	} else {
+		r0 = ret.Get(0).(domain.ChatRole)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(Ctx, chatId, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRoleRepository_GetByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByName'
+type IChatRoleRepository_GetByName_Call struct {
+	*mock.Call
+}
+
+// GetByName is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - name string
+func (_e *IChatRoleRepository_Expecter) GetByName(Ctx interface{}, chatId interface{}, name interface{}) *IChatRoleRepository_GetByName_Call {
+	return &IChatRoleRepository_GetByName_Call{Call: _e.mock.On("GetByName", Ctx, chatId, name)}
+}
+
+func (_c *IChatRoleRepository_GetByName_Call) Run(run func(Ctx context.Context, chatId int64, name string)) *IChatRoleRepository_GetByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetByName_Call) Return(_a0 domain.ChatRole, _a1 error) *IChatRoleRepository_GetByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetByName_Call) RunAndReturn(run func(context.Context, int64, string) (domain.ChatRole, error)) *IChatRoleRepository_GetByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatRole provides a mock function with given fields: Ctx, chatId, roleId
+func (_m *IChatRoleRepository) GetChatRole(Ctx context.Context, chatId int64, roleId int64) (domain.ChatRole, error) {
+	ret := _m.Called(Ctx, chatId, roleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatRole")
+	}
+
+	var r0 domain.ChatRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.ChatRole, error)); ok {
+		return rf(Ctx, chatId, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.ChatRole); ok {
+		r0 = rf(Ctx, chatId, roleId)
+	} else {
+		r0 = ret.Get(0).(domain.ChatRole)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(Ctx, chatId, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRoleRepository_GetChatRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatRole'
+type IChatRoleRepository_GetChatRole_Call struct {
+	*mock.Call
+}
+
+// GetChatRole is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - roleId int64
+func (_e *IChatRoleRepository_Expecter) GetChatRole(Ctx interface{}, chatId interface{}, roleId interface{}) *IChatRoleRepository_GetChatRole_Call {
+	return &IChatRoleRepository_GetChatRole_Call{Call: _e.mock.On("GetChatRole", Ctx, chatId, roleId)}
+}
+
+func (_c *IChatRoleRepository_GetChatRole_Call) Run(run func(Ctx context.Context, chatId int64, roleId int64)) *IChatRoleRepository_GetChatRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetChatRole_Call) Return(_a0 domain.ChatRole, _a1 error) *IChatRoleRepository_GetChatRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetChatRole_Call) RunAndReturn(run func(context.Context, int64, int64) (domain.ChatRole, error)) *IChatRoleRepository_GetChatRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetForChat provides a mock function with given fields: Ctx, chatId
+func (_m *IChatRoleRepository) GetForChat(Ctx context.Context, chatId int64) ([]domain.ChatRole, error) {
+	ret := _m.Called(Ctx, chatId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForChat")
+	}
+
+	var r0 []domain.ChatRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.ChatRole, error)); ok {
+		return rf(Ctx, chatId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.ChatRole); ok {
+		r0 = rf(Ctx, chatId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, chatId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IChatRoleRepository_GetForChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForChat'
+type IChatRoleRepository_GetForChat_Call struct {
+	*mock.Call
+}
+
+// GetForChat is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+func (_e *IChatRoleRepository_Expecter) GetForChat(Ctx interface{}, chatId interface{}) *IChatRoleRepository_GetForChat_Call {
+	return &IChatRoleRepository_GetForChat_Call{Call: _e.mock.On("GetForChat", Ctx, chatId)}
+}
+
+func (_c *IChatRoleRepository_GetForChat_Call) Run(run func(Ctx context.Context, chatId int64)) *IChatRoleRepository_GetForChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetForChat_Call) Return(_a0 []domain.ChatRole, _a1 error) *IChatRoleRepository_GetForChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IChatRoleRepository_GetForChat_Call) RunAndReturn(run func(context.Context, int64) ([]domain.ChatRole, error)) *IChatRoleRepository_GetForChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ManyToCreate provides a mock function with given fields: Ctx, objects
+func (_m *IChatRoleRepository) ManyToCreate(Ctx context.Context, objects []domain.ChatRole) error {
+	ret := _m.Called(Ctx, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManyToCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ChatRole) error); ok {
+		r0 = rf(Ctx, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRoleRepository_ManyToCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManyToCreate'
+type IChatRoleRepository_ManyToCreate_Call struct {
+	*mock.Call
+}
+
+// ManyToCreate is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - objects []domain.ChatRole
+func (_e *IChatRoleRepository_Expecter) ManyToCreate(Ctx interface{}, objects interface{}) *IChatRoleRepository_ManyToCreate_Call {
+	return &IChatRoleRepository_ManyToCreate_Call{Call: _e.mock.On("ManyToCreate", Ctx, objects)}
+}
+
+func (_c *IChatRoleRepository_ManyToCreate_Call) Run(run func(Ctx context.Context, objects []domain.ChatRole)) *IChatRoleRepository_ManyToCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.ChatRole))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_ManyToCreate_Call) Return(_a0 error) *IChatRoleRepository_ManyToCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRoleRepository_ManyToCreate_Call) RunAndReturn(run func(context.Context, []domain.ChatRole) error) *IChatRoleRepository_ManyToCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IChatRoleRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRoleRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IChatRoleRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+//   - updateFields map[string]interface{}
+func (_e *IChatRoleRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IChatRoleRepository_UpdateById_Call {
+	return &IChatRoleRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IChatRoleRepository_UpdateById_Call) Run(run func(Ctx context.Context, id int64, updateFields map[string]interface{})) *IChatRoleRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_UpdateById_Call) Return(_a0 error) *IChatRoleRepository_UpdateById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRoleRepository_UpdateById_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}) error) *IChatRoleRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function with given fields: Ctx, roleId, updateFields, audit
+func (_m *IChatRoleRepository) UpdateRole(Ctx context.Context, roleId int64, updateFields map[string]interface{}, audit *domain.ChatAuditEntry) error {
+	ret := _m.Called(Ctx, roleId, updateFields, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}, *domain.ChatAuditEntry) error); ok {
+		r0 = rf(Ctx, roleId, updateFields, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IChatRoleRepository_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type IChatRoleRepository_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - roleId int64
+//   - updateFields map[string]interface{}
+//   - audit *domain.ChatAuditEntry
+func (_e *IChatRoleRepository_Expecter) UpdateRole(Ctx interface{}, roleId interface{}, updateFields interface{}, audit interface{}) *IChatRoleRepository_UpdateRole_Call {
+	return &IChatRoleRepository_UpdateRole_Call{Call: _e.mock.On("UpdateRole", Ctx, roleId, updateFields, audit)}
+}
+
+func (_c *IChatRoleRepository_UpdateRole_Call) Run(run func(Ctx context.Context, roleId int64, updateFields map[string]interface{}, audit *domain.ChatAuditEntry)) *IChatRoleRepository_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}), args[3].(*domain.ChatAuditEntry))
+	})
+	return _c
+}
+
+func (_c *IChatRoleRepository_UpdateRole_Call) Return(_a0 error) *IChatRoleRepository_UpdateRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IChatRoleRepository_UpdateRole_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}, *domain.ChatAuditEntry) error) *IChatRoleRepository_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIChatRoleRepository creates a new instance of IChatRoleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChatRoleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChatRoleRepository {
+	mock := &IChatRoleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,768 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IJoinRequestRepository is an autogenerated mock type for the IJoinRequestRepository type
+type IJoinRequestRepository struct {
+	mock.Mock
+}
+
+type IJoinRequestRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IJoinRequestRepository) EXPECT() *IJoinRequestRepository_Expecter {
+	return &IJoinRequestRepository_Expecter{mock: &_m.Mock}
+}
+
+// Count provides a mock function with given fields: Ctx, filter, args
+func (_m *IJoinRequestRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(Ctx, filter, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(Ctx, filter, args...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, filter, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IJoinRequestRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IJoinRequestRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter string
+//   - args ...interface{}
+func (_e *IJoinRequestRepository_Expecter) Count(Ctx interface{}, filter interface{}, args ...interface{}) *IJoinRequestRepository_Count_Call {
+	return &IJoinRequestRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{Ctx, filter}, args...)...)}
+}
+
+func (_c *IJoinRequestRepository_Count_Call) Run(run func(Ctx context.Context, filter string, args ...interface{})) *IJoinRequestRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_Count_Call) Return(_a0 int64, _a1 error) *IJoinRequestRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_Count_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *IJoinRequestRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IJoinRequestRepository) Create(Ctx context.Context, obj *domain.JoinRequest) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.JoinRequest) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IJoinRequestRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IJoinRequestRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.JoinRequest
+func (_e *IJoinRequestRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IJoinRequestRepository_Create_Call {
+	return &IJoinRequestRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IJoinRequestRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.JoinRequest)) *IJoinRequestRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.JoinRequest))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_Create_Call) Return(_a0 error) *IJoinRequestRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.JoinRequest) error) *IJoinRequestRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IJoinRequestRepository) DeleteById(Ctx context.Context, id int64) error {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IJoinRequestRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IJoinRequestRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IJoinRequestRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IJoinRequestRepository_DeleteById_Call {
+	return &IJoinRequestRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IJoinRequestRepository_DeleteById_Call) Run(run func(Ctx context.Context, id int64)) *IJoinRequestRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_DeleteById_Call) Return(_a0 error) *IJoinRequestRepository_DeleteById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_DeleteById_Call) RunAndReturn(run func(context.Context, int64) error) *IJoinRequestRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteQuery provides a mock function with given fields: Ctx, query, args
+func (_m *IJoinRequestRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IJoinRequestRepository_ExecuteQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteQuery'
+type IJoinRequestRepository_ExecuteQuery_Call struct {
+	*mock.Call
+}
+
+// ExecuteQuery is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IJoinRequestRepository_Expecter) ExecuteQuery(Ctx interface{}, query interface{}, args ...interface{}) *IJoinRequestRepository_ExecuteQuery_Call {
+	return &IJoinRequestRepository_ExecuteQuery_Call{Call: _e.mock.On("ExecuteQuery",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IJoinRequestRepository_ExecuteQuery_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IJoinRequestRepository_ExecuteQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_ExecuteQuery_Call) Return(_a0 error) *IJoinRequestRepository_ExecuteQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_ExecuteQuery_Call) RunAndReturn(run func(context.Context, string, ...interface{}) error) *IJoinRequestRepository_ExecuteQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with given fields: Ctx, query, args
+func (_m *IJoinRequestRepository) Filter(Ctx context.Context, query string, args ...interface{}) ([]domain.JoinRequest, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 []domain.JoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) ([]domain.JoinRequest, error)); ok {
+		return rf(Ctx, query, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) []domain.JoinRequest); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.JoinRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IJoinRequestRepository_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type IJoinRequestRepository_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IJoinRequestRepository_Expecter) Filter(Ctx interface{}, query interface{}, args ...interface{}) *IJoinRequestRepository_Filter_Call {
+	return &IJoinRequestRepository_Filter_Call{Call: _e.mock.On("Filter",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IJoinRequestRepository_Filter_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IJoinRequestRepository_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_Filter_Call) Return(_a0 []domain.JoinRequest, _a1 error) *IJoinRequestRepository_Filter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_Filter_Call) RunAndReturn(run func(context.Context, string, ...interface{}) ([]domain.JoinRequest, error)) *IJoinRequestRepository_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx
+func (_m *IJoinRequestRepository) GetAll(Ctx context.Context) ([]domain.JoinRequest, error) {
+	ret := _m.Called(Ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.JoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.JoinRequest, error)); ok {
+		return rf(Ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.JoinRequest); ok {
+		r0 = rf(Ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.JoinRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(Ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IJoinRequestRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IJoinRequestRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+func (_e *IJoinRequestRepository_Expecter) GetAll(Ctx interface{}) *IJoinRequestRepository_GetAll_Call {
+	return &IJoinRequestRepository_GetAll_Call{Call: _e.mock.On("GetAll", Ctx)}
+}
+
+func (_c *IJoinRequestRepository_GetAll_Call) Run(run func(Ctx context.Context)) *IJoinRequestRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetAll_Call) Return(_a0 []domain.JoinRequest, _a1 error) *IJoinRequestRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.JoinRequest, error)) *IJoinRequestRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: Ctx, id
+func (_m *IJoinRequestRepository) GetById(Ctx context.Context, id int64) (domain.JoinRequest, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 domain.JoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.JoinRequest, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.JoinRequest); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.JoinRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IJoinRequestRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IJoinRequestRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IJoinRequestRepository_Expecter) GetById(Ctx interface{}, id interface{}) *IJoinRequestRepository_GetById_Call {
+	return &IJoinRequestRepository_GetById_Call{Call: _e.mock.On("GetById", Ctx, id)}
+}
+
+func (_c *IJoinRequestRepository_GetById_Call) Run(run func(Ctx context.Context, id int64)) *IJoinRequestRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetById_Call) Return(_a0 domain.JoinRequest, _a1 error) *IJoinRequestRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetById_Call) RunAndReturn(run func(context.Context, int64) (domain.JoinRequest, error)) *IJoinRequestRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetForUser provides a mock function with given fields: Ctx, userId, limit, offset
+func (_m *IJoinRequestRepository) GetForUser(Ctx context.Context, userId int64, limit int, offset int) ([]domain.JoinRequest, error) {
+	ret := _m.Called(Ctx, userId, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUser")
+	}
+
+	var r0 []domain.JoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) ([]domain.JoinRequest, error)); ok {
+		return rf(Ctx, userId, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.JoinRequest); ok {
+		r0 = rf(Ctx, userId, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.JoinRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(Ctx, userId, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IJoinRequestRepository_GetForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForUser'
+type IJoinRequestRepository_GetForUser_Call struct {
+	*mock.Call
+}
+
+// GetForUser is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - userId int64
+//   - limit int
+//   - offset int
+func (_e *IJoinRequestRepository_Expecter) GetForUser(Ctx interface{}, userId interface{}, limit interface{}, offset interface{}) *IJoinRequestRepository_GetForUser_Call {
+	return &IJoinRequestRepository_GetForUser_Call{Call: _e.mock.On("GetForUser", Ctx, userId, limit, offset)}
+}
+
+func (_c *IJoinRequestRepository_GetForUser_Call) Run(run func(Ctx context.Context, userId int64, limit int, offset int)) *IJoinRequestRepository_GetForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetForUser_Call) Return(_a0 []domain.JoinRequest, _a1 error) *IJoinRequestRepository_GetForUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetForUser_Call) RunAndReturn(run func(context.Context, int64, int, int) ([]domain.JoinRequest, error)) *IJoinRequestRepository_GetForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPending provides a mock function with given fields: Ctx, requestId, chatId
+func (_m *IJoinRequestRepository) GetPending(Ctx context.Context, requestId int64, chatId int64) (domain.JoinRequest, error) {
+	ret := _m.Called(Ctx, requestId, chatId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPending")
+	}
+
+	var r0 domain.JoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.JoinRequest, error)); ok {
+		return rf(Ctx, requestId, chatId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.JoinRequest); ok {
+		r0 = rf(Ctx, requestId, chatId)
+	} else {
+		r0 = ret.Get(0).(domain.JoinRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(Ctx, requestId, chatId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IJoinRequestRepository_GetPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPending'
+type IJoinRequestRepository_GetPending_Call struct {
+	*mock.Call
+}
+
+// GetPending is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - requestId int64
+//   - chatId int64
+func (_e *IJoinRequestRepository_Expecter) GetPending(Ctx interface{}, requestId interface{}, chatId interface{}) *IJoinRequestRepository_GetPending_Call {
+	return &IJoinRequestRepository_GetPending_Call{Call: _e.mock.On("GetPending", Ctx, requestId, chatId)}
+}
+
+func (_c *IJoinRequestRepository_GetPending_Call) Run(run func(Ctx context.Context, requestId int64, chatId int64)) *IJoinRequestRepository_GetPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetPending_Call) Return(_a0 domain.JoinRequest, _a1 error) *IJoinRequestRepository_GetPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetPending_Call) RunAndReturn(run func(context.Context, int64, int64) (domain.JoinRequest, error)) *IJoinRequestRepository_GetPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQueue provides a mock function with given fields: Ctx, chatId, limit, offset
+func (_m *IJoinRequestRepository) GetQueue(Ctx context.Context, chatId int64, limit int, offset int) ([]domain.JoinRequest, error) {
+	ret := _m.Called(Ctx, chatId, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueue")
+	}
+
+	var r0 []domain.JoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) ([]domain.JoinRequest, error)); ok {
+		return rf(Ctx, chatId, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.JoinRequest); ok {
+		r0 = rf(Ctx, chatId, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.JoinRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(Ctx, chatId, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IJoinRequestRepository_GetQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQueue'
+type IJoinRequestRepository_GetQueue_Call struct {
+	*mock.Call
+}
+
+// GetQueue is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - limit int
+//   - offset int
+func (_e *IJoinRequestRepository_Expecter) GetQueue(Ctx interface{}, chatId interface{}, limit interface{}, offset interface{}) *IJoinRequestRepository_GetQueue_Call {
+	return &IJoinRequestRepository_GetQueue_Call{Call: _e.mock.On("GetQueue", Ctx, chatId, limit, offset)}
+}
+
+func (_c *IJoinRequestRepository_GetQueue_Call) Run(run func(Ctx context.Context, chatId int64, limit int, offset int)) *IJoinRequestRepository_GetQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetQueue_Call) Return(_a0 []domain.JoinRequest, _a1 error) *IJoinRequestRepository_GetQueue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_GetQueue_Call) RunAndReturn(run func(context.Context, int64, int, int) ([]domain.JoinRequest, error)) *IJoinRequestRepository_GetQueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ManyToCreate provides a mock function with given fields: Ctx, objects
+func (_m *IJoinRequestRepository) ManyToCreate(Ctx context.Context, objects []domain.JoinRequest) error {
+	ret := _m.Called(Ctx, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManyToCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.JoinRequest) error); ok {
+		r0 = rf(Ctx, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IJoinRequestRepository_ManyToCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManyToCreate'
+type IJoinRequestRepository_ManyToCreate_Call struct {
+	*mock.Call
+}
+
+// ManyToCreate is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - objects []domain.JoinRequest
+func (_e *IJoinRequestRepository_Expecter) ManyToCreate(Ctx interface{}, objects interface{}) *IJoinRequestRepository_ManyToCreate_Call {
+	return &IJoinRequestRepository_ManyToCreate_Call{Call: _e.mock.On("ManyToCreate", Ctx, objects)}
+}
+
+func (_c *IJoinRequestRepository_ManyToCreate_Call) Run(run func(Ctx context.Context, objects []domain.JoinRequest)) *IJoinRequestRepository_ManyToCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.JoinRequest))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_ManyToCreate_Call) Return(_a0 error) *IJoinRequestRepository_ManyToCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_ManyToCreate_Call) RunAndReturn(run func(context.Context, []domain.JoinRequest) error) *IJoinRequestRepository_ManyToCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Review provides a mock function with given fields: Ctx, requestId, chatId, reviewerId, status, reason
+func (_m *IJoinRequestRepository) Review(Ctx context.Context, requestId int64, chatId int64, reviewerId int64, status byte, reason string) error {
+	ret := _m.Called(Ctx, requestId, chatId, reviewerId, status, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Review")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, byte, string) error); ok {
+		r0 = rf(Ctx, requestId, chatId, reviewerId, status, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IJoinRequestRepository_Review_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Review'
+type IJoinRequestRepository_Review_Call struct {
+	*mock.Call
+}
+
+// Review is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - requestId int64
+//   - chatId int64
+//   - reviewerId int64
+//   - status byte
+//   - reason string
+func (_e *IJoinRequestRepository_Expecter) Review(Ctx interface{}, requestId interface{}, chatId interface{}, reviewerId interface{}, status interface{}, reason interface{}) *IJoinRequestRepository_Review_Call {
+	return &IJoinRequestRepository_Review_Call{Call: _e.mock.On("Review", Ctx, requestId, chatId, reviewerId, status, reason)}
+}
+
+func (_c *IJoinRequestRepository_Review_Call) Run(run func(Ctx context.Context, requestId int64, chatId int64, reviewerId int64, status byte, reason string)) *IJoinRequestRepository_Review_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64), args[4].(byte), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_Review_Call) Return(_a0 error) *IJoinRequestRepository_Review_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_Review_Call) RunAndReturn(run func(context.Context, int64, int64, int64, byte, string) error) *IJoinRequestRepository_Review_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IJoinRequestRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IJoinRequestRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IJoinRequestRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+//   - updateFields map[string]interface{}
+func (_e *IJoinRequestRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IJoinRequestRepository_UpdateById_Call {
+	return &IJoinRequestRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IJoinRequestRepository_UpdateById_Call) Run(run func(Ctx context.Context, id int64, updateFields map[string]interface{})) *IJoinRequestRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *IJoinRequestRepository_UpdateById_Call) Return(_a0 error) *IJoinRequestRepository_UpdateById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IJoinRequestRepository_UpdateById_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}) error) *IJoinRequestRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIJoinRequestRepository creates a new instance of IJoinRequestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIJoinRequestRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IJoinRequestRepository {
+	mock := &IJoinRequestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
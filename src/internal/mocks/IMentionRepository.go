@@ -0,0 +1,798 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IMentionRepository is an autogenerated mock type for the IMentionRepository type
+type IMentionRepository struct {
+	mock.Mock
+}
+
+type IMentionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IMentionRepository) EXPECT() *IMentionRepository_Expecter {
+	return &IMentionRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddMany provides a mock function with given fields: Ctx, mentions
+func (_m *IMentionRepository) AddMany(Ctx context.Context, mentions []domain.Mention) error {
+	ret := _m.Called(Ctx, mentions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMany")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Mention) error); ok {
+		r0 = rf(Ctx, mentions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMentionRepository_AddMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMany'
+type IMentionRepository_AddMany_Call struct {
+	*mock.Call
+}
+
+// AddMany is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - mentions []domain.Mention
+func (_e *IMentionRepository_Expecter) AddMany(Ctx interface{}, mentions interface{}) *IMentionRepository_AddMany_Call {
+	return &IMentionRepository_AddMany_Call{Call: _e.mock.On("AddMany", Ctx, mentions)}
+}
+
+func (_c *IMentionRepository_AddMany_Call) Run(run func(Ctx context.Context, mentions []domain.Mention)) *IMentionRepository_AddMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Mention))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_AddMany_Call) Return(_a0 error) *IMentionRepository_AddMany_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMentionRepository_AddMany_Call) RunAndReturn(run func(context.Context, []domain.Mention) error) *IMentionRepository_AddMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function with given fields: Ctx, filter, args
+func (_m *IMentionRepository) Count(Ctx context.Context, filter string, args ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(Ctx, filter, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(Ctx, filter, args...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, filter, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMentionRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IMentionRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter string
+//   - args ...interface{}
+func (_e *IMentionRepository_Expecter) Count(Ctx interface{}, filter interface{}, args ...interface{}) *IMentionRepository_Count_Call {
+	return &IMentionRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{Ctx, filter}, args...)...)}
+}
+
+func (_c *IMentionRepository_Count_Call) Run(run func(Ctx context.Context, filter string, args ...interface{})) *IMentionRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_Count_Call) Return(_a0 int64, _a1 error) *IMentionRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMentionRepository_Count_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *IMentionRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUnread provides a mock function with given fields: Ctx, userId
+func (_m *IMentionRepository) CountUnread(Ctx context.Context, userId int64) (int64, error) {
+	ret := _m.Called(Ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnread")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(Ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(Ctx, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMentionRepository_CountUnread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUnread'
+type IMentionRepository_CountUnread_Call struct {
+	*mock.Call
+}
+
+// CountUnread is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - userId int64
+func (_e *IMentionRepository_Expecter) CountUnread(Ctx interface{}, userId interface{}) *IMentionRepository_CountUnread_Call {
+	return &IMentionRepository_CountUnread_Call{Call: _e.mock.On("CountUnread", Ctx, userId)}
+}
+
+func (_c *IMentionRepository_CountUnread_Call) Run(run func(Ctx context.Context, userId int64)) *IMentionRepository_CountUnread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_CountUnread_Call) Return(_a0 int64, _a1 error) *IMentionRepository_CountUnread_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMentionRepository_CountUnread_Call) RunAndReturn(run func(context.Context, int64) (int64, error)) *IMentionRepository_CountUnread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IMentionRepository) Create(Ctx context.Context, obj *domain.Mention) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Mention) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMentionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IMentionRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.Mention
+func (_e *IMentionRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IMentionRepository_Create_Call {
+	return &IMentionRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IMentionRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.Mention)) *IMentionRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Mention))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_Create_Call) Return(_a0 error) *IMentionRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMentionRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.Mention) error) *IMentionRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IMentionRepository) DeleteById(Ctx context.Context, id int64) error {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMentionRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IMentionRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IMentionRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IMentionRepository_DeleteById_Call {
+	return &IMentionRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IMentionRepository_DeleteById_Call) Run(run func(Ctx context.Context, id int64)) *IMentionRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_DeleteById_Call) Return(_a0 error) *IMentionRepository_DeleteById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMentionRepository_DeleteById_Call) RunAndReturn(run func(context.Context, int64) error) *IMentionRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteQuery provides a mock function with given fields: Ctx, query, args
+func (_m *IMentionRepository) ExecuteQuery(Ctx context.Context, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMentionRepository_ExecuteQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteQuery'
+type IMentionRepository_ExecuteQuery_Call struct {
+	*mock.Call
+}
+
+// ExecuteQuery is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IMentionRepository_Expecter) ExecuteQuery(Ctx interface{}, query interface{}, args ...interface{}) *IMentionRepository_ExecuteQuery_Call {
+	return &IMentionRepository_ExecuteQuery_Call{Call: _e.mock.On("ExecuteQuery",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IMentionRepository_ExecuteQuery_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IMentionRepository_ExecuteQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_ExecuteQuery_Call) Return(_a0 error) *IMentionRepository_ExecuteQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMentionRepository_ExecuteQuery_Call) RunAndReturn(run func(context.Context, string, ...interface{}) error) *IMentionRepository_ExecuteQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with given fields: Ctx, query, args
+func (_m *IMentionRepository) Filter(Ctx context.Context, query string, args ...interface{}) ([]domain.Mention, error) {
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 []domain.Mention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) ([]domain.Mention, error)); ok {
+		return rf(Ctx, query, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) []domain.Mention); ok {
+		r0 = rf(Ctx, query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Mention)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(Ctx, query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMentionRepository_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type IMentionRepository_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - query string
+//   - args ...interface{}
+func (_e *IMentionRepository_Expecter) Filter(Ctx interface{}, query interface{}, args ...interface{}) *IMentionRepository_Filter_Call {
+	return &IMentionRepository_Filter_Call{Call: _e.mock.On("Filter",
+		append([]interface{}{Ctx, query}, args...)...)}
+}
+
+func (_c *IMentionRepository_Filter_Call) Run(run func(Ctx context.Context, query string, args ...interface{})) *IMentionRepository_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_Filter_Call) Return(_a0 []domain.Mention, _a1 error) *IMentionRepository_Filter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMentionRepository_Filter_Call) RunAndReturn(run func(context.Context, string, ...interface{}) ([]domain.Mention, error)) *IMentionRepository_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx
+func (_m *IMentionRepository) GetAll(Ctx context.Context) ([]domain.Mention, error) {
+	ret := _m.Called(Ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.Mention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Mention, error)); ok {
+		return rf(Ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Mention); ok {
+		r0 = rf(Ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Mention)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(Ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMentionRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IMentionRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+func (_e *IMentionRepository_Expecter) GetAll(Ctx interface{}) *IMentionRepository_GetAll_Call {
+	return &IMentionRepository_GetAll_Call{Call: _e.mock.On("GetAll", Ctx)}
+}
+
+func (_c *IMentionRepository_GetAll_Call) Run(run func(Ctx context.Context)) *IMentionRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_GetAll_Call) Return(_a0 []domain.Mention, _a1 error) *IMentionRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMentionRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.Mention, error)) *IMentionRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: Ctx, id
+func (_m *IMentionRepository) GetById(Ctx context.Context, id int64) (domain.Mention, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 domain.Mention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Mention, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Mention); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Mention)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMentionRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IMentionRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+func (_e *IMentionRepository_Expecter) GetById(Ctx interface{}, id interface{}) *IMentionRepository_GetById_Call {
+	return &IMentionRepository_GetById_Call{Call: _e.mock.On("GetById", Ctx, id)}
+}
+
+func (_c *IMentionRepository_GetById_Call) Run(run func(Ctx context.Context, id int64)) *IMentionRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_GetById_Call) Return(_a0 domain.Mention, _a1 error) *IMentionRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMentionRepository_GetById_Call) RunAndReturn(run func(context.Context, int64) (domain.Mention, error)) *IMentionRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetForUser provides a mock function with given fields: Ctx, userId, unreadOnly, limit, offset
+func (_m *IMentionRepository) GetForUser(Ctx context.Context, userId int64, unreadOnly bool, limit int, offset int) ([]domain.Mention, error) {
+	ret := _m.Called(Ctx, userId, unreadOnly, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUser")
+	}
+
+	var r0 []domain.Mention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool, int, int) ([]domain.Mention, error)); ok {
+		return rf(Ctx, userId, unreadOnly, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool, int, int) []domain.Mention); ok {
+		r0 = rf(Ctx, userId, unreadOnly, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Mention)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, bool, int, int) error); ok {
+		r1 = rf(Ctx, userId, unreadOnly, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMentionRepository_GetForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForUser'
+type IMentionRepository_GetForUser_Call struct {
+	*mock.Call
+}
+
+// GetForUser is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - userId int64
+//   - unreadOnly bool
+//   - limit int
+//   - offset int
+func (_e *IMentionRepository_Expecter) GetForUser(Ctx interface{}, userId interface{}, unreadOnly interface{}, limit interface{}, offset interface{}) *IMentionRepository_GetForUser_Call {
+	return &IMentionRepository_GetForUser_Call{Call: _e.mock.On("GetForUser", Ctx, userId, unreadOnly, limit, offset)}
+}
+
+func (_c *IMentionRepository_GetForUser_Call) Run(run func(Ctx context.Context, userId int64, unreadOnly bool, limit int, offset int)) *IMentionRepository_GetForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_GetForUser_Call) Return(_a0 []domain.Mention, _a1 error) *IMentionRepository_GetForUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMentionRepository_GetForUser_Call) RunAndReturn(run func(context.Context, int64, bool, int, int) ([]domain.Mention, error)) *IMentionRepository_GetForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ManyToCreate provides a mock function with given fields: Ctx, objects
+func (_m *IMentionRepository) ManyToCreate(Ctx context.Context, objects []domain.Mention) error {
+	ret := _m.Called(Ctx, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManyToCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Mention) error); ok {
+		r0 = rf(Ctx, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMentionRepository_ManyToCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManyToCreate'
+type IMentionRepository_ManyToCreate_Call struct {
+	*mock.Call
+}
+
+// ManyToCreate is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - objects []domain.Mention
+func (_e *IMentionRepository_Expecter) ManyToCreate(Ctx interface{}, objects interface{}) *IMentionRepository_ManyToCreate_Call {
+	return &IMentionRepository_ManyToCreate_Call{Call: _e.mock.On("ManyToCreate", Ctx, objects)}
+}
+
+func (_c *IMentionRepository_ManyToCreate_Call) Run(run func(Ctx context.Context, objects []domain.Mention)) *IMentionRepository_ManyToCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Mention))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_ManyToCreate_Call) Return(_a0 error) *IMentionRepository_ManyToCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMentionRepository_ManyToCreate_Call) RunAndReturn(run func(context.Context, []domain.Mention) error) *IMentionRepository_ManyToCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRead provides a mock function with given fields: Ctx, userId, mentionIds
+func (_m *IMentionRepository) MarkRead(Ctx context.Context, userId int64, mentionIds []int64) error {
+	ret := _m.Called(Ctx, userId, mentionIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64) error); ok {
+		r0 = rf(Ctx, userId, mentionIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMentionRepository_MarkRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRead'
+type IMentionRepository_MarkRead_Call struct {
+	*mock.Call
+}
+
+// MarkRead is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - userId int64
+//   - mentionIds []int64
+func (_e *IMentionRepository_Expecter) MarkRead(Ctx interface{}, userId interface{}, mentionIds interface{}) *IMentionRepository_MarkRead_Call {
+	return &IMentionRepository_MarkRead_Call{Call: _e.mock.On("MarkRead", Ctx, userId, mentionIds)}
+}
+
+func (_c *IMentionRepository_MarkRead_Call) Run(run func(Ctx context.Context, userId int64, mentionIds []int64)) *IMentionRepository_MarkRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]int64))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_MarkRead_Call) Return(_a0 error) *IMentionRepository_MarkRead_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMentionRepository_MarkRead_Call) RunAndReturn(run func(context.Context, int64, []int64) error) *IMentionRepository_MarkRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveForMessage provides a mock function with given fields: Ctx, messageId
+func (_m *IMentionRepository) RemoveForMessage(Ctx context.Context, messageId string) error {
+	ret := _m.Called(Ctx, messageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveForMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(Ctx, messageId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMentionRepository_RemoveForMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveForMessage'
+type IMentionRepository_RemoveForMessage_Call struct {
+	*mock.Call
+}
+
+// RemoveForMessage is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - messageId string
+func (_e *IMentionRepository_Expecter) RemoveForMessage(Ctx interface{}, messageId interface{}) *IMentionRepository_RemoveForMessage_Call {
+	return &IMentionRepository_RemoveForMessage_Call{Call: _e.mock.On("RemoveForMessage", Ctx, messageId)}
+}
+
+func (_c *IMentionRepository_RemoveForMessage_Call) Run(run func(Ctx context.Context, messageId string)) *IMentionRepository_RemoveForMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_RemoveForMessage_Call) Return(_a0 error) *IMentionRepository_RemoveForMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMentionRepository_RemoveForMessage_Call) RunAndReturn(run func(context.Context, string) error) *IMentionRepository_RemoveForMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IMentionRepository) UpdateById(Ctx context.Context, id int64, updateFields map[string]interface{}) error {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMentionRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IMentionRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id int64
+//   - updateFields map[string]interface{}
+func (_e *IMentionRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IMentionRepository_UpdateById_Call {
+	return &IMentionRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IMentionRepository_UpdateById_Call) Run(run func(Ctx context.Context, id int64, updateFields map[string]interface{})) *IMentionRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *IMentionRepository_UpdateById_Call) Return(_a0 error) *IMentionRepository_UpdateById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMentionRepository_UpdateById_Call) RunAndReturn(run func(context.Context, int64, map[string]interface{}) error) *IMentionRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIMentionRepository creates a new instance of IMentionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIMentionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IMentionRepository {
+	mock := &IMentionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
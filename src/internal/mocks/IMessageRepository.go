@@ -0,0 +1,1089 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "libs/src/internal/domain/models"
+	repositories "libs/src/internal/repositories"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// IMessageRepository is an autogenerated mock type for the IMessageRepository type
+type IMessageRepository struct {
+	mock.Mock
+}
+
+type IMessageRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IMessageRepository) EXPECT() *IMessageRepository_Expecter {
+	return &IMessageRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddReaction provides a mock function with given fields: Ctx, id, emoji, userId, maxDistinct
+func (_m *IMessageRepository) AddReaction(Ctx context.Context, id primitive.ObjectID, emoji string, userId int64, maxDistinct int) error {
+	ret := _m.Called(Ctx, id, emoji, userId, maxDistinct)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string, int64, int) error); ok {
+		r0 = rf(Ctx, id, emoji, userId, maxDistinct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMessageRepository_AddReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReaction'
+type IMessageRepository_AddReaction_Call struct {
+	*mock.Call
+}
+
+// AddReaction is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id primitive.ObjectID
+//   - emoji string
+//   - userId int64
+//   - maxDistinct int
+func (_e *IMessageRepository_Expecter) AddReaction(Ctx interface{}, id interface{}, emoji interface{}, userId interface{}, maxDistinct interface{}) *IMessageRepository_AddReaction_Call {
+	return &IMessageRepository_AddReaction_Call{Call: _e.mock.On("AddReaction", Ctx, id, emoji, userId, maxDistinct)}
+}
+
+func (_c *IMessageRepository_AddReaction_Call) Run(run func(Ctx context.Context, id primitive.ObjectID, emoji string, userId int64, maxDistinct int)) *IMessageRepository_AddReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string), args[3].(int64), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_AddReaction_Call) Return(_a0 error) *IMessageRepository_AddReaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMessageRepository_AddReaction_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string, int64, int) error) *IMessageRepository_AddReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddReply provides a mock function with given fields: Ctx, threadId, repliedAt, senderId
+func (_m *IMessageRepository) AddReply(Ctx context.Context, threadId primitive.ObjectID, repliedAt time.Time, senderId int64) error {
+	ret := _m.Called(Ctx, threadId, repliedAt, senderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReply")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time, int64) error); ok {
+		r0 = rf(Ctx, threadId, repliedAt, senderId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMessageRepository_AddReply_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReply'
+type IMessageRepository_AddReply_Call struct {
+	*mock.Call
+}
+
+// AddReply is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - threadId primitive.ObjectID
+//   - repliedAt time.Time
+//   - senderId int64
+func (_e *IMessageRepository_Expecter) AddReply(Ctx interface{}, threadId interface{}, repliedAt interface{}, senderId interface{}) *IMessageRepository_AddReply_Call {
+	return &IMessageRepository_AddReply_Call{Call: _e.mock.On("AddReply", Ctx, threadId, repliedAt, senderId)}
+}
+
+func (_c *IMessageRepository_AddReply_Call) Run(run func(Ctx context.Context, threadId primitive.ObjectID, repliedAt time.Time, senderId int64)) *IMessageRepository_AddReply_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(time.Time), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_AddReply_Call) Return(_a0 error) *IMessageRepository_AddReply_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMessageRepository_AddReply_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, time.Time, int64) error) *IMessageRepository_AddReply_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function with given fields: Ctx, filters
+func (_m *IMessageRepository) Count(Ctx context.Context, filters interface{}) (int64, error) {
+	ret := _m.Called(Ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) (int64, error)); ok {
+		return rf(Ctx, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) int64); ok {
+		r0 = rf(Ctx, filters)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(Ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type IMessageRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filters interface{}
+func (_e *IMessageRepository_Expecter) Count(Ctx interface{}, filters interface{}) *IMessageRepository_Count_Call {
+	return &IMessageRepository_Count_Call{Call: _e.mock.On("Count", Ctx, filters)}
+}
+
+func (_c *IMessageRepository_Count_Call) Run(run func(Ctx context.Context, filters interface{})) *IMessageRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_Count_Call) Return(_a0 int64, _a1 error) *IMessageRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_Count_Call) RunAndReturn(run func(context.Context, interface{}) (int64, error)) *IMessageRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUnread provides a mock function with given fields: Ctx, userId, members
+func (_m *IMessageRepository) CountUnread(Ctx context.Context, userId int64, members []domain.ChatMember) (map[int64]int64, error) {
+	ret := _m.Called(Ctx, userId, members)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnread")
+	}
+
+	var r0 map[int64]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []domain.ChatMember) (map[int64]int64, error)); ok {
+		return rf(Ctx, userId, members)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []domain.ChatMember) map[int64]int64); ok {
+		r0 = rf(Ctx, userId, members)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []domain.ChatMember) error); ok {
+		r1 = rf(Ctx, userId, members)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_CountUnread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUnread'
+type IMessageRepository_CountUnread_Call struct {
+	*mock.Call
+}
+
+// CountUnread is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - userId int64
+//   - members []domain.ChatMember
+func (_e *IMessageRepository_Expecter) CountUnread(Ctx interface{}, userId interface{}, members interface{}) *IMessageRepository_CountUnread_Call {
+	return &IMessageRepository_CountUnread_Call{Call: _e.mock.On("CountUnread", Ctx, userId, members)}
+}
+
+func (_c *IMessageRepository_CountUnread_Call) Run(run func(Ctx context.Context, userId int64, members []domain.ChatMember)) *IMessageRepository_CountUnread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]domain.ChatMember))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_CountUnread_Call) Return(_a0 map[int64]int64, _a1 error) *IMessageRepository_CountUnread_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_CountUnread_Call) RunAndReturn(run func(context.Context, int64, []domain.ChatMember) (map[int64]int64, error)) *IMessageRepository_CountUnread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: Ctx, obj
+func (_m *IMessageRepository) Create(Ctx context.Context, obj *domain.Message) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Message) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMessageRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IMessageRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj *domain.Message
+func (_e *IMessageRepository_Expecter) Create(Ctx interface{}, obj interface{}) *IMessageRepository_Create_Call {
+	return &IMessageRepository_Create_Call{Call: _e.mock.On("Create", Ctx, obj)}
+}
+
+func (_c *IMessageRepository_Create_Call) Run(run func(Ctx context.Context, obj *domain.Message)) *IMessageRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Message))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_Create_Call) Return(_a0 error) *IMessageRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMessageRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.Message) error) *IMessageRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateIndex provides a mock function with no fields
+func (_m *IMessageRepository) CreateIndex() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIndex")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMessageRepository_CreateIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIndex'
+type IMessageRepository_CreateIndex_Call struct {
+	*mock.Call
+}
+
+// CreateIndex is a helper method to define mock.On call
+func (_e *IMessageRepository_Expecter) CreateIndex() *IMessageRepository_CreateIndex_Call {
+	return &IMessageRepository_CreateIndex_Call{Call: _e.mock.On("CreateIndex")}
+}
+
+func (_c *IMessageRepository_CreateIndex_Call) Run(run func()) *IMessageRepository_CreateIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_CreateIndex_Call) Return(_a0 error) *IMessageRepository_CreateIndex_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMessageRepository_CreateIndex_Call) RunAndReturn(run func() error) *IMessageRepository_CreateIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateMany provides a mock function with given fields: Ctx, obj
+func (_m *IMessageRepository) CreateMany(Ctx context.Context, obj []domain.Message) error {
+	ret := _m.Called(Ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMany")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Message) error); ok {
+		r0 = rf(Ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMessageRepository_CreateMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMany'
+type IMessageRepository_CreateMany_Call struct {
+	*mock.Call
+}
+
+// CreateMany is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - obj []domain.Message
+func (_e *IMessageRepository_Expecter) CreateMany(Ctx interface{}, obj interface{}) *IMessageRepository_CreateMany_Call {
+	return &IMessageRepository_CreateMany_Call{Call: _e.mock.On("CreateMany", Ctx, obj)}
+}
+
+func (_c *IMessageRepository_CreateMany_Call) Run(run func(Ctx context.Context, obj []domain.Message)) *IMessageRepository_CreateMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Message))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_CreateMany_Call) Return(_a0 error) *IMessageRepository_CreateMany_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMessageRepository_CreateMany_Call) RunAndReturn(run func(context.Context, []domain.Message) error) *IMessageRepository_CreateMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteById provides a mock function with given fields: Ctx, id
+func (_m *IMessageRepository) DeleteById(Ctx context.Context, id string) (*mongo.DeleteResult, error) {
+	ret := _m.Called(Ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteById")
+	}
+
+	var r0 *mongo.DeleteResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*mongo.DeleteResult, error)); ok {
+		return rf(Ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *mongo.DeleteResult); ok {
+		r0 = rf(Ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*mongo.DeleteResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(Ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_DeleteById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteById'
+type IMessageRepository_DeleteById_Call struct {
+	*mock.Call
+}
+
+// DeleteById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id string
+func (_e *IMessageRepository_Expecter) DeleteById(Ctx interface{}, id interface{}) *IMessageRepository_DeleteById_Call {
+	return &IMessageRepository_DeleteById_Call{Call: _e.mock.On("DeleteById", Ctx, id)}
+}
+
+func (_c *IMessageRepository_DeleteById_Call) Run(run func(Ctx context.Context, id string)) *IMessageRepository_DeleteById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_DeleteById_Call) Return(_a0 *mongo.DeleteResult, _a1 error) *IMessageRepository_DeleteById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_DeleteById_Call) RunAndReturn(run func(context.Context, string) (*mongo.DeleteResult, error)) *IMessageRepository_DeleteById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Edit provides a mock function with given fields: Ctx, id, content, mentions, editedAt
+func (_m *IMessageRepository) Edit(Ctx context.Context, id primitive.ObjectID, content string, mentions []domain.MessageMention, editedAt time.Time) error {
+	ret := _m.Called(Ctx, id, content, mentions, editedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Edit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string, []domain.MessageMention, time.Time) error); ok {
+		r0 = rf(Ctx, id, content, mentions, editedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMessageRepository_Edit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Edit'
+type IMessageRepository_Edit_Call struct {
+	*mock.Call
+}
+
+// Edit is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id primitive.ObjectID
+//   - content string
+//   - mentions []domain.MessageMention
+//   - editedAt time.Time
+func (_e *IMessageRepository_Expecter) Edit(Ctx interface{}, id interface{}, content interface{}, mentions interface{}, editedAt interface{}) *IMessageRepository_Edit_Call {
+	return &IMessageRepository_Edit_Call{Call: _e.mock.On("Edit", Ctx, id, content, mentions, editedAt)}
+}
+
+func (_c *IMessageRepository_Edit_Call) Run(run func(Ctx context.Context, id primitive.ObjectID, content string, mentions []domain.MessageMention, editedAt time.Time)) *IMessageRepository_Edit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string), args[3].([]domain.MessageMention), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_Edit_Call) Return(_a0 error) *IMessageRepository_Edit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMessageRepository_Edit_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string, []domain.MessageMention, time.Time) error) *IMessageRepository_Edit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: Ctx, filter, offset, limit, sortOption
+func (_m *IMessageRepository) GetAll(Ctx context.Context, filter interface{}, offset int64, limit int64, sortOption ...primitive.D) ([]domain.Message, error) {
+	_va := make([]interface{}, len(sortOption))
+	for _i := range sortOption {
+		_va[_i] = sortOption[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, Ctx, filter, offset, limit)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, int64, int64, ...primitive.D) ([]domain.Message, error)); ok {
+		return rf(Ctx, filter, offset, limit, sortOption...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, int64, int64, ...primitive.D) []domain.Message); ok {
+		r0 = rf(Ctx, filter, offset, limit, sortOption...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, int64, int64, ...primitive.D) error); ok {
+		r1 = rf(Ctx, filter, offset, limit, sortOption...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type IMessageRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filter interface{}
+//   - offset int64
+//   - limit int64
+//   - sortOption ...primitive.D
+func (_e *IMessageRepository_Expecter) GetAll(Ctx interface{}, filter interface{}, offset interface{}, limit interface{}, sortOption ...interface{}) *IMessageRepository_GetAll_Call {
+	return &IMessageRepository_GetAll_Call{Call: _e.mock.On("GetAll",
+		append([]interface{}{Ctx, filter, offset, limit}, sortOption...)...)}
+}
+
+func (_c *IMessageRepository_GetAll_Call) Run(run func(Ctx context.Context, filter interface{}, offset int64, limit int64, sortOption ...primitive.D)) *IMessageRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]primitive.D, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(primitive.D)
+			}
+		}
+		run(args[0].(context.Context), args[1].(interface{}), args[2].(int64), args[3].(int64), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_GetAll_Call) Return(_a0 []domain.Message, _a1 error) *IMessageRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_GetAll_Call) RunAndReturn(run func(context.Context, interface{}, int64, int64, ...primitive.D) ([]domain.Message, error)) *IMessageRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatMessage provides a mock function with given fields: Ctx, chatId, id
+func (_m *IMessageRepository) GetChatMessage(Ctx context.Context, chatId int64, id string) (domain.Message, error) {
+	ret := _m.Called(Ctx, chatId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatMessage")
+	}
+
+	var r0 domain.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (domain.Message, error)); ok {
+		return rf(Ctx, chatId, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) domain.Message); ok {
+		r0 = rf(Ctx, chatId, id)
+	} else {
+		r0 = ret.Get(0).(domain.Message)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(Ctx, chatId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_GetChatMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatMessage'
+type IMessageRepository_GetChatMessage_Call struct {
+	*mock.Call
+}
+
+// GetChatMessage is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - id string
+func (_e *IMessageRepository_Expecter) GetChatMessage(Ctx interface{}, chatId interface{}, id interface{}) *IMessageRepository_GetChatMessage_Call {
+	return &IMessageRepository_GetChatMessage_Call{Call: _e.mock.On("GetChatMessage", Ctx, chatId, id)}
+}
+
+func (_c *IMessageRepository_GetChatMessage_Call) Run(run func(Ctx context.Context, chatId int64, id string)) *IMessageRepository_GetChatMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_GetChatMessage_Call) Return(_a0 domain.Message, _a1 error) *IMessageRepository_GetChatMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_GetChatMessage_Call) RunAndReturn(run func(context.Context, int64, string) (domain.Message, error)) *IMessageRepository_GetChatMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNewer provides a mock function with given fields: Ctx, chatId, threadId, createdAt, id, limit, inclusive
+func (_m *IMessageRepository) GetNewer(Ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, limit int64, inclusive bool) ([]domain.Message, error) {
+	ret := _m.Called(Ctx, chatId, threadId, createdAt, id, limit, inclusive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewer")
+	}
+
+	var r0 []domain.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, primitive.ObjectID, time.Time, primitive.ObjectID, int64, bool) ([]domain.Message, error)); ok {
+		return rf(Ctx, chatId, threadId, createdAt, id, limit, inclusive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, primitive.ObjectID, time.Time, primitive.ObjectID, int64, bool) []domain.Message); ok {
+		r0 = rf(Ctx, chatId, threadId, createdAt, id, limit, inclusive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, primitive.ObjectID, time.Time, primitive.ObjectID, int64, bool) error); ok {
+		r1 = rf(Ctx, chatId, threadId, createdAt, id, limit, inclusive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_GetNewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNewer'
+type IMessageRepository_GetNewer_Call struct {
+	*mock.Call
+}
+
+// GetNewer is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - threadId primitive.ObjectID
+//   - createdAt time.Time
+//   - id primitive.ObjectID
+//   - limit int64
+//   - inclusive bool
+func (_e *IMessageRepository_Expecter) GetNewer(Ctx interface{}, chatId interface{}, threadId interface{}, createdAt interface{}, id interface{}, limit interface{}, inclusive interface{}) *IMessageRepository_GetNewer_Call {
+	return &IMessageRepository_GetNewer_Call{Call: _e.mock.On("GetNewer", Ctx, chatId, threadId, createdAt, id, limit, inclusive)}
+}
+
+func (_c *IMessageRepository_GetNewer_Call) Run(run func(Ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, limit int64, inclusive bool)) *IMessageRepository_GetNewer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(primitive.ObjectID), args[3].(time.Time), args[4].(primitive.ObjectID), args[5].(int64), args[6].(bool))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_GetNewer_Call) Return(_a0 []domain.Message, _a1 error) *IMessageRepository_GetNewer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_GetNewer_Call) RunAndReturn(run func(context.Context, int64, primitive.ObjectID, time.Time, primitive.ObjectID, int64, bool) ([]domain.Message, error)) *IMessageRepository_GetNewer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOlder provides a mock function with given fields: Ctx, chatId, threadId, createdAt, id, limit
+func (_m *IMessageRepository) GetOlder(Ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, limit int64) ([]domain.Message, error) {
+	ret := _m.Called(Ctx, chatId, threadId, createdAt, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOlder")
+	}
+
+	var r0 []domain.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, primitive.ObjectID, time.Time, primitive.ObjectID, int64) ([]domain.Message, error)); ok {
+		return rf(Ctx, chatId, threadId, createdAt, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, primitive.ObjectID, time.Time, primitive.ObjectID, int64) []domain.Message); ok {
+		r0 = rf(Ctx, chatId, threadId, createdAt, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, primitive.ObjectID, time.Time, primitive.ObjectID, int64) error); ok {
+		r1 = rf(Ctx, chatId, threadId, createdAt, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_GetOlder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOlder'
+type IMessageRepository_GetOlder_Call struct {
+	*mock.Call
+}
+
+// GetOlder is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - chatId int64
+//   - threadId primitive.ObjectID
+//   - createdAt time.Time
+//   - id primitive.ObjectID
+//   - limit int64
+func (_e *IMessageRepository_Expecter) GetOlder(Ctx interface{}, chatId interface{}, threadId interface{}, createdAt interface{}, id interface{}, limit interface{}) *IMessageRepository_GetOlder_Call {
+	return &IMessageRepository_GetOlder_Call{Call: _e.mock.On("GetOlder", Ctx, chatId, threadId, createdAt, id, limit)}
+}
+
+func (_c *IMessageRepository_GetOlder_Call) Run(run func(Ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, limit int64)) *IMessageRepository_GetOlder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(primitive.ObjectID), args[3].(time.Time), args[4].(primitive.ObjectID), args[5].(int64))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_GetOlder_Call) Return(_a0 []domain.Message, _a1 error) *IMessageRepository_GetOlder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_GetOlder_Call) RunAndReturn(run func(context.Context, int64, primitive.ObjectID, time.Time, primitive.ObjectID, int64) ([]domain.Message, error)) *IMessageRepository_GetOlder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOne provides a mock function with given fields: Ctx, filters
+func (_m *IMessageRepository) GetOne(Ctx context.Context, filters interface{}) (domain.Message, error) {
+	ret := _m.Called(Ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOne")
+	}
+
+	var r0 domain.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) (domain.Message, error)); ok {
+		return rf(Ctx, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) domain.Message); ok {
+		r0 = rf(Ctx, filters)
+	} else {
+		r0 = ret.Get(0).(domain.Message)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(Ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_GetOne_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOne'
+type IMessageRepository_GetOne_Call struct {
+	*mock.Call
+}
+
+// GetOne is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - filters interface{}
+func (_e *IMessageRepository_Expecter) GetOne(Ctx interface{}, filters interface{}) *IMessageRepository_GetOne_Call {
+	return &IMessageRepository_GetOne_Call{Call: _e.mock.On("GetOne", Ctx, filters)}
+}
+
+func (_c *IMessageRepository_GetOne_Call) Run(run func(Ctx context.Context, filters interface{})) *IMessageRepository_GetOne_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_GetOne_Call) Return(_a0 domain.Message, _a1 error) *IMessageRepository_GetOne_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_GetOne_Call) RunAndReturn(run func(context.Context, interface{}) (domain.Message, error)) *IMessageRepository_GetOne_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveReaction provides a mock function with given fields: Ctx, id, emoji, userId
+func (_m *IMessageRepository) RemoveReaction(Ctx context.Context, id primitive.ObjectID, emoji string, userId int64) error {
+	ret := _m.Called(Ctx, id, emoji, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string, int64) error); ok {
+		r0 = rf(Ctx, id, emoji, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMessageRepository_RemoveReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReaction'
+type IMessageRepository_RemoveReaction_Call struct {
+	*mock.Call
+}
+
+// RemoveReaction is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id primitive.ObjectID
+//   - emoji string
+//   - userId int64
+func (_e *IMessageRepository_Expecter) RemoveReaction(Ctx interface{}, id interface{}, emoji interface{}, userId interface{}) *IMessageRepository_RemoveReaction_Call {
+	return &IMessageRepository_RemoveReaction_Call{Call: _e.mock.On("RemoveReaction", Ctx, id, emoji, userId)}
+}
+
+func (_c *IMessageRepository_RemoveReaction_Call) Run(run func(Ctx context.Context, id primitive.ObjectID, emoji string, userId int64)) *IMessageRepository_RemoveReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_RemoveReaction_Call) Return(_a0 error) *IMessageRepository_RemoveReaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMessageRepository_RemoveReaction_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string, int64) error) *IMessageRepository_RemoveReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveReply provides a mock function with given fields: Ctx, threadId
+func (_m *IMessageRepository) RemoveReply(Ctx context.Context, threadId primitive.ObjectID) error {
+	ret := _m.Called(Ctx, threadId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReply")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(Ctx, threadId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMessageRepository_RemoveReply_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReply'
+type IMessageRepository_RemoveReply_Call struct {
+	*mock.Call
+}
+
+// RemoveReply is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - threadId primitive.ObjectID
+func (_e *IMessageRepository_Expecter) RemoveReply(Ctx interface{}, threadId interface{}) *IMessageRepository_RemoveReply_Call {
+	return &IMessageRepository_RemoveReply_Call{Call: _e.mock.On("RemoveReply", Ctx, threadId)}
+}
+
+func (_c *IMessageRepository_RemoveReply_Call) Run(run func(Ctx context.Context, threadId primitive.ObjectID)) *IMessageRepository_RemoveReply_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_RemoveReply_Call) Return(_a0 error) *IMessageRepository_RemoveReply_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMessageRepository_RemoveReply_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *IMessageRepository_RemoveReply_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: Ctx, search, createdAt, id, limit
+func (_m *IMessageRepository) Search(Ctx context.Context, search repositories.MessageSearch, createdAt time.Time, id primitive.ObjectID, limit int64) ([]domain.Message, error) {
+	ret := _m.Called(Ctx, search, createdAt, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.MessageSearch, time.Time, primitive.ObjectID, int64) ([]domain.Message, error)); ok {
+		return rf(Ctx, search, createdAt, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.MessageSearch, time.Time, primitive.ObjectID, int64) []domain.Message); ok {
+		r0 = rf(Ctx, search, createdAt, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.MessageSearch, time.Time, primitive.ObjectID, int64) error); ok {
+		r1 = rf(Ctx, search, createdAt, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type IMessageRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - search repositories.MessageSearch
+//   - createdAt time.Time
+//   - id primitive.ObjectID
+//   - limit int64
+func (_e *IMessageRepository_Expecter) Search(Ctx interface{}, search interface{}, createdAt interface{}, id interface{}, limit interface{}) *IMessageRepository_Search_Call {
+	return &IMessageRepository_Search_Call{Call: _e.mock.On("Search", Ctx, search, createdAt, id, limit)}
+}
+
+func (_c *IMessageRepository_Search_Call) Run(run func(Ctx context.Context, search repositories.MessageSearch, createdAt time.Time, id primitive.ObjectID, limit int64)) *IMessageRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.MessageSearch), args[2].(time.Time), args[3].(primitive.ObjectID), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_Search_Call) Return(_a0 []domain.Message, _a1 error) *IMessageRepository_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_Search_Call) RunAndReturn(run func(context.Context, repositories.MessageSearch, time.Time, primitive.ObjectID, int64) ([]domain.Message, error)) *IMessageRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SoftDelete provides a mock function with given fields: Ctx, id, deletedAt
+func (_m *IMessageRepository) SoftDelete(Ctx context.Context, id primitive.ObjectID, deletedAt time.Time) error {
+	ret := _m.Called(Ctx, id, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SoftDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time) error); ok {
+		r0 = rf(Ctx, id, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMessageRepository_SoftDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SoftDelete'
+type IMessageRepository_SoftDelete_Call struct {
+	*mock.Call
+}
+
+// SoftDelete is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id primitive.ObjectID
+//   - deletedAt time.Time
+func (_e *IMessageRepository_Expecter) SoftDelete(Ctx interface{}, id interface{}, deletedAt interface{}) *IMessageRepository_SoftDelete_Call {
+	return &IMessageRepository_SoftDelete_Call{Call: _e.mock.On("SoftDelete", Ctx, id, deletedAt)}
+}
+
+func (_c *IMessageRepository_SoftDelete_Call) Run(run func(Ctx context.Context, id primitive.ObjectID, deletedAt time.Time)) *IMessageRepository_SoftDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_SoftDelete_Call) Return(_a0 error) *IMessageRepository_SoftDelete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMessageRepository_SoftDelete_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, time.Time) error) *IMessageRepository_SoftDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateById provides a mock function with given fields: Ctx, id, updateFields
+func (_m *IMessageRepository) UpdateById(Ctx context.Context, id string, updateFields primitive.M) (*mongo.UpdateResult, error) {
+	ret := _m.Called(Ctx, id, updateFields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateById")
+	}
+
+	var r0 *mongo.UpdateResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.M) (*mongo.UpdateResult, error)); ok {
+		return rf(Ctx, id, updateFields)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.M) *mongo.UpdateResult); ok {
+		r0 = rf(Ctx, id, updateFields)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*mongo.UpdateResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, primitive.M) error); ok {
+		r1 = rf(Ctx, id, updateFields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMessageRepository_UpdateById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateById'
+type IMessageRepository_UpdateById_Call struct {
+	*mock.Call
+}
+
+// UpdateById is a helper method to define mock.On call
+//   - Ctx context.Context
+//   - id string
+//   - updateFields primitive.M
+func (_e *IMessageRepository_Expecter) UpdateById(Ctx interface{}, id interface{}, updateFields interface{}) *IMessageRepository_UpdateById_Call {
+	return &IMessageRepository_UpdateById_Call{Call: _e.mock.On("UpdateById", Ctx, id, updateFields)}
+}
+
+func (_c *IMessageRepository_UpdateById_Call) Run(run func(Ctx context.Context, id string, updateFields primitive.M)) *IMessageRepository_UpdateById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(primitive.M))
+	})
+	return _c
+}
+
+func (_c *IMessageRepository_UpdateById_Call) Return(_a0 *mongo.UpdateResult, _a1 error) *IMessageRepository_UpdateById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMessageRepository_UpdateById_Call) RunAndReturn(run func(context.Context, string, primitive.M) (*mongo.UpdateResult, error)) *IMessageRepository_UpdateById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIMessageRepository creates a new instance of IMessageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIMessageRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IMessageRepository {
+	mock := &IMessageRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
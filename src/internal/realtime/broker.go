@@ -0,0 +1,13 @@
+package realtime
+
+import (
+	"context"
+	"libs/src/internal/dto"
+)
+
+// IBroker fans chat events out to every running instance, each of which
+// delivers them to the websocket clients connected to its own Hub.
+type IBroker interface {
+	Publish(ctx context.Context, event dto.EventDTO) error
+	Run(ctx context.Context)
+}
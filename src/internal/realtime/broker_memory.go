@@ -0,0 +1,27 @@
+package realtime
+
+import (
+	"context"
+	"libs/src/internal/dto"
+)
+
+// MemoryBroker hands events straight to the local Hub, it is meant for tests
+// and single-node setups.
+type MemoryBroker struct {
+	Hub *Hub
+}
+
+func NewMemoryBroker(hub *Hub) *MemoryBroker {
+	return &MemoryBroker{
+		Hub: hub,
+	}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, event dto.EventDTO) error {
+	b.Hub.Publish(event)
+	return nil
+}
+
+func (b *MemoryBroker) Run(ctx context.Context) {
+	<-ctx.Done()
+}
@@ -0,0 +1,67 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"libs/src/internal/dto"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBroker publishes every event to the "<prefix><chat_id>" channel and
+// listens to all of them, so each instance receives the events of every chat
+// and delivers them to its local subscribers.
+type RedisBroker struct {
+	Client  *redis.Client
+	Prefix  string
+	Timeout time.Duration
+	Hub     *Hub
+}
+
+func NewRedisBroker(client *redis.Client, prefix string, timeout time.Duration, hub *Hub) *RedisBroker {
+	return &RedisBroker{
+		Client:  client,
+		Prefix:  prefix,
+		Timeout: timeout,
+		Hub:     hub,
+	}
+}
+
+func (b *RedisBroker) Publish(Ctx context.Context, event dto.EventDTO) error {
+	ctx, cancel := context.WithTimeout(Ctx, b.Timeout)
+	defer cancel()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return b.Client.Publish(ctx, b.Prefix+strconv.FormatInt(event.ChatId, 10), data).Err()
+}
+
+func (b *RedisBroker) Run(ctx context.Context) {
+	pubsub := b.Client.PSubscribe(ctx, b.Prefix+"*")
+	defer pubsub.Close()
+
+	channel := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-channel:
+			if !ok {
+				return
+			}
+
+			var event dto.EventDTO
+			if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+				b.Hub.Logger.Error(fmt.Sprintf("Error decoding event from %s: %v", message.Channel, err))
+				continue
+			}
+			b.Hub.Dispatch(event, []byte(message.Payload))
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package realtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Client is a single websocket connection of an authenticated user.
+type Client struct {
+	UserId int64
+
+	hub    *Hub
+	conn   *websocket.Conn
+	send   chan []byte
+	chats  map[int64]struct{}
+	closed bool
+}
+
+func NewClient(hub *Hub, conn *websocket.Conn, userId int64) *Client {
+	return &Client{
+		UserId: userId,
+		hub:    hub,
+		conn:   conn,
+		send:   make(chan []byte, hub.Options.SendBuffer),
+		chats:  make(map[int64]struct{}),
+	}
+}
+
+func (c *Client) Run() {
+	go c.writePump()
+	go c.readPump()
+}
+
+// readPump only serves control frames: the connection is push-only, so any
+// data frame from the client is read and discarded.
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.Unregister(c)
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(c.hub.Options.MaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(c.hub.Options.PongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(c.hub.Options.PongWait))
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				c.hub.Logger.Error(fmt.Sprintf("Websocket of user %d closed: %v", c.UserId, err))
+			}
+			return
+		}
+	}
+}
+
+func (c *Client) writePump() {
+	ticker := time.NewTicker(c.hub.Options.PongWait * 9 / 10)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(c.hub.Options.WriteWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(c.hub.Options.WriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
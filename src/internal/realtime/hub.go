@@ -0,0 +1,187 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Options struct {
+	WriteWait      time.Duration
+	PongWait       time.Duration
+	MaxMessageSize int64
+	SendBuffer     int
+}
+
+// Hub keeps track of the websocket clients connected to this process and
+// of the chats each of them is subscribed to.
+type Hub struct {
+	Logger  *zap.Logger
+	Options Options
+
+	mu    sync.RWMutex
+	chats map[int64]map[*Client]struct{}
+	users map[int64]map[*Client]struct{}
+}
+
+func NewHub(logger *zap.Logger, options Options) *Hub {
+	return &Hub{
+		Logger:  logger,
+		Options: options,
+		chats:   make(map[int64]map[*Client]struct{}),
+		users:   make(map[int64]map[*Client]struct{}),
+	}
+}
+
+func (h *Hub) Register(client *Client, chatIds []int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.users[client.UserId] == nil {
+		h.users[client.UserId] = make(map[*Client]struct{})
+	}
+	h.users[client.UserId][client] = struct{}{}
+
+	for _, chatId := range chatIds {
+		h.subscribe(client, chatId)
+	}
+}
+
+func (h *Hub) Unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if client.closed {
+		return
+	}
+
+	for chatId := range client.chats {
+		h.unsubscribe(client, chatId)
+	}
+
+	delete(h.users[client.UserId], client)
+	if len(h.users[client.UserId]) == 0 {
+		delete(h.users, client.UserId)
+	}
+
+	client.closed = true
+	close(client.send)
+}
+
+// Subscribe attaches every connection of the user to the chat.
+func (h *Hub) Subscribe(userId, chatId int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.users[userId] {
+		h.subscribe(client, chatId)
+	}
+}
+
+// Unsubscribe detaches every connection of the user from the chat.
+func (h *Hub) Unsubscribe(userId, chatId int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.users[userId] {
+		h.unsubscribe(client, chatId)
+	}
+}
+
+func (h *Hub) Publish(event dto.EventDTO) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		h.Logger.Error(fmt.Sprintf("Error encoding event %s: %v", event.Type, err))
+		return
+	}
+	h.Dispatch(event, data)
+}
+
+// Dispatch delivers the already encoded event to the local subscribers of its
+// chat and keeps the subscriptions in sync with membership events.
+func (h *Hub) Dispatch(event dto.EventDTO, data []byte) {
+	switch event.Type {
+	case enums.EventTypesToLabels[enums.MEMBER_JOINED]:
+		h.Subscribe(event.UserId, event.ChatId)
+		h.deliver(event.ChatId, data)
+	case enums.EventTypesToLabels[enums.MEMBER_LEFT]:
+		h.deliver(event.ChatId, data)
+		h.Unsubscribe(event.UserId, event.ChatId)
+	case enums.EventTypesToLabels[enums.CHAT_DELETED]:
+		h.deliver(event.ChatId, data)
+		h.dropChat(event.ChatId)
+	case enums.EventTypesToLabels[enums.MENTIONED]:
+		h.deliverToUser(event.UserId, data)
+	default:
+		h.deliver(event.ChatId, data)
+	}
+}
+
+func (h *Hub) deliver(chatId int64, data []byte) {
+	h.mu.RLock()
+	slow := h.send(h.chats[chatId], data)
+	h.mu.RUnlock()
+
+	h.dropSlow(slow)
+}
+
+// deliverToUser sends the event to every connection of the user
+func (h *Hub) deliverToUser(userId int64, data []byte) {
+	h.mu.RLock()
+	slow := h.send(h.users[userId], data)
+	h.mu.RUnlock()
+
+	h.dropSlow(slow)
+}
+
+// send must be called with the lock held, it returns the clients whose
+// buffer is full
+func (h *Hub) send(clients map[*Client]struct{}, data []byte) []*Client {
+	var slow []*Client
+	for client := range clients {
+		select {
+		case client.send <- data:
+		default:
+			slow = append(slow, client)
+		}
+	}
+	return slow
+}
+
+func (h *Hub) dropSlow(slow []*Client) {
+	for _, client := range slow {
+		h.Logger.Warn(fmt.Sprintf("Dropping slow websocket client of user %d", client.UserId))
+		h.Unregister(client)
+	}
+}
+
+func (h *Hub) dropChat(chatId int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.chats[chatId] {
+		delete(client.chats, chatId)
+	}
+	delete(h.chats, chatId)
+}
+
+func (h *Hub) subscribe(client *Client, chatId int64) {
+	if h.chats[chatId] == nil {
+		h.chats[chatId] = make(map[*Client]struct{})
+	}
+	h.chats[chatId][client] = struct{}{}
+	client.chats[chatId] = struct{}{}
+}
+
+func (h *Hub) unsubscribe(client *Client, chatId int64) {
+	delete(h.chats[chatId], client)
+	if len(h.chats[chatId]) == 0 {
+		delete(h.chats, chatId)
+	}
+	delete(client.chats, chatId)
+}
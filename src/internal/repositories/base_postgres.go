@@ -12,7 +12,7 @@ import (
 	"gorm.io/gorm"
 )
 
-type IBasePostgresRepository[T models.User | models.Chat | models.ChatMember] interface {
+type IBasePostgresRepository[T models.User | models.Chat | models.ChatMember | models.ChatInvite | models.ChatInvitation | models.JoinRequest | models.ChatRestriction | models.ChatRolePermission | models.ChatRole | models.ChatAuditEntry | models.ChatPin | models.Mention] interface {
 	Create(Ctx context.Context, obj *T) error
 	GetById(Ctx context.Context, id int64) (T, error)
 	GetAll(Ctx context.Context) ([]T, error)
@@ -24,7 +24,7 @@ type IBasePostgresRepository[T models.User | models.Chat | models.ChatMember] in
 	ManyToCreate(Ctx context.Context, objects []T) error
 }
 
-type BasePostgresRepository[T models.User | models.Chat | models.ChatMember] struct {
+type BasePostgresRepository[T models.User | models.Chat | models.ChatMember | models.ChatInvite | models.ChatInvitation | models.JoinRequest | models.ChatRestriction | models.ChatRolePermission | models.ChatRole | models.ChatAuditEntry | models.ChatPin | models.Mention] struct {
 	Model T
 	Db    *gorm.DB
 }
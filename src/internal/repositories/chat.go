@@ -4,8 +4,13 @@ import (
 	"context"
 	"libs/src/internal/domain/enums"
 	domain "libs/src/internal/domain/models"
+	"libs/src/internal/dto"
 	"libs/src/settings"
+	"maps"
+	"slices"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 //go:generate mockery --name=IChatRepository --dir=. --output=../mocks --with-expecter
@@ -13,6 +18,12 @@ type IChatRepository interface {
 	IBasePostgresRepository[domain.Chat]
 	GetListForUser(Ctx context.Context, userId int64, limit int, offset int) ([]domain.Chat, error)
 	SearchForUser(Ctx context.Context, userId int64, name string, limit, offset int) ([]domain.Chat, error)
+	CreateDirect(Ctx context.Context, chat *domain.Chat, peerId int64) error
+	DiscoverPublic(Ctx context.Context, search string, limit, offset int) ([]dto.ChatPreview, error)
+	GetPreview(Ctx context.Context, chatId int64) (dto.ChatPreview, error)
+	TransferOwnership(Ctx context.Context, chatId, ownerId, newOwnerId int64, audit *domain.ChatAuditEntry) error
+	UpdateChat(Ctx context.Context, chatId int64, updateFields map[string]interface{}, audit *domain.ChatAuditEntry) error
+	DeleteChat(Ctx context.Context, chatId int64, audit *domain.ChatAuditEntry) error
 }
 
 func NewChatRepository(app *settings.App) *ChatRepository {
@@ -53,6 +64,132 @@ func (r *ChatRepository) Create(Ctx context.Context, chat *domain.Chat) error {
 	return nil
 }
 
+// CreateDirect creates a direct chat together with its two members, neither of
+// them gets any admin role.
+func (r *ChatRepository) CreateDirect(Ctx context.Context, chat *domain.Chat, peerId int64) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Large)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	if err := tx.Create(chat).Error; err != nil {
+		tx.Rollback()
+		return parsePgError(err)
+	}
+
+	members := []domain.ChatMember{
+		{ChatID: chat.ID, UserID: chat.OwnerID, MemberRole: enums.MEMBER},
+		{ChatID: chat.ID, UserID: peerId, MemberRole: enums.MEMBER},
+	}
+
+	if err := tx.Create(&members).Error; err != nil {
+		tx.Rollback()
+		return parsePgError(err)
+	}
+	return nil
+}
+
+// TransferOwnership demotes the owner to admin, promotes the new owner and
+// moves Chat.OwnerID in one transaction. Returns ErrRecordNotFound when the
+// owner does not own the chat or the new owner is not a member of it.
+func (r *ChatRepository) TransferOwnership(Ctx context.Context, chatId, ownerId, newOwnerId int64, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Large)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.Model(&domain.Chat{}).
+		Where("id = ? AND owner_id = ?", chatId, ownerId).
+		Update("owner_id", newOwnerId)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrRecordNotFound
+	}
+
+	res = tx.Model(&domain.ChatMember{}).
+		Where("chat_id = ? AND user_id = ?", chatId, ownerId).
+		Updates(map[string]interface{}{"member_role": enums.CHAT_ADMIN, "role_id": nil})
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+
+	res = tx.Model(&domain.ChatMember{}).
+		Where("chat_id = ? AND user_id = ?", chatId, newOwnerId).
+		Updates(map[string]interface{}{"member_role": enums.OWNER, "role_id": nil})
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrRecordNotFound
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
+
+// UpdateChat changes the given fields of the chat. The audit entry, if any, is
+// recorded with the change.
+func (r *ChatRepository) UpdateChat(Ctx context.Context, chatId int64, updateFields map[string]interface{}, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Large)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.Model(&domain.Chat{}).
+		Where("id = ?", chatId).Select(slices.Collect(maps.Keys(updateFields))).
+		Updates(updateFields)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrRecordNotFound
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
+
+// DeleteChat deletes the chat with its members. The audit entry, if any, is
+// recorded with the change and is kept after the chat is gone.
+func (r *ChatRepository) DeleteChat(Ctx context.Context, chatId int64, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.Where("id = ?", chatId).Delete(&domain.Chat{})
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrRecordNotFound
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
+
 func (r *ChatRepository) GetListForUser(Ctx context.Context, userId int64, limit int, offset int) ([]domain.Chat, error) {
 	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
 	defer cancel()
@@ -81,7 +218,12 @@ func (r *ChatRepository) SearchForUser(Ctx context.Context, userId int64, name s
 	err := r.Db.WithContext(ctx).Table("chats").
 		Select("chats.*").
 		Joins("JOIN chat_members ON chat_members.chat_id = chats.id").
-		Where("chat_members.user_id = ? AND chats.title LIKE ?", userId, "%"+name+"%").
+		Where("chat_members.user_id = ?", userId).
+		Where(`chats.title LIKE ? OR (chats.type = ? AND EXISTS (
+			SELECT 1 FROM chat_members peers
+			JOIN users ON users.id = peers.user_id
+			WHERE peers.chat_id = chats.id AND peers.user_id <> ? AND users.username LIKE ?
+		))`, "%"+name+"%", enums.DIRECT, userId, "%"+name+"%").
 		Limit(limit).
 		Offset(offset).
 		Find(&chats).Error
@@ -92,3 +234,50 @@ func (r *ChatRepository) SearchForUser(Ctx context.Context, userId int64, name s
 
 	return chats, nil
 }
+
+// DiscoverPublic lists the public chats whose title or description contains
+// the search, the most populated first.
+func (r *ChatRepository) DiscoverPublic(Ctx context.Context, search string, limit, offset int) ([]dto.ChatPreview, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	query := r.previewQuery(ctx).
+		Where("chats.type = ? AND chats.visibility = ?", enums.GROUP, enums.PUBLIC)
+	if search != "" {
+		query = query.Where("chats.title ILIKE ? OR chats.description ILIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+
+	var chats []dto.ChatPreview
+	err := query.
+		Order("members_count DESC, chats.id").
+		Limit(limit).
+		Offset(offset).
+		Scan(&chats).Error
+	if err != nil {
+		return nil, parsePgError(err)
+	}
+	return chats, nil
+}
+
+// GetPreview returns the public card of a chat, shown before joining it
+func (r *ChatRepository) GetPreview(Ctx context.Context, chatId int64) (dto.ChatPreview, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	var chat dto.ChatPreview
+	res := r.previewQuery(ctx).Where("chats.id = ?", chatId).Scan(&chat)
+	if res.Error != nil {
+		return dto.ChatPreview{}, parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return dto.ChatPreview{}, ErrRecordNotFound
+	}
+	return chat, nil
+}
+
+func (r *ChatRepository) previewQuery(ctx context.Context) *gorm.DB {
+	return r.Db.WithContext(ctx).Table("chats").
+		Select(`chats.id, chats.title, chats.description, users.username AS owner,
+			(SELECT COUNT(*) FROM chat_members WHERE chat_members.chat_id = chats.id) AS members_count`).
+		Joins("JOIN users ON users.id = chats.owner_id")
+}
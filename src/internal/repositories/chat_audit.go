@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=IChatAuditRepository --dir=. --output=../mocks --with-expecter
+type IChatAuditRepository interface {
+	IBasePostgresRepository[domain.ChatAuditEntry]
+	GetForChat(Ctx context.Context, chatId int64, limit, offset int) ([]domain.ChatAuditEntry, error)
+}
+
+func NewChatAuditRepository(app *settings.App) *ChatAuditRepository {
+	return &ChatAuditRepository{
+		BasePostgresRepository: BasePostgresRepository[domain.ChatAuditEntry]{
+			Model: domain.ChatAuditEntry{},
+			Db:    app.DB,
+		},
+	}
+}
+
+type ChatAuditRepository struct {
+	BasePostgresRepository[domain.ChatAuditEntry]
+}
+
+// GetForChat returns the audit log of the chat, newest first
+func (r *ChatAuditRepository) GetForChat(Ctx context.Context, chatId int64, limit, offset int) ([]domain.ChatAuditEntry, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	var entries []domain.ChatAuditEntry
+	res := r.Db.WithContext(ctx).
+		Preload("Actor").Preload("Target").
+		Where("chat_id = ?", chatId).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return entries, nil
+}
+
+// recordAudit appends the entry within the transaction of the change it
+// records, so the change and its entry are committed or rolled back together
+func recordAudit(tx *gorm.DB, entry *domain.ChatAuditEntry) error {
+	if entry == nil {
+		return nil
+	}
+	if err := tx.Create(entry).Error; err != nil {
+		return parsePgError(err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"libs/src/internal/domain/enums"
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"time"
+)
+
+//go:generate mockery --name=IChatInvitationRepository --dir=. --output=../mocks --with-expecter
+type IChatInvitationRepository interface {
+	IBasePostgresRepository[domain.ChatInvitation]
+	GetPending(Ctx context.Context, invitationId, inviteeId int64) (domain.ChatInvitation, error)
+	GetInbox(Ctx context.Context, inviteeId int64, limit, offset int) ([]domain.ChatInvitation, error)
+	GetSent(Ctx context.Context, inviterId int64, limit, offset int) ([]domain.ChatInvitation, error)
+	Respond(Ctx context.Context, invitationId, inviteeId int64, status byte) error
+}
+
+func NewChatInvitationRepository(app *settings.App) *ChatInvitationRepository {
+	return &ChatInvitationRepository{
+		BasePostgresRepository: BasePostgresRepository[domain.ChatInvitation]{
+			Model: domain.ChatInvitation{},
+			Db:    app.DB,
+		},
+	}
+}
+
+type ChatInvitationRepository struct {
+	BasePostgresRepository[domain.ChatInvitation]
+}
+
+func (r *ChatInvitationRepository) GetPending(Ctx context.Context, invitationId, inviteeId int64) (domain.ChatInvitation, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	var invitation domain.ChatInvitation
+	res := r.Db.WithContext(ctx).
+		Preload("Chat").Preload("Inviter").
+		Where("id = ? AND invitee_id = ? AND status = ?", invitationId, inviteeId, enums.INVITATION_PENDING).
+		Limit(1).
+		Find(&invitation)
+	if res.Error != nil {
+		return invitation, parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return invitation, ErrRecordNotFound
+	}
+	return invitation, nil
+}
+
+// GetInbox returns the pending invitations of the user, newest first
+func (r *ChatInvitationRepository) GetInbox(Ctx context.Context, inviteeId int64, limit, offset int) ([]domain.ChatInvitation, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	var invitations []domain.ChatInvitation
+	res := r.Db.WithContext(ctx).
+		Preload("Chat").Preload("Inviter").Preload("Invitee").
+		Where("invitee_id = ? AND status = ?", inviteeId, enums.INVITATION_PENDING).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&invitations)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return invitations, nil
+}
+
+// GetSent returns the invitations made by the user in any status, newest first
+func (r *ChatInvitationRepository) GetSent(Ctx context.Context, inviterId int64, limit, offset int) ([]domain.ChatInvitation, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	var invitations []domain.ChatInvitation
+	res := r.Db.WithContext(ctx).
+		Preload("Chat").Preload("Inviter").Preload("Invitee").
+		Where("inviter_id = ?", inviterId).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&invitations)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return invitations, nil
+}
+
+// Respond moves a pending invitation of the invitee to the status. Returns
+// ErrRecordNotFound when there is no such pending invitation, so an
+// invitation is answered only once.
+func (r *ChatInvitationRepository) Respond(Ctx context.Context, invitationId, inviteeId int64, status byte) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	res := r.Db.WithContext(ctx).Model(&r.Model).
+		Where("id = ? AND invitee_id = ? AND status = ?", invitationId, inviteeId, enums.INVITATION_PENDING).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_at": time.Now(),
+		})
+	if res.Error != nil {
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=IChatInviteRepository --dir=. --output=../mocks --with-expecter
+type IChatInviteRepository interface {
+	IBasePostgresRepository[domain.ChatInvite]
+	GetByToken(Ctx context.Context, token string) (domain.ChatInvite, error)
+	GetListForChat(Ctx context.Context, chatId int64) ([]domain.ChatInvite, error)
+	Revoke(Ctx context.Context, chatId, inviteId int64) error
+	Consume(Ctx context.Context, inviteId int64) error
+	Release(Ctx context.Context, inviteId int64) error
+}
+
+func NewChatInviteRepository(app *settings.App) *ChatInviteRepository {
+	return &ChatInviteRepository{
+		BasePostgresRepository: BasePostgresRepository[domain.ChatInvite]{
+			Model: domain.ChatInvite{},
+			Db:    app.DB,
+		},
+	}
+}
+
+type ChatInviteRepository struct {
+	BasePostgresRepository[domain.ChatInvite]
+}
+
+func (r *ChatInviteRepository) GetByToken(Ctx context.Context, token string) (domain.ChatInvite, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	var invite domain.ChatInvite
+	res := r.Db.WithContext(ctx).Where("token = ?", token).Limit(1).Find(&invite)
+	if res.Error != nil {
+		return invite, parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return invite, ErrRecordNotFound
+	}
+	return invite, nil
+}
+
+func (r *ChatInviteRepository) GetListForChat(Ctx context.Context, chatId int64) ([]domain.ChatInvite, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	var invites []domain.ChatInvite
+	res := r.Db.WithContext(ctx).
+		Preload("Creator").
+		Where("chat_id = ?", chatId).
+		Order("created_at DESC").
+		Find(&invites)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return invites, nil
+}
+
+func (r *ChatInviteRepository) Revoke(Ctx context.Context, chatId, inviteId int64) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	res := r.Db.WithContext(ctx).Model(&r.Model).
+		Where("id = ? AND chat_id = ? AND revoked_at IS NULL", inviteId, chatId).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// Consume takes one use of the invite. The check and the increment are a
+// single statement, so concurrent redeems can not go over the limit. Returns
+// ErrRecordNotFound when the invite is no longer active.
+func (r *ChatInviteRepository) Consume(Ctx context.Context, inviteId int64) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	res := r.Db.WithContext(ctx).Model(&r.Model).
+		Where("id = ? AND revoked_at IS NULL", inviteId).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Where("max_uses = 0 OR uses < max_uses").
+		Update("uses", gorm.Expr("uses + 1"))
+	if res.Error != nil {
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// Release gives back a use taken by Consume when joining the chat failed
+func (r *ChatInviteRepository) Release(Ctx context.Context, inviteId int64) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	res := r.Db.WithContext(ctx).Model(&r.Model).
+		Where("id = ? AND uses > 0", inviteId).
+		Update("uses", gorm.Expr("uses - 1"))
+	if res.Error != nil {
+		return parsePgError(res.Error)
+	}
+	return nil
+}
@@ -14,10 +14,13 @@ import (
 //go:generate mockery --name=IChatMemberRepository --dir=. --output=../mocks --with-expecter
 type IChatMemberRepository interface {
 	IBasePostgresRepository[domain.ChatMember]
-	SetNewRole(Ctx context.Context, chatId, userId int64, role byte) error
+	SetNewRole(Ctx context.Context, chatId, userId int64, role byte, roleId *int64, audit *domain.ChatAuditEntry) error
 	GetMemberInfo(Ctx context.Context, memberId, chatId int64) (dto.MemberInfo, error)
-	DeleteMember(Ctx context.Context, memberId, chatId int64) error
+	DeleteMember(Ctx context.Context, memberId, chatId int64, audit *domain.ChatAuditEntry) error
 	GetMembersPreview(Ctx context.Context, chatId int64, limit, offset int, searchUsername string) ([]dto.MemberPreview, error)
+	GetDirectPeers(Ctx context.Context, userId int64, chatIds []int64) (map[int64]dto.ChatPeerDTO, error)
+	AdvanceReadCursor(Ctx context.Context, chatId, userId int64, messageId string, createdAt time.Time) (bool, error)
+	GetReaders(Ctx context.Context, chatId, senderId int64, messageId string, createdAt time.Time, limit, offset int) ([]dto.MessageReaderDTO, error)
 }
 
 func NewChatMemberRepository(app *settings.App) *ChatMemberRepository {
@@ -33,20 +36,34 @@ type ChatMemberRepository struct {
 	BasePostgresRepository[domain.ChatMember]
 }
 
-func (r *ChatMemberRepository) SetNewRole(Ctx context.Context, chatId, userId int64, role byte) error {
+// SetNewRole gives the member a built-in role, or a custom role of the chat
+// when roleId is set. The audit entry, if any, is recorded with the change.
+func (r *ChatMemberRepository) SetNewRole(Ctx context.Context, chatId, userId int64, role byte, roleId *int64, audit *domain.ChatAuditEntry) error {
 	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
 	defer cancel()
 
-	res := r.Db.WithContext(ctx).Model(&r.Model).
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.Model(&r.Model).
 		Where("chat_id = ? AND user_id = ?", chatId, userId).
-		Update("member_role", role)
+		Updates(map[string]interface{}{
+			"member_role": role,
+			"role_id":     roleId,
+		})
 
 	if res.Error != nil {
+		tx.Rollback()
 		return parsePgError(res.Error)
 	}
 	if res.RowsAffected == 0 {
+		tx.Rollback()
 		return ErrRecordNotFound
 	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
 	return nil
 }
 
@@ -58,12 +75,19 @@ func (r *ChatMemberRepository) GetMemberInfo(Ctx context.Context, memberId, chat
 	res := r.Db.WithContext(ctx).Raw(`
 				SELECT chats.id AS chat_id,
 					   chats.title AS chat_title,
+					   chats.type AS chat_type,
 					   user_id AS member_id,
 					   member_role,
+					   chat_roles.name AS role_name,
+					   chat_roles.rank,
+					   COALESCE(chat_roles.permissions, chat_role_permissions.permissions) AS permissions,
 					   chat_members.created_at AS date_joined,
 					   chat_members.updated_at
 				FROM chats
 				JOIN chat_members ON chats.id = chat_members.chat_id
+				LEFT JOIN chat_roles ON chat_roles.id = chat_members.role_id
+				LEFT JOIN chat_role_permissions ON chat_role_permissions.chat_id = chats.id
+					AND chat_role_permissions.role = chat_members.member_role
 				WHERE chats.id = ? AND chat_members.user_id = ?;
 			`, chatId, memberId).Scan(&memberInfo)
 
@@ -76,17 +100,28 @@ func (r *ChatMemberRepository) GetMemberInfo(Ctx context.Context, memberId, chat
 	return memberInfo, nil
 }
 
-func (r *ChatMemberRepository) DeleteMember(Ctx context.Context, memberId, chatId int64) error {
+// DeleteMember removes the member from the chat. The audit entry, if any, is
+// recorded with the change.
+func (r *ChatMemberRepository) DeleteMember(Ctx context.Context, memberId, chatId int64, audit *domain.ChatAuditEntry) error {
 	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
 	defer cancel()
 
-	res := r.Db.WithContext(ctx).Where("user_id = ? AND chat_id = ?", memberId, chatId).Delete(&r.Model)
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.Where("user_id = ? AND chat_id = ?", memberId, chatId).Delete(&r.Model)
 	if res.Error != nil {
+		tx.Rollback()
 		return parsePgError(res.Error)
 	}
 	if res.RowsAffected == 0 {
+		tx.Rollback()
 		return ErrRecordNotFound
 	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
 	return nil
 }
 
@@ -108,11 +143,12 @@ func (r *ChatMemberRepository) GetMembersPreview(Ctx context.Context, chatId int
 		users.username AS username,
 		users.image AS avatar,
 		chat_members.created_at AS joined_at,
-		CASE
+		COALESCE(chat_roles.name, CASE
 			%s
-		END AS role
+		END) AS role
 		`, r.buildCaseByRole(enums.ChatRolesToLabels))).
 		Joins("JOIN users ON chat_members.user_id = users.id").
+		Joins("LEFT JOIN chat_roles ON chat_roles.id = chat_members.role_id").
 		Where("chat_members.chat_id = ?", chatId)
 
 	if searchUsername != "" {
@@ -150,3 +186,74 @@ func (r *ChatMemberRepository) GetMembersPreview(Ctx context.Context, chatId int
 
 	return result, nil
 }
+
+// AdvanceReadCursor moves the read cursor of the member to the message, unless
+// the member has already read past it. Reports whether the cursor was moved.
+func (r *ChatMemberRepository) AdvanceReadCursor(Ctx context.Context, chatId, userId int64, messageId string, createdAt time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	res := r.Db.WithContext(ctx).Model(&r.Model).
+		Where("chat_id = ? AND user_id = ?", chatId, userId).
+		Where("last_read_at < ? OR (last_read_at = ? AND last_read_message_id < ?)", createdAt, createdAt, messageId).
+		Updates(map[string]interface{}{
+			"last_read_message_id": messageId,
+			"last_read_at":         createdAt,
+		})
+
+	if res.Error != nil {
+		return false, parsePgError(res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// GetReaders returns the members, except the sender, whose read cursor is at
+// the message or past it. Object ids are fixed length hex, so comparing them
+// as strings keeps their order.
+func (r *ChatMemberRepository) GetReaders(Ctx context.Context, chatId, senderId int64, messageId string, createdAt time.Time, limit, offset int) ([]dto.MessageReaderDTO, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	readers := []dto.MessageReaderDTO{}
+	res := r.Db.WithContext(ctx).Table("chat_members").
+		Select("users.username AS username, users.image AS avatar, chat_members.last_read_at AS read_up_to").
+		Joins("JOIN users ON chat_members.user_id = users.id").
+		Where("chat_members.chat_id = ? AND chat_members.user_id <> ? AND chat_members.last_read_message_id <> ''", chatId, senderId).
+		Where("chat_members.last_read_at > ? OR (chat_members.last_read_at = ? AND chat_members.last_read_message_id >= ?)", createdAt, createdAt, messageId).
+		Order("chat_members.last_read_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&readers)
+
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return readers, nil
+}
+
+// GetDirectPeers returns the other participant of each of the given direct
+// chats of the user, keyed by chat id.
+func (r *ChatMemberRepository) GetDirectPeers(Ctx context.Context, userId int64, chatIds []int64) (map[int64]dto.ChatPeerDTO, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	peers := []struct {
+		ChatId int64 `gorm:"column:chat_id"`
+		dto.ChatPeerDTO
+	}{}
+	res := r.Db.WithContext(ctx).Table("chat_members").
+		Select("chat_members.chat_id AS chat_id, users.username AS username, users.image AS avatar").
+		Joins("JOIN users ON chat_members.user_id = users.id").
+		Where("chat_members.chat_id IN ? AND chat_members.user_id <> ?", chatIds, userId).
+		Scan(&peers)
+
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+
+	result := make(map[int64]dto.ChatPeerDTO, len(peers))
+	for _, peer := range peers {
+		result[peer.ChatId] = peer.ChatPeerDTO
+	}
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+var ErrTooManyPins = errors.New("too many pinned messages")
+
+//go:generate mockery --name=IChatPinRepository --dir=. --output=../mocks --with-expecter
+type IChatPinRepository interface {
+	IBasePostgresRepository[domain.ChatPin]
+	GetForChat(Ctx context.Context, chatId int64) ([]domain.ChatPin, error)
+	Pin(Ctx context.Context, pin *domain.ChatPin, maxPinned int) error
+	Unpin(Ctx context.Context, chatId int64, messageId string) error
+}
+
+func NewChatPinRepository(app *settings.App) *ChatPinRepository {
+	return &ChatPinRepository{
+		BasePostgresRepository: BasePostgresRepository[domain.ChatPin]{
+			Model: domain.ChatPin{},
+			Db:    app.DB,
+		},
+	}
+}
+
+type ChatPinRepository struct {
+	BasePostgresRepository[domain.ChatPin]
+}
+
+// GetForChat returns the pins of the chat, the latest pinned first
+func (r *ChatPinRepository) GetForChat(Ctx context.Context, chatId int64) ([]domain.ChatPin, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	var pins []domain.ChatPin
+	res := r.Db.WithContext(ctx).
+		Preload("PinnedBy").
+		Where("chat_id = ?", chatId).
+		Order("created_at DESC, id DESC").
+		Find(&pins)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return pins, nil
+}
+
+// Pin adds the pin unless the chat already has maxPinned of them, in which
+// case ErrTooManyPins is returned. The chat row is locked so concurrent pins
+// can't go over the limit together.
+func (r *ChatPinRepository) Pin(Ctx context.Context, pin *domain.ChatPin, maxPinned int) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Large)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	var chat domain.Chat
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", pin.ChatID).
+		Find(&chat)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrRecordNotFound
+	}
+
+	var count int64
+	res = tx.Model(&domain.ChatPin{}).Where("chat_id = ?", pin.ChatID).Count(&count)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if count >= int64(maxPinned) {
+		tx.Rollback()
+		return ErrTooManyPins
+	}
+
+	if err := tx.Create(pin).Error; err != nil {
+		tx.Rollback()
+		return parsePgError(err)
+	}
+	return nil
+}
+
+// Unpin removes the pin of the message. Returns ErrRecordNotFound when the
+// message is not pinned.
+func (r *ChatPinRepository) Unpin(Ctx context.Context, chatId int64, messageId string) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	res := r.Db.WithContext(ctx).
+		Where("chat_id = ? AND message_id = ?", chatId, messageId).
+		Delete(&r.Model)
+	if res.Error != nil {
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
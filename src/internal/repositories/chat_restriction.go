@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=IChatRestrictionRepository --dir=. --output=../mocks --with-expecter
+type IChatRestrictionRepository interface {
+	IBasePostgresRepository[domain.ChatRestriction]
+	Upsert(Ctx context.Context, restriction *domain.ChatRestriction, audit *domain.ChatAuditEntry) error
+	GetActive(Ctx context.Context, chatId, userId int64, restrictionType byte) (domain.ChatRestriction, error)
+	GetListForChat(Ctx context.Context, chatId int64, restrictionType byte, limit, offset int) ([]domain.ChatRestriction, error)
+	Lift(Ctx context.Context, chatId, userId int64, restrictionType byte, audit *domain.ChatAuditEntry) error
+}
+
+func NewChatRestrictionRepository(app *settings.App) *ChatRestrictionRepository {
+	return &ChatRestrictionRepository{
+		BasePostgresRepository: BasePostgresRepository[domain.ChatRestriction]{
+			Model: domain.ChatRestriction{},
+			Db:    app.DB,
+		},
+	}
+}
+
+type ChatRestrictionRepository struct {
+	BasePostgresRepository[domain.ChatRestriction]
+}
+
+// Upsert creates the restriction or replaces the reason, expiry and author of
+// the existing one of the same type. The audit entry, if any, is recorded with
+// the change.
+func (r *ChatRestrictionRepository) Upsert(Ctx context.Context, restriction *domain.ChatRestriction, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "chat_id"}, {Name: "user_id"}, {Name: "type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"reason", "expires_at", "created_by_id", "updated_at"}),
+		}).
+		Create(restriction)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
+
+// GetActive returns the restriction of the type unless it has expired
+func (r *ChatRestrictionRepository) GetActive(Ctx context.Context, chatId, userId int64, restrictionType byte) (domain.ChatRestriction, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	var restriction domain.ChatRestriction
+	res := r.Db.WithContext(ctx).
+		Where("chat_id = ? AND user_id = ? AND type = ?", chatId, userId, restrictionType).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Limit(1).
+		Find(&restriction)
+	if res.Error != nil {
+		return restriction, parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return restriction, ErrRecordNotFound
+	}
+	return restriction, nil
+}
+
+// GetListForChat returns the restrictions of the type in force, the ones
+// ending soonest first
+func (r *ChatRestrictionRepository) GetListForChat(Ctx context.Context, chatId int64, restrictionType byte, limit, offset int) ([]domain.ChatRestriction, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	var restrictions []domain.ChatRestriction
+	res := r.Db.WithContext(ctx).
+		Preload("User").Preload("CreatedBy").
+		Where("chat_id = ? AND type = ?", chatId, restrictionType).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Order("expires_at ASC NULLS LAST, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&restrictions)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return restrictions, nil
+}
+
+// Lift removes the restriction of the type. Returns ErrRecordNotFound when
+// there is none in force. The audit entry, if any, is recorded with the change.
+func (r *ChatRestrictionRepository) Lift(Ctx context.Context, chatId, userId int64, restrictionType byte, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.
+		Where("chat_id = ? AND user_id = ? AND type = ?", chatId, userId, restrictionType).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Delete(&r.Model)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrRecordNotFound
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
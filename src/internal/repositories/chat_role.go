@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"maps"
+	"slices"
+	"time"
+)
+
+//go:generate mockery --name=IChatRoleRepository --dir=. --output=../mocks --with-expecter
+type IChatRoleRepository interface {
+	IBasePostgresRepository[domain.ChatRole]
+	GetForChat(Ctx context.Context, chatId int64) ([]domain.ChatRole, error)
+	GetChatRole(Ctx context.Context, chatId, roleId int64) (domain.ChatRole, error)
+	GetByName(Ctx context.Context, chatId int64, name string) (domain.ChatRole, error)
+	CreateRole(Ctx context.Context, role *domain.ChatRole, audit *domain.ChatAuditEntry) error
+	UpdateRole(Ctx context.Context, roleId int64, updateFields map[string]interface{}, audit *domain.ChatAuditEntry) error
+	DeleteRole(Ctx context.Context, roleId int64, audit *domain.ChatAuditEntry) error
+}
+
+func NewChatRoleRepository(app *settings.App) *ChatRoleRepository {
+	return &ChatRoleRepository{
+		BasePostgresRepository: BasePostgresRepository[domain.ChatRole]{
+			Model: domain.ChatRole{},
+			Db:    app.DB,
+		},
+	}
+}
+
+type ChatRoleRepository struct {
+	BasePostgresRepository[domain.ChatRole]
+}
+
+// GetForChat returns the custom roles of the chat, highest rank first
+func (r *ChatRoleRepository) GetForChat(Ctx context.Context, chatId int64) ([]domain.ChatRole, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	var roles []domain.ChatRole
+	res := r.Db.WithContext(ctx).
+		Where("chat_id = ?", chatId).
+		Order("rank DESC, name").
+		Find(&roles)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return roles, nil
+}
+
+// GetChatRole returns the role if it belongs to the chat
+func (r *ChatRoleRepository) GetChatRole(Ctx context.Context, chatId, roleId int64) (domain.ChatRole, error) {
+	return r.getOne(Ctx, "chat_id = ? AND id = ?", chatId, roleId)
+}
+
+func (r *ChatRoleRepository) GetByName(Ctx context.Context, chatId int64, name string) (domain.ChatRole, error) {
+	return r.getOne(Ctx, "chat_id = ? AND name = ?", chatId, name)
+}
+
+func (r *ChatRoleRepository) getOne(Ctx context.Context, query string, args ...interface{}) (domain.ChatRole, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	var role domain.ChatRole
+	res := r.Db.WithContext(ctx).Where(query, args...).Limit(1).Find(&role)
+	if res.Error != nil {
+		return role, parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return role, ErrRecordNotFound
+	}
+	return role, nil
+}
+
+// CreateRole adds the custom role to its chat. The audit entry, if any, is
+// recorded with the change.
+func (r *ChatRoleRepository) CreateRole(Ctx context.Context, role *domain.ChatRole, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	if err := tx.Create(role).Error; err != nil {
+		tx.Rollback()
+		return parsePgError(err)
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
+
+// UpdateRole changes the given fields of the role. The audit entry, if any,
+// is recorded with the change.
+func (r *ChatRoleRepository) UpdateRole(Ctx context.Context, roleId int64, updateFields map[string]interface{}, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.Model(&r.Model).
+		Where("id = ?", roleId).Select(slices.Collect(maps.Keys(updateFields))).
+		Updates(updateFields)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrRecordNotFound
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
+
+// DeleteRole removes the role, its members fall back to their built-in role.
+// The audit entry, if any, is recorded with the change.
+func (r *ChatRoleRepository) DeleteRole(Ctx context.Context, roleId int64, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.Where("id = ?", roleId).Delete(&r.Model)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrRecordNotFound
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=IChatRolePermissionRepository --dir=. --output=../mocks --with-expecter
+type IChatRolePermissionRepository interface {
+	IBasePostgresRepository[domain.ChatRolePermission]
+	GetForChat(Ctx context.Context, chatId int64) ([]domain.ChatRolePermission, error)
+	Upsert(Ctx context.Context, permission *domain.ChatRolePermission, audit *domain.ChatAuditEntry) error
+	Reset(Ctx context.Context, chatId int64, role byte, audit *domain.ChatAuditEntry) error
+}
+
+func NewChatRolePermissionRepository(app *settings.App) *ChatRolePermissionRepository {
+	return &ChatRolePermissionRepository{
+		BasePostgresRepository: BasePostgresRepository[domain.ChatRolePermission]{
+			Model: domain.ChatRolePermission{},
+			Db:    app.DB,
+		},
+	}
+}
+
+type ChatRolePermissionRepository struct {
+	BasePostgresRepository[domain.ChatRolePermission]
+}
+
+// GetForChat returns the roles whose permissions the chat has customised
+func (r *ChatRolePermissionRepository) GetForChat(Ctx context.Context, chatId int64) ([]domain.ChatRolePermission, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	var permissions []domain.ChatRolePermission
+	res := r.Db.WithContext(ctx).
+		Where("chat_id = ?", chatId).
+		Order("role").
+		Find(&permissions)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return permissions, nil
+}
+
+// Upsert sets the permission set of the role in the chat. The audit entry, if
+// any, is recorded with the change.
+func (r *ChatRolePermissionRepository) Upsert(Ctx context.Context, permission *domain.ChatRolePermission, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "chat_id"}, {Name: "role"}},
+			DoUpdates: clause.AssignmentColumns([]string{"permissions", "updated_at"}),
+		}).
+		Create(permission)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
+
+// Reset brings the role back to its default permissions. Returns
+// ErrRecordNotFound when the role was not customised. The audit entry, if
+// any, is recorded with the change.
+func (r *ChatRolePermissionRepository) Reset(Ctx context.Context, chatId int64, role byte, audit *domain.ChatAuditEntry) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	tx := r.Db.WithContext(ctx).Begin()
+	defer tx.Commit()
+
+	res := tx.
+		Where("chat_id = ? AND role = ?", chatId, role).
+		Delete(&r.Model)
+	if res.Error != nil {
+		tx.Rollback()
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrRecordNotFound
+	}
+	if err := recordAudit(tx, audit); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
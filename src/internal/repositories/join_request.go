@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"libs/src/internal/domain/enums"
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"time"
+)
+
+//go:generate mockery --name=IJoinRequestRepository --dir=. --output=../mocks --with-expecter
+type IJoinRequestRepository interface {
+	IBasePostgresRepository[domain.JoinRequest]
+	GetPending(Ctx context.Context, requestId, chatId int64) (domain.JoinRequest, error)
+	GetQueue(Ctx context.Context, chatId int64, limit, offset int) ([]domain.JoinRequest, error)
+	GetForUser(Ctx context.Context, userId int64, limit, offset int) ([]domain.JoinRequest, error)
+	Review(Ctx context.Context, requestId, chatId, reviewerId int64, status byte, reason string) error
+}
+
+func NewJoinRequestRepository(app *settings.App) *JoinRequestRepository {
+	return &JoinRequestRepository{
+		BasePostgresRepository: BasePostgresRepository[domain.JoinRequest]{
+			Model: domain.JoinRequest{},
+			Db:    app.DB,
+		},
+	}
+}
+
+type JoinRequestRepository struct {
+	BasePostgresRepository[domain.JoinRequest]
+}
+
+func (r *JoinRequestRepository) GetPending(Ctx context.Context, requestId, chatId int64) (domain.JoinRequest, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	var request domain.JoinRequest
+	res := r.Db.WithContext(ctx).
+		Preload("Chat").Preload("User").
+		Where("id = ? AND chat_id = ? AND status = ?", requestId, chatId, enums.JOIN_REQUEST_PENDING).
+		Limit(1).
+		Find(&request)
+	if res.Error != nil {
+		return request, parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return request, ErrRecordNotFound
+	}
+	return request, nil
+}
+
+// GetQueue returns the pending requests to join the chat, oldest first
+func (r *JoinRequestRepository) GetQueue(Ctx context.Context, chatId int64, limit, offset int) ([]domain.JoinRequest, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	var requests []domain.JoinRequest
+	res := r.Db.WithContext(ctx).
+		Preload("Chat").Preload("User").
+		Where("chat_id = ? AND status = ?", chatId, enums.JOIN_REQUEST_PENDING).
+		Order("created_at").
+		Limit(limit).
+		Offset(offset).
+		Find(&requests)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return requests, nil
+}
+
+// GetForUser returns the requests made by the user in any status, newest first
+func (r *JoinRequestRepository) GetForUser(Ctx context.Context, userId int64, limit, offset int) ([]domain.JoinRequest, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	var requests []domain.JoinRequest
+	res := r.Db.WithContext(ctx).
+		Preload("Chat").Preload("User").
+		Where("user_id = ?", userId).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&requests)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return requests, nil
+}
+
+// Review approves or rejects a pending request. Returns ErrRecordNotFound
+// when the request is not pending, so a request is reviewed only once.
+func (r *JoinRequestRepository) Review(Ctx context.Context, requestId, chatId, reviewerId int64, status byte, reason string) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	res := r.Db.WithContext(ctx).Model(&r.Model).
+		Where("id = ? AND chat_id = ? AND status = ?", requestId, chatId, enums.JOIN_REQUEST_PENDING).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"reason":      reason,
+			"reviewer_id": reviewerId,
+			"reviewed_at": time.Now(),
+		})
+	if res.Error != nil {
+		return parsePgError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
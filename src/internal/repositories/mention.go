@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=IMentionRepository --dir=. --output=../mocks --with-expecter
+type IMentionRepository interface {
+	IBasePostgresRepository[domain.Mention]
+	AddMany(Ctx context.Context, mentions []domain.Mention) error
+	GetForUser(Ctx context.Context, userId int64, unreadOnly bool, limit, offset int) ([]domain.Mention, error)
+	CountUnread(Ctx context.Context, userId int64) (int64, error)
+	MarkRead(Ctx context.Context, userId int64, mentionIds []int64) error
+	RemoveForMessage(Ctx context.Context, messageId string) error
+}
+
+func NewMentionRepository(app *settings.App) *MentionRepository {
+	return &MentionRepository{
+		BasePostgresRepository: BasePostgresRepository[domain.Mention]{
+			Model: domain.Mention{},
+			Db:    app.DB,
+		},
+	}
+}
+
+type MentionRepository struct {
+	BasePostgresRepository[domain.Mention]
+}
+
+// AddMany adds the mentions to the inboxes, a user mentioned again by the
+// same message keeps the entry they have
+func (r *MentionRepository) AddMany(Ctx context.Context, mentions []domain.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Large)*time.Millisecond)
+	defer cancel()
+
+	res := r.Db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(mentions, 500)
+	if res.Error != nil {
+		return parsePgError(res.Error)
+	}
+	return nil
+}
+
+// inbox selects the mentions of the user in the chats they are still a member of
+func (r *MentionRepository) inbox(db *gorm.DB, userId int64) *gorm.DB {
+	return db.
+		Joins("JOIN chat_members ON chat_members.chat_id = mentions.chat_id AND chat_members.user_id = mentions.user_id").
+		Where("mentions.user_id = ?", userId)
+}
+
+// GetForUser returns the inbox of the user, newest first
+func (r *MentionRepository) GetForUser(Ctx context.Context, userId int64, unreadOnly bool, limit, offset int) ([]domain.Mention, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	query := r.inbox(r.Db.WithContext(ctx), userId)
+	if unreadOnly {
+		query = query.Where("mentions.is_read = false")
+	}
+
+	var mentions []domain.Mention
+	res := query.
+		Preload("Chat").Preload("Sender").
+		Order("mentions.created_at DESC, mentions.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&mentions)
+	if res.Error != nil {
+		return nil, parsePgError(res.Error)
+	}
+	return mentions, nil
+}
+
+func (r *MentionRepository) CountUnread(Ctx context.Context, userId int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Small)*time.Millisecond)
+	defer cancel()
+
+	var count int64
+	res := r.inbox(r.Db.WithContext(ctx).Model(&domain.Mention{}), userId).
+		Where("mentions.is_read = false").
+		Count(&count)
+	if res.Error != nil {
+		return 0, parsePgError(res.Error)
+	}
+	return count, nil
+}
+
+// MarkRead marks the mentions of the user as read, all of them when no ids
+// are given
+func (r *MentionRepository) MarkRead(Ctx context.Context, userId int64, mentionIds []int64) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	query := r.Db.WithContext(ctx).Model(&domain.Mention{}).Where("user_id = ? AND is_read = false", userId)
+	if len(mentionIds) > 0 {
+		query = query.Where("id IN ?", mentionIds)
+	}
+	if err := query.Update("is_read", true).Error; err != nil {
+		return parsePgError(err)
+	}
+	return nil
+}
+
+// RemoveForMessage takes the message out of every inbox
+func (r *MentionRepository) RemoveForMessage(Ctx context.Context, messageId string) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Postgres.Medium)*time.Millisecond)
+	defer cancel()
+
+	if err := r.Db.WithContext(ctx).Where("message_id = ?", messageId).Delete(&domain.Mention{}).Error; err != nil {
+		return parsePgError(err)
+	}
+	return nil
+}
@@ -1,16 +1,47 @@
 package repositories
 
 import (
+	"context"
+	"errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"libs/src/internal/domain/enums"
 	domain "libs/src/internal/domain/models"
 	"libs/src/settings"
+	"slices"
+	"strconv"
+	"time"
 )
 
+var ErrTooManyReactions = errors.New("too many distinct reactions")
+
+// MessageSearch selects the messages of a full-text search, zero fields don't
+// filter
+type MessageSearch struct {
+	Query    string
+	ChatIds  []int64
+	SenderId int64
+	From     time.Time
+	To       time.Time
+}
+
+//go:generate mockery --name=IMessageRepository --dir=. --output=../mocks --with-expecter
 type IMessageRepository interface {
 	IBaseMongoRepository[domain.Message]
 	CreateIndex() error
+	GetOlder(Ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, limit int64) ([]domain.Message, error)
+	GetNewer(Ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, limit int64, inclusive bool) ([]domain.Message, error)
+	AddReply(Ctx context.Context, threadId primitive.ObjectID, repliedAt time.Time, senderId int64) error
+	RemoveReply(Ctx context.Context, threadId primitive.ObjectID) error
+	GetChatMessage(Ctx context.Context, chatId int64, id string) (domain.Message, error)
+	Edit(Ctx context.Context, id primitive.ObjectID, content string, mentions []domain.MessageMention, editedAt time.Time) error
+	SoftDelete(Ctx context.Context, id primitive.ObjectID, deletedAt time.Time) error
+	CountUnread(Ctx context.Context, userId int64, members []domain.ChatMember) (map[int64]int64, error)
+	AddReaction(Ctx context.Context, id primitive.ObjectID, emoji string, userId int64, maxDistinct int) error
+	RemoveReaction(Ctx context.Context, id primitive.ObjectID, emoji string, userId int64) error
+	Search(Ctx context.Context, search MessageSearch, createdAt time.Time, id primitive.ObjectID, limit int64) ([]domain.Message, error)
 }
 
 type MessageRepository struct {
@@ -35,6 +66,364 @@ func (r *MessageRepository) CreateIndex() error {
 		},
 		Options: options.Index().SetName("chat_id_created_at_index"),
 	}
-	_, err := r.Db.Collection(r.CollectionName).Indexes().CreateOne(settings.AppVar.Ctx, compoundIndex)
+	threadIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "reply_to", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+		Options: options.Index().
+			SetName("reply_to_created_at_index").
+			SetPartialFilterExpression(bson.M{"reply_to": bson.M{"$exists": true}}),
+	}
+	// Messages are written in any language, so the words are matched as they
+	// are, without stemming or stop words
+	textIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "content", Value: "text"},
+		},
+		Options: options.Index().
+			SetName("content_text_index").
+			SetDefaultLanguage("none"),
+	}
+	_, err := r.Db.Collection(r.CollectionName).Indexes().CreateMany(settings.AppVar.Ctx, []mongo.IndexModel{compoundIndex, threadIndex, textIndex})
+	return err
+}
+
+// streamFilter selects the messages of a thread, or the top level messages of
+// the chat when threadId is nil.
+func streamFilter(chatId int64, threadId primitive.ObjectID) bson.M {
+	if threadId.IsZero() {
+		return bson.M{"chat_id": chatId, "reply_to": nil}
+	}
+	return bson.M{"chat_id": chatId, "reply_to": threadId}
+}
+
+// GetOlder returns up to limit messages of the stream that go strictly before
+// the (createdAt, id) position in chronological order. A zero createdAt starts
+// from the newest message.
+func (r *MessageRepository) GetOlder(Ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, limit int64) ([]domain.Message, error) {
+	filter := streamFilter(chatId, threadId)
+	if !createdAt.IsZero() {
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": createdAt}},
+			bson.M{"created_at": createdAt, "_id": bson.M{"$lt": id}},
+		}
+	}
+
+	messages, err := r.GetAll(Ctx, filter, 0, limit, bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(messages)
+	return messages, nil
+}
+
+// GetNewer returns up to limit messages of the stream that go after the
+// (createdAt, id) position in chronological order, including the message at
+// this position when inclusive is set.
+func (r *MessageRepository) GetNewer(Ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, limit int64, inclusive bool) ([]domain.Message, error) {
+	idOperator := "$gt"
+	if inclusive {
+		idOperator = "$gte"
+	}
+
+	filter := streamFilter(chatId, threadId)
+	filter["$or"] = bson.A{
+		bson.M{"created_at": bson.M{"$gt": createdAt}},
+		bson.M{"created_at": createdAt, "_id": bson.M{idOperator: id}},
+	}
+
+	return r.GetAll(Ctx, filter, 0, limit, bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+}
+
+func (r *MessageRepository) GetChatMessage(Ctx context.Context, chatId int64, id string) (domain.Message, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Message{}, ErrRecordNotFound
+	}
+
+	message, err := r.GetOne(Ctx, bson.M{"_id": objID, "chat_id": chatId})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.Message{}, ErrRecordNotFound
+	}
+	return message, err
+}
+
+// AddReply updates the reply counter and the last reply info of the thread root.
+// Replies may be saved out of order, so the last reply info only moves forward
+// and its time and sender always belong to the same reply.
+func (r *MessageRepository) AddReply(Ctx context.Context, threadId primitive.ObjectID, repliedAt time.Time, senderId int64) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Mongo.Large)*time.Millisecond)
+	defer cancel()
+
+	isLatest := bson.M{"$gte": bson.A{repliedAt, bson.M{"$ifNull": bson.A{"$last_reply_at", time.Time{}}}}}
+	_, err := r.Db.Collection(r.CollectionName).UpdateByID(ctx, threadId, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"reply_count":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$reply_count", 0}}, 1}},
+			"last_reply_at": bson.M{"$cond": bson.A{isLatest, repliedAt, "$last_reply_at"}},
+			"last_reply_by": bson.M{"$cond": bson.A{isLatest, senderId, "$last_reply_by"}},
+		}}},
+	})
+	return err
+}
+
+// RemoveReply recounts the replies of the thread root after one of them has been
+// deleted, the last reply info is taken from the latest reply left.
+func (r *MessageRepository) RemoveReply(Ctx context.Context, threadId primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Mongo.Large)*time.Millisecond)
+	defer cancel()
+
+	con := r.Db.Collection(r.CollectionName)
+	filter := bson.M{"reply_to": threadId, "is_deleted": false}
+
+	count, err := con.CountDocuments(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		_, err = con.UpdateByID(ctx, threadId, bson.M{
+			"$set":   bson.M{"reply_count": 0},
+			"$unset": bson.M{"last_reply_at": "", "last_reply_by": ""},
+		})
+		return err
+	}
+
+	var last domain.Message
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if err = con.FindOne(ctx, filter, opts).Decode(&last); err != nil {
+		return err
+	}
+
+	_, err = con.UpdateByID(ctx, threadId, bson.M{"$set": bson.M{
+		"reply_count":   count,
+		"last_reply_at": last.CreatedAt,
+		"last_reply_by": last.SenderId,
+	}})
+	return err
+}
+
+// pushRevision appends the current content of the document to its revisions,
+// inside an update pipeline it is read before the content gets replaced.
+func pushRevision(editedAt time.Time) bson.M {
+	return bson.M{"$concatArrays": bson.A{
+		bson.M{"$ifNull": bson.A{"$revisions", bson.A{}}},
+		bson.A{bson.M{"content": "$content", "edited_at": editedAt}},
+	}}
+}
+
+// updateAlive applies the pipeline to the message unless it has been deleted,
+// in which case ErrRecordNotFound is returned.
+func (r *MessageRepository) updateAlive(Ctx context.Context, id primitive.ObjectID, pipeline mongo.Pipeline) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Mongo.Large)*time.Millisecond)
+	defer cancel()
+
+	res, err := r.Db.Collection(r.CollectionName).UpdateOne(ctx, bson.M{"_id": id, "is_deleted": false}, pipeline)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// Edit replaces the content of the message and its mentions and keeps the
+// previous content in its revisions. The values are wrapped in $literal,
+// otherwise the pipeline would treat text starting with "$" as a field path.
+func (r *MessageRepository) Edit(Ctx context.Context, id primitive.ObjectID, content string, mentions []domain.MessageMention, editedAt time.Time) error {
+	return r.updateAlive(Ctx, id, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"revisions":  pushRevision(editedAt),
+			"content":    bson.M{"$literal": content},
+			"mentions":   bson.M{"$literal": mentions},
+			"is_updated": true,
+			"updated_at": editedAt,
+		}}},
+	})
+}
+
+// SoftDelete turns the message into a tombstone, its last content goes to the
+// revisions so moderators can still see it.
+func (r *MessageRepository) SoftDelete(Ctx context.Context, id primitive.ObjectID, deletedAt time.Time) error {
+	return r.updateAlive(Ctx, id, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"revisions":  pushRevision(deletedAt),
+			"content":    "",
+			"is_deleted": true,
+			"delete_at":  deletedAt,
+		}}},
+	})
+}
+
+// CountUnread counts, per chat, the top level messages of other users that go
+// after the read cursor of the member. Chats without unread messages are absent from the result.
+func (r *MessageRepository) CountUnread(Ctx context.Context, userId int64, members []domain.ChatMember) (map[int64]int64, error) {
+	result := make(map[int64]int64, len(members))
+	if len(members) == 0 {
+		return result, nil
+	}
+
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Mongo.Medium)*time.Millisecond)
+	defer cancel()
+
+	positions := make(bson.A, 0, len(members))
+	for _, member := range members {
+		// Nothing read yet, the cursor is the moment of joining. Postgres keeps
+		// microseconds and mongo milliseconds, so a message sent in the same
+		// millisecond must still count.
+		position := bson.M{"chat_id": member.ChatID, "created_at": bson.M{"$gte": member.LastReadAt.Truncate(time.Millisecond)}}
+		if lastReadId, err := primitive.ObjectIDFromHex(member.LastReadMessageId); err == nil {
+			position = bson.M{"chat_id": member.ChatID, "$or": bson.A{
+				bson.M{"created_at": bson.M{"$gt": member.LastReadAt}},
+				bson.M{"created_at": member.LastReadAt, "_id": bson.M{"$gt": lastReadId}},
+			}}
+		}
+		positions = append(positions, position)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"$or":        positions,
+			"sender_id":  bson.M{"$ne": userId},
+			"is_deleted": false,
+			"reply_to":   nil,
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$chat_id", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cur, err := r.Db.Collection(r.CollectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var counts []struct {
+		ChatId int64 `bson:"_id"`
+		Count  int64 `bson:"count"`
+	}
+	if err := cur.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	for _, count := range counts {
+		result[count.ChatId] = count.Count
+	}
+	return result, nil
+}
+
+// AddReaction adds the user to the reactions of the message with the emoji. A
+// new emoji is only accepted while the message has less than maxDistinct of them.
+func (r *MessageRepository) AddReaction(Ctx context.Context, id primitive.ObjectID, emoji string, userId int64, maxDistinct int) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Mongo.Large)*time.Millisecond)
+	defer cancel()
+
+	con := r.Db.Collection(r.CollectionName)
+
+	joinExisting := func() (bool, error) {
+		res, err := con.UpdateOne(ctx,
+			bson.M{"_id": id, "is_deleted": false, "reactions.emoji": emoji},
+			bson.M{"$addToSet": bson.M{"reactions.$.user_ids": userId}},
+		)
+		if err != nil {
+			return false, err
+		}
+		return res.MatchedCount > 0, nil
+	}
+
+	if joined, err := joinExisting(); err != nil || joined {
+		return err
+	}
+	if maxDistinct < 1 {
+		return ErrTooManyReactions
+	}
+
+	// The emoji is new for the message, the limit is checked by requiring the
+	// array to have no element at index maxDistinct-1
+	res, err := con.UpdateOne(ctx,
+		bson.M{
+			"_id":             id,
+			"is_deleted":      false,
+			"reactions.emoji": bson.M{"$ne": emoji},
+			"reactions." + strconv.Itoa(maxDistinct-1): bson.M{"$exists": false},
+		},
+		bson.M{"$push": bson.M{"reactions": bson.M{"emoji": emoji, "user_ids": bson.A{userId}}}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount > 0 {
+		return nil
+	}
+
+	// Someone else may have added the same emoji in the meantime
+	if joined, err := joinExisting(); err != nil || joined {
+		return err
+	}
+
+	count, err := con.CountDocuments(ctx, bson.M{"_id": id, "is_deleted": false})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrRecordNotFound
+	}
+	return ErrTooManyReactions
+}
+
+// RemoveReaction removes the user from the reactions of the message with the
+// emoji, the emoji goes away with its last user.
+func (r *MessageRepository) RemoveReaction(Ctx context.Context, id primitive.ObjectID, emoji string, userId int64) error {
+	ctx, cancel := context.WithTimeout(Ctx, time.Duration(settings.AppVar.Config.Timeout.Mongo.Large)*time.Millisecond)
+	defer cancel()
+
+	con := r.Db.Collection(r.CollectionName)
+
+	_, err := con.UpdateOne(ctx,
+		bson.M{"_id": id, "reactions.emoji": emoji},
+		bson.M{"$pull": bson.M{"reactions.$.user_ids": userId}},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = con.UpdateOne(ctx,
+		bson.M{"_id": id, "reactions": bson.M{"$elemMatch": bson.M{"emoji": emoji, "user_ids": bson.M{"$size": 0}}}},
+		bson.M{"$pull": bson.M{"reactions": bson.M{"emoji": emoji, "user_ids": bson.M{"$size": 0}}}},
+	)
 	return err
 }
+
+// Search returns up to limit messages matching the search, newest first,
+// starting strictly before the (createdAt, id) position when createdAt is set.
+// Deleted and system messages are never found.
+func (r *MessageRepository) Search(Ctx context.Context, search MessageSearch, createdAt time.Time, id primitive.ObjectID, limit int64) ([]domain.Message, error) {
+	filter := bson.M{
+		"$text":      bson.M{"$search": search.Query},
+		"chat_id":    bson.M{"$in": search.ChatIds},
+		"is_deleted": false,
+		"type":       bson.M{"$ne": enums.SYSTEM_MESSAGE},
+	}
+	if search.SenderId != 0 {
+		filter["sender_id"] = search.SenderId
+	}
+
+	period := bson.M{}
+	if !search.From.IsZero() {
+		period["$gte"] = search.From
+	}
+	if !search.To.IsZero() {
+		period["$lt"] = search.To
+	}
+	if len(period) > 0 {
+		filter["created_at"] = period
+	}
+
+	if !createdAt.IsZero() {
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": createdAt}},
+			bson.M{"created_at": createdAt, "_id": bson.M{"$lt": id}},
+		}
+	}
+
+	return r.GetAll(Ctx, filter, 0, limit, bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"time"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+type ObjectInfo struct {
+	Size    int64
+	ModTime time.Time
+	// ETag changes whenever the content does, without quotes
+	ETag string
+	// ContentType is empty when the storage does not know it
+	ContentType string
+}
+
+// IStorage keeps uploaded files, keys are slash separated paths like
+// "attachments/1/<uuid>".
+type IStorage interface {
+	Save(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete removes the object, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// SignedURL gives a link anyone can download the object from until it
+	// expires, a non-empty name makes browsers save it under this name.
+	SignedURL(ctx context.Context, key string, name string, expires time.Duration) (string, error)
+}
+
+// SaveFile stores an uploaded file under the key
+func SaveFile(ctx context.Context, storage IStorage, key string, fileHeader *multipart.FileHeader, contentType string) error {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return storage.Save(ctx, key, file, fileHeader.Size, contentType)
+}
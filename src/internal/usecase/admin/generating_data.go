@@ -216,7 +216,6 @@ func (dg *DataGenerator) GenerateMessages(caller dto.UserDTO, count int) error {
 					SenderId:  senderId,
 					ChatId:    chatId,
 					Content:   message.Content,
-					IsRead:    message.IsRead,
 					IsUpdated: message.IsUpdated,
 					IsDeleted: message.IsDeleted,
 				}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	domain "libs/src/internal/domain/models"
+	"libs/src/settings"
+	"strings"
+)
+
+// avatarURL links the avatar in the size, users without an avatar get an
+// empty string. Avatar keys are never reused so the links are stable.
+func avatarURL(app *settings.App, image string, size int) string {
+	if image == "" {
+		return ""
+	}
+	key := strings.TrimPrefix(domain.AvatarKey(image, size), domain.AvatarsPrefix)
+	return app.Config.AppConfig.BaseURL() + settings.AvatarsPath + "/" + key
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"libs/src/internal/domain/enums"
+	domain "libs/src/internal/domain/models"
+	"libs/src/internal/dto"
+	"libs/src/internal/repositories"
+	usecase_errors "libs/src/internal/usecase/errors"
+	"libs/src/pkg/utils"
+	"libs/src/settings"
+	"time"
+)
+
+// InvitesPath is where invite links are previewed and redeemed
+const InvitesPath = "/messenger/invite/"
+
+type ChatInviteService struct {
+	App                  *settings.App
+	ChatInviteRepository repositories.IChatInviteRepository
+	ChatMemberRepository repositories.IChatMemberRepository
+	ChatRepository       repositories.IChatRepository
+	ChatMemberService    *ChatMemberService
+}
+
+func NewChatInviteService(app *settings.App) *ChatInviteService {
+	return &ChatInviteService{
+		App:                  app,
+		ChatInviteRepository: repositories.NewChatInviteRepository(app),
+		ChatMemberRepository: repositories.NewChatMemberRepository(app),
+		ChatRepository:       repositories.NewChatRepository(app),
+		ChatMemberService:    NewChatMemberService(app),
+	}
+}
+
+func (s *ChatInviteService) CreateInvite(ctx context.Context, caller dto.UserDTO, chatId int64, request dto.CreateInviteRequest) (dto.ChatInviteDTO, error) {
+	if err := s.checkCanManage(ctx, caller, chatId); err != nil {
+		return dto.ChatInviteDTO{}, err
+	}
+
+	token, err := utils.GenerateToken(16)
+	if err != nil {
+		return dto.ChatInviteDTO{}, err
+	}
+	invite := domain.ChatInvite{
+		ChatID:    chatId,
+		CreatorID: caller.ID,
+		Token:     token,
+		MaxUses:   request.MaxUses,
+	}
+	if request.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(request.ExpiresIn) * time.Second)
+		invite.ExpiresAt = &expiresAt
+	}
+
+	if err = s.ChatInviteRepository.Create(ctx, &invite); err != nil {
+		return dto.ChatInviteDTO{}, err
+	}
+	invite.Creator.Username = caller.Username
+	return invite.ToDTO(s.inviteLink(invite.Token)), nil
+}
+
+func (s *ChatInviteService) GetInvites(ctx context.Context, caller dto.UserDTO, chatId int64) (dto.ChatInviteListResponse, error) {
+	if err := s.checkCanManage(ctx, caller, chatId); err != nil {
+		return dto.ChatInviteListResponse{}, err
+	}
+
+	invites, err := s.ChatInviteRepository.GetListForChat(ctx, chatId)
+	if err != nil {
+		return dto.ChatInviteListResponse{}, err
+	}
+
+	result := make([]dto.ChatInviteDTO, len(invites))
+	for i := range invites {
+		result[i] = invites[i].ToDTO(s.inviteLink(invites[i].Token))
+	}
+	return dto.ChatInviteListResponse{Invites: result}, nil
+}
+
+func (s *ChatInviteService) RevokeInvite(ctx context.Context, caller dto.UserDTO, chatId, inviteId int64) error {
+	if err := s.checkCanManage(ctx, caller, chatId); err != nil {
+		return err
+	}
+
+	err := s.ChatInviteRepository.Revoke(ctx, chatId, inviteId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Invite not found"}
+		}
+		return err
+	}
+	return nil
+}
+
+// PreviewInvite shows the chat behind an invite link without joining it
+func (s *ChatInviteService) PreviewInvite(ctx context.Context, caller dto.UserDTO, token string) (dto.InvitePreviewDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.InvitePreviewDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to use invite links"}
+	}
+
+	invite, err := s.getActiveInvite(ctx, token)
+	if err != nil {
+		return dto.InvitePreviewDTO{}, err
+	}
+
+	chat, err := s.ChatRepository.GetPreview(ctx, invite.ChatID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.InvitePreviewDTO{}, usecase_errors.NotFoundError{Msg: "Invite not found"}
+		}
+		return dto.InvitePreviewDTO{}, err
+	}
+
+	memberCount, err := s.ChatMemberRepository.Count(ctx, "chat_id = ? AND user_id = ?", invite.ChatID, caller.ID)
+	if err != nil {
+		return dto.InvitePreviewDTO{}, err
+	}
+
+	return dto.InvitePreviewDTO{
+		Chat:      chat,
+		ExpiresAt: invite.ExpiresAt,
+		IsMember:  memberCount > 0,
+	}, nil
+}
+
+// RedeemInvite adds the caller to the chat of the invite and takes one of its
+// uses. The use is given back when the caller could not be added.
+func (s *ChatInviteService) RedeemInvite(ctx context.Context, caller dto.UserDTO, token string) (dto.ChatDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.ChatDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to use invite links"}
+	}
+
+	invite, err := s.getActiveInvite(ctx, token)
+	if err != nil {
+		return dto.ChatDTO{}, err
+	}
+
+	chat, err := s.ChatRepository.GetById(ctx, invite.ChatID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatDTO{}, usecase_errors.NotFoundError{Msg: "Invite not found"}
+		}
+		return dto.ChatDTO{}, err
+	}
+
+	err = s.ChatInviteRepository.Consume(ctx, invite.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatDTO{}, usecase_errors.NotFoundError{Msg: "Invite link is expired or revoked"}
+		}
+		return dto.ChatDTO{}, err
+	}
+
+	err = s.ChatMemberService.CreateMember(ctx, caller, invite.ChatID, nil)
+	if err != nil {
+		if releaseErr := s.ChatInviteRepository.Release(ctx, invite.ID); releaseErr != nil {
+			s.App.Logger.Warn(fmt.Sprintf("Failed to release use of invite %d: %v", invite.ID, releaseErr))
+		}
+		return dto.ChatDTO{}, err
+	}
+	return chat.ToDTO(), nil
+}
+
+// checkCanManage allows members of group chats who can invite to manage invite links
+func (s *ChatInviteService) checkCanManage(ctx context.Context, caller dto.UserDTO, chatId int64) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to manage invite links"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return usecase_errors.BadRequestError{Msg: "Direct chats cannot have more members"}
+	}
+	return authorize(callerInfo, enums.INVITE_MEMBERS)
+}
+
+func (s *ChatInviteService) getActiveInvite(ctx context.Context, token string) (domain.ChatInvite, error) {
+	invite, err := s.ChatInviteRepository.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return domain.ChatInvite{}, usecase_errors.NotFoundError{Msg: "Invite not found"}
+		}
+		return domain.ChatInvite{}, err
+	}
+	if !invite.IsActive(time.Now()) {
+		return domain.ChatInvite{}, usecase_errors.NotFoundError{Msg: "Invite link is expired or revoked"}
+	}
+	return invite, nil
+}
+
+func (s *ChatInviteService) inviteLink(token string) string {
+	return s.App.Config.AppConfig.BaseURL() + InvitesPath + token
+}
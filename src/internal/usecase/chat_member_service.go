@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"libs/src/internal/domain/enums"
 	domain "libs/src/internal/domain/models"
 	"libs/src/internal/dto"
@@ -10,25 +11,45 @@ import (
 	usecase_errors "libs/src/internal/usecase/errors"
 	"libs/src/settings"
 	"strings"
+	"time"
 )
 
 type ChatMemberService struct {
-	App                  *settings.App
-	ChatMemberRepository repositories.IChatMemberRepository
-	UserRepository       repositories.IUserRepository
-	ChatRepository       repositories.IChatRepository
+	App                      *settings.App
+	ChatMemberRepository     repositories.IChatMemberRepository
+	UserRepository           repositories.IUserRepository
+	ChatRepository           repositories.IChatRepository
+	ChatInvitationRepository repositories.IChatInvitationRepository
+	JoinRequestRepository    repositories.IJoinRequestRepository
+	RestrictionRepository    repositories.IChatRestrictionRepository
+	RolePermissionRepository repositories.IChatRolePermissionRepository
+	ChatRoleRepository       repositories.IChatRoleRepository
+	MessageRepository        repositories.IMessageRepository
 }
 
 func NewChatMemberService(app *settings.App) *ChatMemberService {
 	return &ChatMemberService{
-		App:                  app,
-		ChatMemberRepository: repositories.NewChatMemberRepository(app),
-		UserRepository:       repositories.NewUserRepository(app),
-		ChatRepository:       repositories.NewChatRepository(app),
+		App:                      app,
+		ChatMemberRepository:     repositories.NewChatMemberRepository(app),
+		UserRepository:           repositories.NewUserRepository(app),
+		ChatRepository:           repositories.NewChatRepository(app),
+		ChatInvitationRepository: repositories.NewChatInvitationRepository(app),
+		JoinRequestRepository:    repositories.NewJoinRequestRepository(app),
+		RestrictionRepository:    repositories.NewChatRestrictionRepository(app),
+		RolePermissionRepository: repositories.NewChatRolePermissionRepository(app),
+		ChatRoleRepository:       repositories.NewChatRoleRepository(app),
+		MessageRepository:        repositories.NewMessageRepository(app),
 	}
 }
 
-func (s *ChatMemberService) CreateMember(ctx context.Context, userId int64, chatId int64) error {
+// CreateMember adds the user to the chat. addedBy is the member who let the
+// user in, nil when the user joined by themselves.
+func (s *ChatMemberService) CreateMember(ctx context.Context, user dto.UserDTO, chatId int64, addedBy *dto.UserDTO) error {
+	userId := user.ID
+	if err := s.checkNotBanned(ctx, chatId, userId); err != nil {
+		return err
+	}
+
 	memberCount, err := s.ChatMemberRepository.Count(ctx, "chat_id = ? AND user_id = ?", chatId, userId)
 	if err != nil {
 		return err
@@ -43,64 +64,260 @@ func (s *ChatMemberService) CreateMember(ctx context.Context, userId int64, chat
 		MemberRole: enums.MEMBER,
 	}
 	err = s.ChatMemberRepository.Create(ctx, &member)
-	return err
+	if err != nil {
+		return err
+	}
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:   enums.EventTypesToLabels[enums.MEMBER_JOINED],
+		ChatId: chatId,
+		UserId: userId,
+	})
+
+	if addedBy == nil {
+		postSystemMessage(ctx, s.App, s.MessageRepository, user, chatId,
+			fmt.Sprintf("%s joined the chat", user.Username),
+			domain.SystemEvent{Action: enums.SYSTEM_MEMBER_JOINED},
+		)
+	} else {
+		postSystemMessage(ctx, s.App, s.MessageRepository, *addedBy, chatId,
+			fmt.Sprintf("%s added %s", addedBy.Username, user.Username),
+			domain.SystemEvent{Action: enums.SYSTEM_MEMBER_ADDED, TargetId: userId, Target: user.Username},
+		)
+	}
+	return nil
 }
 
-func (s *ChatMemberService) InviteToChat(ctx context.Context, inviter *dto.UserDTO, inviteeUsername string, chatId int64) error {
+// InviteToChat invites the user to the chat. The invitee joins after
+// accepting the invitation, unless the invitee lets contacts add them
+// directly and the inviter is one of them.
+func (s *ChatMemberService) InviteToChat(ctx context.Context, inviter *dto.UserDTO, inviteeUsername string, chatId int64) (dto.ChatInvitationDTO, error) {
 	if inviter.Role == enums.ANONYMOUS || !inviter.IsActive {
-		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to invite someone"}
+		return dto.ChatInvitationDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to invite someone"}
 	}
 	inviterInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, inviter.ID, chatId)
 	if err != nil {
 		if errors.Is(err, repositories.ErrRecordNotFound) {
-			return usecase_errors.BadRequestError{Msg: "Inviter is not a member of the chat"}
+			return dto.ChatInvitationDTO{}, usecase_errors.BadRequestError{Msg: "Inviter is not a member of the chat"}
 		}
-		return err
+		return dto.ChatInvitationDTO{}, err
 	}
-	if inviterInfo.MemberRole < enums.CHAT_ADMIN {
-		return usecase_errors.PermissionError{Msg: "You have no permission to invite someone to the chat"}
+	if inviterInfo.ChatType == enums.DIRECT {
+		return dto.ChatInvitationDTO{}, usecase_errors.BadRequestError{Msg: "Direct chats cannot have more members"}
+	}
+	if err = authorize(inviterInfo, enums.INVITE_MEMBERS); err != nil {
+		return dto.ChatInvitationDTO{}, err
 	}
 
 	invitee, err := s.UserRepository.GetByUsername(ctx, inviteeUsername)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
-			return usecase_errors.NotFoundError{Msg: "Invitee not found"}
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatInvitationDTO{}, usecase_errors.NotFoundError{Msg: "Invitee not found"}
 		}
-		return err
+		return dto.ChatInvitationDTO{}, err
 	}
 	if invitee.Role == enums.ANONYMOUS || !invitee.IsActive {
-		return usecase_errors.NotFoundError{Msg: "Invitee not found"}
+		return dto.ChatInvitationDTO{}, usecase_errors.NotFoundError{Msg: "Invitee not found"}
+	}
+
+	memberCount, err := s.ChatMemberRepository.Count(ctx, "chat_id = ? AND user_id = ?", chatId, invitee.ID)
+	if err != nil {
+		return dto.ChatInvitationDTO{}, err
+	}
+	if memberCount > 0 {
+		return dto.ChatInvitationDTO{}, usecase_errors.AlreadyExistsError{Msg: "User already exists in chat"}
+	}
+	if err = s.checkNotBanned(ctx, chatId, invitee.ID); err != nil {
+		return dto.ChatInvitationDTO{}, err
+	}
+
+	invitation := domain.ChatInvitation{
+		ChatID:    chatId,
+		InviterID: inviter.ID,
+		InviteeID: invitee.ID,
+		Status:    enums.INVITATION_PENDING,
+	}
+
+	addDirectly := false
+	if invitee.DirectAdds == enums.DIRECT_ADDS_CONTACTS {
+		addDirectly, err = s.isContact(ctx, inviter.ID, invitee.ID)
+		if err != nil {
+			return dto.ChatInvitationDTO{}, err
+		}
+	}
+	if addDirectly {
+		now := time.Now()
+		invitation.Status = enums.INVITATION_ACCEPTED
+		invitation.RespondedAt = &now
+	}
+
+	// The invitation goes first, so a failed insert leaves no member and no
+	// system message behind
+	err = s.ChatInvitationRepository.Create(ctx, &invitation)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return dto.ChatInvitationDTO{}, usecase_errors.AlreadyExistsError{Msg: "User is already invited to the chat"}
+		}
+		return dto.ChatInvitationDTO{}, err
+	}
+
+	if addDirectly {
+		if err = s.CreateMember(ctx, invitee.ToDTO(), chatId, inviter); err != nil {
+			if deleteErr := s.ChatInvitationRepository.DeleteById(ctx, invitation.ID); deleteErr != nil {
+				s.App.Logger.Error(fmt.Sprintf("Error removing invitation %d of a failed direct add: %v", invitation.ID, deleteErr))
+			}
+			return dto.ChatInvitationDTO{}, err
+		}
+	}
+
+	invitation.Chat.Title = inviterInfo.ChatTitle
+	invitation.Inviter.Username = inviter.Username
+	invitation.Invitee.Username = invitee.Username
+	return invitation.ToDTO(), nil
+}
+
+// isContact tells whether the users have a direct chat with each other
+func (s *ChatMemberService) isContact(ctx context.Context, firstUserId, secondUserId int64) (bool, error) {
+	count, err := s.ChatRepository.Count(ctx, "direct_key = ?", domain.DirectChatKey(firstUserId, secondUserId))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetInvitations returns the pending invitations of the caller
+func (s *ChatMemberService) GetInvitations(ctx context.Context, caller dto.UserDTO, page int) (dto.ChatInvitationListResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.ChatInvitationListResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to get invitations"}
+	}
+	if page < 1 {
+		return dto.ChatInvitationListResponse{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
 	}
 
-	err = s.CreateMember(ctx, invitee.ID, chatId)
+	limit := s.App.Config.Pagination.ChatList
+	invitations, err := s.ChatInvitationRepository.GetInbox(ctx, caller.ID, limit, (page-1)*limit)
+	if err != nil {
+		return dto.ChatInvitationListResponse{}, err
+	}
+	return invitationList(invitations), nil
+}
+
+// GetSentInvitations returns the invitations made by the caller with their status
+func (s *ChatMemberService) GetSentInvitations(ctx context.Context, caller dto.UserDTO, page int) (dto.ChatInvitationListResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.ChatInvitationListResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to get invitations"}
+	}
+	if page < 1 {
+		return dto.ChatInvitationListResponse{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	}
 
-	return err
+	limit := s.App.Config.Pagination.ChatList
+	invitations, err := s.ChatInvitationRepository.GetSent(ctx, caller.ID, limit, (page-1)*limit)
+	if err != nil {
+		return dto.ChatInvitationListResponse{}, err
+	}
+	return invitationList(invitations), nil
 }
 
+func invitationList(invitations []domain.ChatInvitation) dto.ChatInvitationListResponse {
+	result := make([]dto.ChatInvitationDTO, len(invitations))
+	for i := range invitations {
+		result[i] = invitations[i].ToDTO()
+	}
+	return dto.ChatInvitationListResponse{Invitations: result}
+}
+
+// AcceptInvitation makes the caller a member of the chat of the invitation
+func (s *ChatMemberService) AcceptInvitation(ctx context.Context, caller dto.UserDTO, invitationId int64) (dto.ChatDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.ChatDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to accept invitations"}
+	}
+
+	invitation, err := s.ChatInvitationRepository.GetPending(ctx, invitationId, caller.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatDTO{}, usecase_errors.NotFoundError{Msg: "Invitation not found"}
+		}
+		return dto.ChatDTO{}, err
+	}
+
+	// The invitee may have joined the chat another way in the meantime
+	inviter := invitation.Inviter.ToDTO()
+	err = s.CreateMember(ctx, caller, invitation.ChatID, &inviter)
+	if err != nil {
+		if _, ok := err.(usecase_errors.IAlreadyExistsError); !ok {
+			return dto.ChatDTO{}, err
+		}
+	}
+
+	err = s.ChatInvitationRepository.Respond(ctx, invitationId, caller.ID, enums.INVITATION_ACCEPTED)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatDTO{}, usecase_errors.NotFoundError{Msg: "Invitation not found"}
+		}
+		return dto.ChatDTO{}, err
+	}
+	return invitation.Chat.ToDTO(), nil
+}
+
+func (s *ChatMemberService) DeclineInvitation(ctx context.Context, caller dto.UserDTO, invitationId int64) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to decline invitations"}
+	}
+
+	err := s.ChatInvitationRepository.Respond(ctx, invitationId, caller.ID, enums.INVITATION_DECLINED)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Invitation not found"}
+		}
+		return err
+	}
+	return nil
+}
+
+// ChangeMemberRole gives the member a built-in role or a custom role of the
+// chat. Only the roles ranked below the caller's own can be handed out.
 func (s *ChatMemberService) ChangeMemberRole(ctx context.Context, caller dto.UserDTO, chatId int64, targetUsername string, newRole string) error {
-	role, ex := enums.ChatLabelsToRoles[strings.ToLower(newRole)]
-	if !ex {
-		return usecase_errors.BadRequestError{Msg: "Invalid role"}
+	newRole = strings.ToLower(newRole)
+	role, ex := enums.ChatLabelsToRoles[newRole]
+	if ex && role >= enums.OWNER {
+		return usecase_errors.PermissionError{Msg: "The owner can only be changed by transferring the ownership"}
 	}
-	if role >= enums.OWNER {
-		return usecase_errors.PermissionError{Msg: "You do not have permission to change role"}
+
+	var customRole *domain.ChatRole
+	newRank := enums.ChatRolesToRanks[role]
+	if !ex {
+		chatRole, err := s.ChatRoleRepository.GetByName(ctx, chatId, newRole)
+		if err != nil {
+			if errors.Is(err, repositories.ErrRecordNotFound) {
+				return usecase_errors.BadRequestError{Msg: "Invalid role"}
+			}
+			return err
+		}
+		customRole = &chatRole
+		role = enums.MEMBER
+		newRank = chatRole.Rank
 	}
 
 	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
 		}
 		return err
 	}
 
-	if callerInfo.MemberRole < enums.OWNER {
-		return usecase_errors.PermissionError{Msg: "You do not have permission to change role"}
+	if callerInfo.ChatType == enums.DIRECT {
+		return usecase_errors.BadRequestError{Msg: "Direct chats have no roles"}
+	}
+
+	if err = authorize(callerInfo, enums.MANAGE_ROLES); err != nil {
+		return err
 	}
 
 	target, err := s.UserRepository.GetByUsername(ctx, targetUsername)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.NotFoundError{Msg: "Target user not found"}
 		}
 		return err
@@ -112,23 +329,47 @@ func (s *ChatMemberService) ChangeMemberRole(ctx context.Context, caller dto.Use
 
 	targetInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, target.ID, chatId)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.BadRequestError{Msg: "Target user not in chat"}
 		}
 		return err
 	}
 
-	if targetInfo.MemberRole == byte(role) {
+	if roleName(targetInfo) == newRole {
 		return nil
 	}
+	if targetInfo.MemberRole == enums.OWNER {
+		return usecase_errors.PermissionError{Msg: "The owner can only be changed by transferring the ownership"}
+	}
+	callerRank := roleRank(callerInfo)
+	if roleRank(targetInfo) >= callerRank || newRank >= callerRank {
+		return usecase_errors.PermissionError{Msg: "You can only change roles below your own"}
+	}
 
-	err = s.ChatMemberRepository.SetNewRole(ctx, chatId, targetInfo.MemberID, byte(role))
+	var roleId *int64
+	if customRole != nil {
+		roleId = &customRole.ID
+	}
+	audit := &domain.ChatAuditEntry{
+		ChatID:   chatId,
+		ActorID:  caller.ID,
+		Action:   enums.AUDIT_ROLE_CHANGED,
+		TargetID: &target.ID,
+		Before:   map[string]any{"role": roleName(targetInfo)},
+		After:    map[string]any{"role": newRole},
+	}
+	err = s.ChatMemberRepository.SetNewRole(ctx, chatId, targetInfo.MemberID, byte(role), roleId, audit)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.BadRequestError{Msg: "Target user not in chat"}
 		}
 		return err
 	}
+
+	postSystemMessage(ctx, s.App, s.MessageRepository, caller, chatId,
+		fmt.Sprintf("%s made %s %s", caller.Username, target.Username, newRole),
+		domain.SystemEvent{Action: enums.SYSTEM_ROLE_CHANGED, TargetId: target.ID, Target: target.Username, Value: newRole},
+	)
 	return nil
 }
 
@@ -139,14 +380,18 @@ func (s *ChatMemberService) DeleteMember(ctx context.Context, caller dto.UserDTO
 
 	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.BadRequestError{Msg: "You are not a member of the chat"}
 		}
 		return err
 	}
 
-	if callerInfo.MemberRole < enums.CHAT_ADMIN {
-		return usecase_errors.PermissionError{Msg: "You do not have permission to delete someone"}
+	if callerInfo.ChatType == enums.DIRECT {
+		return usecase_errors.BadRequestError{Msg: "Members cannot be removed from direct chats"}
+	}
+
+	if err = authorize(callerInfo, enums.KICK_MEMBERS); err != nil {
+		return err
 	}
 
 	target, err := s.UserRepository.GetByUsername(ctx, targetUsername)
@@ -159,23 +404,41 @@ func (s *ChatMemberService) DeleteMember(ctx context.Context, caller dto.UserDTO
 
 	targetInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, target.ID, chatId)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.BadRequestError{Msg: "Target user not in chat"}
 		}
 		return err
 	}
 
-	if targetInfo.MemberRole >= callerInfo.MemberRole {
+	if roleRank(targetInfo) >= roleRank(callerInfo) {
 		return usecase_errors.PermissionError{Msg: "You do not have permission to delete target"}
 	}
 
-	err = s.ChatMemberRepository.DeleteMember(ctx, targetInfo.MemberID, chatId)
+	audit := &domain.ChatAuditEntry{
+		ChatID:   chatId,
+		ActorID:  caller.ID,
+		Action:   enums.AUDIT_MEMBER_KICKED,
+		TargetID: &target.ID,
+		Before:   map[string]any{"role": roleName(targetInfo)},
+	}
+	err = s.ChatMemberRepository.DeleteMember(ctx, targetInfo.MemberID, chatId, audit)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.BadRequestError{Msg: "Target user not in chat"}
 		}
 		return err
 	}
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:   enums.EventTypesToLabels[enums.MEMBER_LEFT],
+		ChatId: chatId,
+		UserId: targetInfo.MemberID,
+	})
+
+	postSystemMessage(ctx, s.App, s.MessageRepository, caller, chatId,
+		fmt.Sprintf("%s removed %s", caller.Username, target.Username),
+		domain.SystemEvent{Action: enums.SYSTEM_MEMBER_KICKED, TargetId: target.ID, Target: target.Username},
+	)
 	return nil
 }
 
@@ -199,11 +462,693 @@ func (s *ChatMemberService) GetList(ctx context.Context, caller dto.UserDTO, cha
 
 	res, err := s.ChatMemberRepository.GetMembersPreview(ctx, chatId, 25, (page-1)*25, searchName)
 	if err != nil {
-		if errors.As(err, &repositories.ErrLimitMustBePositive) || errors.As(err, &repositories.ErrOffsetMustBePositive) {
+		if errors.Is(err, repositories.ErrLimitMustBePositive) || errors.Is(err, repositories.ErrOffsetMustBePositive) {
 			return dto.MemberListPreview{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
 		}
 		return dto.MemberListPreview{}, err
 	}
 
+	for i := range res {
+		res[i].Avatar = avatarURL(s.App, res[i].Avatar, enums.AVATAR_SMALL)
+	}
 	return dto.MemberListPreview{Members: res}, nil
 }
+
+func (s *ChatMemberService) GetChatIdsForUser(ctx context.Context, caller dto.UserDTO) ([]int64, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return nil, usecase_errors.UnauthorizedError{Msg: "You must be logged in to receive messages"}
+	}
+
+	members, err := s.ChatMemberRepository.Filter(ctx, "user_id = ?", caller.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	chatIds := make([]int64, len(members))
+	for i, member := range members {
+		chatIds[i] = member.ChatID
+	}
+	return chatIds, nil
+}
+
+// JoinChat adds the caller to a public chat, private chats need an invitation
+func (s *ChatMemberService) JoinChat(ctx context.Context, caller dto.UserDTO, chatId int64) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to join a chat"}
+	}
+
+	chat, err := s.ChatRepository.GetById(ctx, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Chat not found"}
+		}
+		return err
+	}
+	if chat.Type == enums.DIRECT {
+		return usecase_errors.NotFoundError{Msg: "Chat not found"}
+	}
+	if chat.Visibility != enums.PUBLIC {
+		return usecase_errors.PermissionError{Msg: "This chat is private, you need an invitation or an approved join request to join it"}
+	}
+
+	return s.CreateMember(ctx, caller, chatId, nil)
+}
+
+// RequestToJoin asks the admins of a private chat to let the caller in
+func (s *ChatMemberService) RequestToJoin(ctx context.Context, caller dto.UserDTO, chatId int64) (dto.JoinRequestDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.JoinRequestDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to request to join a chat"}
+	}
+
+	chat, err := s.ChatRepository.GetById(ctx, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.JoinRequestDTO{}, usecase_errors.NotFoundError{Msg: "Chat not found"}
+		}
+		return dto.JoinRequestDTO{}, err
+	}
+	if chat.Type == enums.DIRECT {
+		return dto.JoinRequestDTO{}, usecase_errors.NotFoundError{Msg: "Chat not found"}
+	}
+	if chat.Visibility == enums.PUBLIC {
+		return dto.JoinRequestDTO{}, usecase_errors.BadRequestError{Msg: "This chat is public, join it directly"}
+	}
+
+	memberCount, err := s.ChatMemberRepository.Count(ctx, "chat_id = ? AND user_id = ?", chatId, caller.ID)
+	if err != nil {
+		return dto.JoinRequestDTO{}, err
+	}
+	if memberCount > 0 {
+		return dto.JoinRequestDTO{}, usecase_errors.AlreadyExistsError{Msg: "User already exists in chat"}
+	}
+	if err = s.checkNotBanned(ctx, chatId, caller.ID); err != nil {
+		return dto.JoinRequestDTO{}, err
+	}
+
+	request := domain.JoinRequest{
+		ChatID: chatId,
+		UserID: caller.ID,
+		Status: enums.JOIN_REQUEST_PENDING,
+	}
+	err = s.JoinRequestRepository.Create(ctx, &request)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return dto.JoinRequestDTO{}, usecase_errors.AlreadyExistsError{Msg: "You have already requested to join the chat"}
+		}
+		return dto.JoinRequestDTO{}, err
+	}
+
+	request.Chat = chat
+	request.User.Username = caller.Username
+	request.User.Image = caller.Image
+	return s.joinRequestToDTO(request), nil
+}
+
+// GetJoinRequests returns the queue of pending requests to join the chat
+func (s *ChatMemberService) GetJoinRequests(ctx context.Context, caller dto.UserDTO, chatId int64, page int) (dto.JoinRequestListResponse, error) {
+	if err := s.checkCanReview(ctx, caller, chatId); err != nil {
+		return dto.JoinRequestListResponse{}, err
+	}
+	if page < 1 {
+		return dto.JoinRequestListResponse{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	}
+
+	limit := s.App.Config.Pagination.UsersInChatList
+	requests, err := s.JoinRequestRepository.GetQueue(ctx, chatId, limit, (page-1)*limit)
+	if err != nil {
+		return dto.JoinRequestListResponse{}, err
+	}
+	return s.joinRequestList(requests), nil
+}
+
+// GetMyJoinRequests returns the requests made by the caller, so the caller
+// can see whether they were approved and why they were rejected
+func (s *ChatMemberService) GetMyJoinRequests(ctx context.Context, caller dto.UserDTO, page int) (dto.JoinRequestListResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.JoinRequestListResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to get join requests"}
+	}
+	if page < 1 {
+		return dto.JoinRequestListResponse{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	}
+
+	limit := s.App.Config.Pagination.ChatList
+	requests, err := s.JoinRequestRepository.GetForUser(ctx, caller.ID, limit, (page-1)*limit)
+	if err != nil {
+		return dto.JoinRequestListResponse{}, err
+	}
+	return s.joinRequestList(requests), nil
+}
+
+// ApproveJoinRequest makes the requester a member of the chat
+func (s *ChatMemberService) ApproveJoinRequest(ctx context.Context, caller dto.UserDTO, chatId, requestId int64) error {
+	if err := s.checkCanReview(ctx, caller, chatId); err != nil {
+		return err
+	}
+
+	request, err := s.JoinRequestRepository.GetPending(ctx, requestId, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Join request not found"}
+		}
+		return err
+	}
+
+	// The requester may have joined the chat another way in the meantime, or
+	// been banned, then the request can never be approved and leaves the queue
+	err = s.CreateMember(ctx, request.User.ToDTO(), chatId, &caller)
+	if err != nil {
+		if _, ok := err.(usecase_errors.IPermissionError); ok {
+			reviewErr := s.JoinRequestRepository.Review(ctx, requestId, chatId, caller.ID, enums.JOIN_REQUEST_REJECTED, "banned")
+			if reviewErr != nil && !errors.Is(reviewErr, repositories.ErrRecordNotFound) {
+				return reviewErr
+			}
+			return err
+		}
+		if _, ok := err.(usecase_errors.IAlreadyExistsError); !ok {
+			return err
+		}
+	}
+
+	err = s.JoinRequestRepository.Review(ctx, requestId, chatId, caller.ID, enums.JOIN_REQUEST_APPROVED, "")
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Join request not found"}
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *ChatMemberService) RejectJoinRequest(ctx context.Context, caller dto.UserDTO, chatId, requestId int64, reason string) error {
+	if err := s.checkCanReview(ctx, caller, chatId); err != nil {
+		return err
+	}
+
+	err := s.JoinRequestRepository.Review(ctx, requestId, chatId, caller.ID, enums.JOIN_REQUEST_REJECTED, reason)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Join request not found"}
+		}
+		return err
+	}
+	return nil
+}
+
+// checkCanReview allows members of group chats who can invite to review join requests
+func (s *ChatMemberService) checkCanReview(ctx context.Context, caller dto.UserDTO, chatId int64) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to review join requests"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return usecase_errors.BadRequestError{Msg: "Direct chats cannot have more members"}
+	}
+	return authorize(callerInfo, enums.INVITE_MEMBERS)
+}
+
+func (s *ChatMemberService) joinRequestToDTO(request domain.JoinRequest) dto.JoinRequestDTO {
+	result := request.ToDTO()
+	result.Avatar = avatarURL(s.App, result.Avatar, enums.AVATAR_SMALL)
+	return result
+}
+
+func (s *ChatMemberService) joinRequestList(requests []domain.JoinRequest) dto.JoinRequestListResponse {
+	result := make([]dto.JoinRequestDTO, len(requests))
+	for i := range requests {
+		result[i] = s.joinRequestToDTO(requests[i])
+	}
+	return dto.JoinRequestListResponse{Requests: result}
+}
+
+// TransferOwnership makes another member the owner of the chat, the previous
+// owner stays in the chat as an admin
+func (s *ChatMemberService) TransferOwnership(ctx context.Context, caller dto.UserDTO, chatId int64, newOwnerUsername string) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to transfer ownership"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return usecase_errors.BadRequestError{Msg: "Direct chats have no owner"}
+	}
+	if callerInfo.MemberRole < enums.OWNER {
+		return usecase_errors.PermissionError{Msg: "Only the owner can transfer the ownership"}
+	}
+
+	return s.transferOwnership(ctx, caller, chatId, newOwnerUsername)
+}
+
+func (s *ChatMemberService) transferOwnership(ctx context.Context, caller dto.UserDTO, chatId int64, newOwnerUsername string) error {
+	target, err := s.UserRepository.GetByUsername(ctx, newOwnerUsername)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Target user not found"}
+		}
+		return err
+	}
+	if target.Role == enums.ANONYMOUS || !target.IsActive {
+		return usecase_errors.NotFoundError{Msg: "Target user not found"}
+	}
+	if target.ID == caller.ID {
+		return usecase_errors.BadRequestError{Msg: "You already own the chat"}
+	}
+
+	audit := &domain.ChatAuditEntry{
+		ChatID:   chatId,
+		ActorID:  caller.ID,
+		Action:   enums.AUDIT_OWNER_CHANGED,
+		TargetID: &target.ID,
+		Before:   map[string]any{"owner": caller.Username},
+		After:    map[string]any{"owner": target.Username},
+	}
+	err = s.ChatRepository.TransferOwnership(ctx, chatId, caller.ID, target.ID, audit)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.BadRequestError{Msg: "Target user not in chat"}
+		}
+		return err
+	}
+
+	postSystemMessage(ctx, s.App, s.MessageRepository, caller, chatId,
+		fmt.Sprintf("%s transferred ownership to %s", caller.Username, target.Username),
+		domain.SystemEvent{Action: enums.SYSTEM_OWNER_CHANGED, TargetId: target.ID, Target: target.Username},
+	)
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:   enums.EventTypesToLabels[enums.OWNER_CHANGED],
+		ChatId: chatId,
+		UserId: target.ID,
+	})
+	return nil
+}
+
+// LeaveChat removes the caller from the chat. The owner has to choose whether
+// the chat goes to another member or gets deleted.
+func (s *ChatMemberService) LeaveChat(ctx context.Context, caller dto.UserDTO, chatId int64, request dto.LeaveChatRequest) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to leave a chat"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return usecase_errors.BadRequestError{Msg: "You cannot leave a direct chat"}
+	}
+
+	if callerInfo.MemberRole == enums.OWNER {
+		switch request.OwnerAction {
+		case "transfer":
+			if request.NewOwner == "" {
+				return usecase_errors.BadRequestError{Msg: "New owner is required to transfer the ownership"}
+			}
+			if err = s.transferOwnership(ctx, caller, chatId, request.NewOwner); err != nil {
+				return err
+			}
+		case "delete":
+			err = s.ChatRepository.DeleteChat(ctx, chatId, &domain.ChatAuditEntry{
+				ChatID:  chatId,
+				ActorID: caller.ID,
+				Action:  enums.AUDIT_CHAT_DELETED,
+				Before:  map[string]any{"title": callerInfo.ChatTitle},
+			})
+			if err != nil {
+				if errors.Is(err, repositories.ErrRecordNotFound) {
+					return usecase_errors.NotFoundError{Msg: "Chat with this ID not found"}
+				}
+				return err
+			}
+			publishEvent(ctx, s.App, dto.EventDTO{
+				Type:   enums.EventTypesToLabels[enums.CHAT_DELETED],
+				ChatId: chatId,
+			})
+			return nil
+		default:
+			return usecase_errors.BadRequestError{Msg: "The owner must transfer the ownership or delete the chat to leave it"}
+		}
+	}
+
+	err = s.ChatMemberRepository.DeleteMember(ctx, caller.ID, chatId, nil)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return err
+	}
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:   enums.EventTypesToLabels[enums.MEMBER_LEFT],
+		ChatId: chatId,
+		UserId: caller.ID,
+	})
+
+	postSystemMessage(ctx, s.App, s.MessageRepository, caller, chatId,
+		fmt.Sprintf("%s left the chat", caller.Username),
+		domain.SystemEvent{Action: enums.SYSTEM_MEMBER_LEFT},
+	)
+	return nil
+}
+
+// checkNotBanned keeps banned users from joining the chat in any way
+func (s *ChatMemberService) checkNotBanned(ctx context.Context, chatId, userId int64) error {
+	ban, err := s.RestrictionRepository.GetActive(ctx, chatId, userId, enums.BAN)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if ban.ExpiresAt != nil {
+		return usecase_errors.PermissionError{Msg: fmt.Sprintf("User is banned from the chat until %s", ban.ExpiresAt.Format(time.RFC3339))}
+	}
+	return usecase_errors.PermissionError{Msg: "User is banned from the chat"}
+}
+
+// Restrict bans or mutes the user in the chat, replacing the previous
+// restriction of the type. A ban also removes the user from the chat, and
+// users who are not members yet can be banned in advance.
+func (s *ChatMemberService) Restrict(ctx context.Context, caller dto.UserDTO, chatId int64, targetUsername string, restrictionType byte, request dto.RestrictMemberRequest) (dto.ChatRestrictionDTO, error) {
+	callerInfo, err := s.checkCanModerate(ctx, caller, chatId)
+	if err != nil {
+		return dto.ChatRestrictionDTO{}, err
+	}
+	if restrictionType == enums.MUTE && request.Duration == 0 {
+		return dto.ChatRestrictionDTO{}, usecase_errors.BadRequestError{Msg: "Mutes must have a duration"}
+	}
+
+	target, err := s.UserRepository.GetByUsername(ctx, targetUsername)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatRestrictionDTO{}, usecase_errors.NotFoundError{Msg: "Target user not found"}
+		}
+		return dto.ChatRestrictionDTO{}, err
+	}
+	if target.Role == enums.ANONYMOUS || !target.IsActive {
+		return dto.ChatRestrictionDTO{}, usecase_errors.NotFoundError{Msg: "Target user not found"}
+	}
+
+	isMember := true
+	targetInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, target.ID, chatId)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatRestrictionDTO{}, err
+		}
+		if restrictionType == enums.MUTE {
+			return dto.ChatRestrictionDTO{}, usecase_errors.BadRequestError{Msg: "Target user not in chat"}
+		}
+		isMember = false
+	}
+	if isMember && roleRank(targetInfo) >= roleRank(callerInfo) {
+		return dto.ChatRestrictionDTO{}, usecase_errors.PermissionError{Msg: "You do not have permission to restrict target"}
+	}
+
+	restriction := domain.ChatRestriction{
+		ChatID:      chatId,
+		UserID:      target.ID,
+		Type:        restrictionType,
+		Reason:      request.Reason,
+		CreatedByID: caller.ID,
+	}
+	if request.Duration > 0 {
+		expiresAt := time.Now().Add(time.Duration(request.Duration) * time.Second)
+		restriction.ExpiresAt = &expiresAt
+	}
+	audit := &domain.ChatAuditEntry{
+		ChatID:   chatId,
+		ActorID:  caller.ID,
+		Action:   enums.AUDIT_MEMBER_MUTED,
+		TargetID: &target.ID,
+		After:    map[string]any{"reason": request.Reason, "expires_at": restriction.ExpiresAt},
+	}
+	if restrictionType == enums.BAN {
+		audit.Action = enums.AUDIT_MEMBER_BANNED
+	}
+	if isMember {
+		audit.Before = map[string]any{"role": roleName(targetInfo)}
+	}
+	if err = s.RestrictionRepository.Upsert(ctx, &restriction, audit); err != nil {
+		return dto.ChatRestrictionDTO{}, err
+	}
+
+	// The ban entry already records the removal
+	if restrictionType == enums.BAN && isMember {
+		err = s.ChatMemberRepository.DeleteMember(ctx, target.ID, chatId, nil)
+		if err != nil && !errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatRestrictionDTO{}, err
+		}
+		publishEvent(ctx, s.App, dto.EventDTO{
+			Type:   enums.EventTypesToLabels[enums.MEMBER_LEFT],
+			ChatId: chatId,
+			UserId: target.ID,
+		})
+		if err == nil {
+			postSystemMessage(ctx, s.App, s.MessageRepository, caller, chatId,
+				fmt.Sprintf("%s banned %s", caller.Username, target.Username),
+				domain.SystemEvent{Action: enums.SYSTEM_MEMBER_BANNED, TargetId: target.ID, Target: target.Username},
+			)
+		}
+	}
+
+	restriction.User = target
+	restriction.CreatedBy.Username = caller.Username
+	return s.restrictionToDTO(restriction), nil
+}
+
+// LiftRestriction unbans or unmutes the user in the chat
+func (s *ChatMemberService) LiftRestriction(ctx context.Context, caller dto.UserDTO, chatId int64, targetUsername string, restrictionType byte) error {
+	if _, err := s.checkCanModerate(ctx, caller, chatId); err != nil {
+		return err
+	}
+
+	target, err := s.UserRepository.GetByUsername(ctx, targetUsername)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Target user not found"}
+		}
+		return err
+	}
+
+	err = s.RestrictionRepository.Lift(ctx, chatId, target.ID, restrictionType, &domain.ChatAuditEntry{
+		ChatID:   chatId,
+		ActorID:  caller.ID,
+		Action:   enums.AUDIT_RESTRICTION_LIFTED,
+		TargetID: &target.ID,
+		Before:   map[string]any{"type": enums.RestrictionTypesToLabels[int(restrictionType)]},
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: fmt.Sprintf("Target user has no %s", enums.RestrictionTypesToLabels[int(restrictionType)])}
+		}
+		return err
+	}
+	return nil
+}
+
+// GetRestrictions returns the bans or the mutes in force in the chat
+func (s *ChatMemberService) GetRestrictions(ctx context.Context, caller dto.UserDTO, chatId int64, restrictionType byte, page int) (dto.ChatRestrictionListResponse, error) {
+	if _, err := s.checkCanModerate(ctx, caller, chatId); err != nil {
+		return dto.ChatRestrictionListResponse{}, err
+	}
+	if page < 1 {
+		return dto.ChatRestrictionListResponse{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	}
+
+	limit := s.App.Config.Pagination.UsersInChatList
+	restrictions, err := s.RestrictionRepository.GetListForChat(ctx, chatId, restrictionType, limit, (page-1)*limit)
+	if err != nil {
+		return dto.ChatRestrictionListResponse{}, err
+	}
+
+	result := make([]dto.ChatRestrictionDTO, len(restrictions))
+	for i := range restrictions {
+		result[i] = s.restrictionToDTO(restrictions[i])
+	}
+	return dto.ChatRestrictionListResponse{Restrictions: result}, nil
+}
+
+// checkCanModerate allows members of group chats who can kick to ban and mute members
+func (s *ChatMemberService) checkCanModerate(ctx context.Context, caller dto.UserDTO, chatId int64) (dto.MemberInfo, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.MemberInfo{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to moderate a chat"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.MemberInfo{}, usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return dto.MemberInfo{}, err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return dto.MemberInfo{}, usecase_errors.BadRequestError{Msg: "Direct chats cannot be moderated"}
+	}
+	if err = authorize(callerInfo, enums.KICK_MEMBERS); err != nil {
+		return dto.MemberInfo{}, err
+	}
+	return callerInfo, nil
+}
+
+func (s *ChatMemberService) restrictionToDTO(restriction domain.ChatRestriction) dto.ChatRestrictionDTO {
+	result := restriction.ToDTO()
+	result.Avatar = avatarURL(s.App, result.Avatar, enums.AVATAR_SMALL)
+	return result
+}
+
+// GetRolePermissions returns the permission set of every role in the chat
+func (s *ChatMemberService) GetRolePermissions(ctx context.Context, caller dto.UserDTO, chatId int64) (dto.RolePermissionsListResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.RolePermissionsListResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to see the permissions"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.RolePermissionsListResponse{}, usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return dto.RolePermissionsListResponse{}, err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return dto.RolePermissionsListResponse{}, usecase_errors.BadRequestError{Msg: "Direct chats have no roles"}
+	}
+
+	customised, err := s.RolePermissionRepository.GetForChat(ctx, chatId)
+	if err != nil {
+		return dto.RolePermissionsListResponse{}, err
+	}
+	overrides := make(map[byte]int, len(customised))
+	for _, permission := range customised {
+		overrides[permission.Role] = permission.Permissions
+	}
+
+	roles := make([]dto.RolePermissionsDTO, 0, len(enums.ChatRolesToLabels))
+	for _, role := range []byte{enums.MEMBER, enums.CHAT_ADMIN, enums.OWNER} {
+		info := dto.MemberInfo{MemberRole: role}
+		permissions, ok := overrides[role]
+		if ok {
+			info.Permissions = &permissions
+		}
+		roles = append(roles, dto.RolePermissionsDTO{
+			Role:        enums.ChatRolesToLabels[int(role)],
+			Permissions: permissionLabels(rolePermissions(info)),
+			IsDefault:   !ok || role == enums.OWNER,
+		})
+	}
+	return dto.RolePermissionsListResponse{Roles: roles}, nil
+}
+
+// ChangeRolePermissions replaces the permission set of a role in the chat. The
+// owner's permissions cannot be changed.
+func (s *ChatMemberService) ChangeRolePermissions(ctx context.Context, caller dto.UserDTO, chatId int64, roleLabel string, request dto.ChangeRolePermissionsRequest) error {
+	role, err := s.checkCanCustomise(ctx, caller, chatId, roleLabel)
+	if err != nil {
+		return err
+	}
+	permissions, err := parsePermissions(request.Permissions)
+	if err != nil {
+		return err
+	}
+	previous, err := s.currentRolePermissions(ctx, chatId, role)
+	if err != nil {
+		return err
+	}
+
+	return s.RolePermissionRepository.Upsert(ctx, &domain.ChatRolePermission{
+		ChatID:      chatId,
+		Role:        role,
+		Permissions: permissions,
+	}, s.permissionsAudit(caller, chatId, role, previous, permissions))
+}
+
+// ResetRolePermissions brings a role of the chat back to its default
+// permissions
+func (s *ChatMemberService) ResetRolePermissions(ctx context.Context, caller dto.UserDTO, chatId int64, roleLabel string) error {
+	role, err := s.checkCanCustomise(ctx, caller, chatId, roleLabel)
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.currentRolePermissions(ctx, chatId, role)
+	if err != nil {
+		return err
+	}
+
+	defaults := rolePermissions(dto.MemberInfo{MemberRole: role})
+	err = s.RolePermissionRepository.Reset(ctx, chatId, role, s.permissionsAudit(caller, chatId, role, previous, defaults))
+	if err != nil && !errors.Is(err, repositories.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// currentRolePermissions returns the permissions the role has in the chat,
+// customised or default
+func (s *ChatMemberService) currentRolePermissions(ctx context.Context, chatId int64, role byte) (int, error) {
+	customised, err := s.RolePermissionRepository.GetForChat(ctx, chatId)
+	if err != nil {
+		return 0, err
+	}
+	info := dto.MemberInfo{MemberRole: role}
+	for _, permission := range customised {
+		if permission.Role == role {
+			info.Permissions = &permission.Permissions
+		}
+	}
+	return rolePermissions(info), nil
+}
+
+func (s *ChatMemberService) permissionsAudit(caller dto.UserDTO, chatId int64, role byte, before, after int) *domain.ChatAuditEntry {
+	return &domain.ChatAuditEntry{
+		ChatID:  chatId,
+		ActorID: caller.ID,
+		Action:  enums.AUDIT_PERMISSIONS_CHANGED,
+		Before:  map[string]any{"role": enums.ChatRolesToLabels[int(role)], "permissions": before},
+		After:   map[string]any{"role": enums.ChatRolesToLabels[int(role)], "permissions": after},
+	}
+}
+
+// checkCanCustomise allows the owner of a group chat to customise the
+// permissions of the roles below them
+func (s *ChatMemberService) checkCanCustomise(ctx context.Context, caller dto.UserDTO, chatId int64, roleLabel string) (byte, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return 0, usecase_errors.UnauthorizedError{Msg: "You must be logged in to change the permissions"}
+	}
+
+	role, ok := enums.ChatLabelsToRoles[strings.ToLower(roleLabel)]
+	if !ok {
+		return 0, usecase_errors.BadRequestError{Msg: "Invalid role"}
+	}
+	if role == enums.OWNER {
+		return 0, usecase_errors.BadRequestError{Msg: "The owner always has every permission"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return 0, usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return 0, err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return 0, usecase_errors.BadRequestError{Msg: "Direct chats have no roles"}
+	}
+	if callerInfo.MemberRole < enums.OWNER {
+		return 0, usecase_errors.PermissionError{Msg: "Only the owner can change the permissions"}
+	}
+	return byte(role), nil
+}
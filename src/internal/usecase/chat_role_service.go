@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"libs/src/internal/domain/enums"
+	domain "libs/src/internal/domain/models"
+	"libs/src/internal/dto"
+	"libs/src/internal/repositories"
+	usecase_errors "libs/src/internal/usecase/errors"
+	"libs/src/settings"
+	"strings"
+)
+
+type ChatRoleService struct {
+	App                  *settings.App
+	ChatRoleRepository   repositories.IChatRoleRepository
+	ChatMemberRepository repositories.IChatMemberRepository
+}
+
+func NewChatRoleService(app *settings.App) *ChatRoleService {
+	return &ChatRoleService{
+		App:                  app,
+		ChatRoleRepository:   repositories.NewChatRoleRepository(app),
+		ChatMemberRepository: repositories.NewChatMemberRepository(app),
+	}
+}
+
+// GetRoles returns the custom roles of the chat to its members
+func (s *ChatRoleService) GetRoles(ctx context.Context, caller dto.UserDTO, chatId int64) (dto.ChatRoleListResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.ChatRoleListResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to see the roles"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatRoleListResponse{}, usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return dto.ChatRoleListResponse{}, err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return dto.ChatRoleListResponse{}, usecase_errors.BadRequestError{Msg: "Direct chats have no roles"}
+	}
+
+	roles, err := s.ChatRoleRepository.GetForChat(ctx, chatId)
+	if err != nil {
+		return dto.ChatRoleListResponse{}, err
+	}
+
+	result := make([]dto.ChatRoleDTO, len(roles))
+	for i := range roles {
+		result[i] = s.roleToDTO(roles[i])
+	}
+	return dto.ChatRoleListResponse{Roles: result}, nil
+}
+
+func (s *ChatRoleService) CreateRole(ctx context.Context, caller dto.UserDTO, chatId int64, request dto.CreateChatRoleRequest) (dto.ChatRoleDTO, error) {
+	if err := s.checkIsOwner(ctx, caller, chatId); err != nil {
+		return dto.ChatRoleDTO{}, err
+	}
+
+	name, err := s.parseName(request.Name)
+	if err != nil {
+		return dto.ChatRoleDTO{}, err
+	}
+	permissions, err := parsePermissions(request.Permissions)
+	if err != nil {
+		return dto.ChatRoleDTO{}, err
+	}
+
+	role := domain.ChatRole{
+		ChatID:      chatId,
+		Name:        name,
+		Rank:        request.Rank,
+		Permissions: permissions,
+	}
+	err = s.ChatRoleRepository.CreateRole(ctx, &role, &domain.ChatAuditEntry{
+		ChatID:  chatId,
+		ActorID: caller.ID,
+		Action:  enums.AUDIT_ROLE_CREATED,
+		After:   roleAudit(role),
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return dto.ChatRoleDTO{}, usecase_errors.AlreadyExistsError{Msg: "Role with this name already exists"}
+		}
+		return dto.ChatRoleDTO{}, err
+	}
+	return s.roleToDTO(role), nil
+}
+
+func (s *ChatRoleService) ChangeRole(ctx context.Context, caller dto.UserDTO, chatId int64, roleId int64, request dto.ChangeChatRoleRequest) (dto.ChatRoleDTO, error) {
+	if err := s.checkIsOwner(ctx, caller, chatId); err != nil {
+		return dto.ChatRoleDTO{}, err
+	}
+
+	role, err := s.getRole(ctx, chatId, roleId)
+	if err != nil {
+		return dto.ChatRoleDTO{}, err
+	}
+	before := roleAudit(role)
+
+	updateData := map[string]any{}
+	if request.Name != nil {
+		if role.Name, err = s.parseName(*request.Name); err != nil {
+			return dto.ChatRoleDTO{}, err
+		}
+		updateData["name"] = role.Name
+	}
+	if request.Rank != nil {
+		role.Rank = *request.Rank
+		updateData["rank"] = role.Rank
+	}
+	if request.Permissions != nil {
+		if role.Permissions, err = parsePermissions(request.Permissions); err != nil {
+			return dto.ChatRoleDTO{}, err
+		}
+		updateData["permissions"] = role.Permissions
+	}
+	if len(updateData) == 0 {
+		return s.roleToDTO(role), nil
+	}
+
+	err = s.ChatRoleRepository.UpdateRole(ctx, role.ID, updateData, &domain.ChatAuditEntry{
+		ChatID:  chatId,
+		ActorID: caller.ID,
+		Action:  enums.AUDIT_ROLE_EDITED,
+		Before:  before,
+		After:   roleAudit(role),
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return dto.ChatRoleDTO{}, usecase_errors.AlreadyExistsError{Msg: "Role with this name already exists"}
+		}
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatRoleDTO{}, usecase_errors.NotFoundError{Msg: "Role not found"}
+		}
+		return dto.ChatRoleDTO{}, err
+	}
+	return s.roleToDTO(role), nil
+}
+
+// DeleteRole removes the custom role, the members who had it become plain
+// members. The audit entry keeps what the role was, since the members lose
+// its permissions.
+func (s *ChatRoleService) DeleteRole(ctx context.Context, caller dto.UserDTO, chatId int64, roleId int64) error {
+	if err := s.checkIsOwner(ctx, caller, chatId); err != nil {
+		return err
+	}
+
+	role, err := s.getRole(ctx, chatId, roleId)
+	if err != nil {
+		return err
+	}
+
+	err = s.ChatRoleRepository.DeleteRole(ctx, role.ID, &domain.ChatAuditEntry{
+		ChatID:  chatId,
+		ActorID: caller.ID,
+		Action:  enums.AUDIT_ROLE_DELETED,
+		Before:  roleAudit(role),
+	})
+	if err != nil && !errors.Is(err, repositories.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// checkIsOwner allows the owner of a group chat to manage its roles
+func (s *ChatRoleService) checkIsOwner(ctx context.Context, caller dto.UserDTO, chatId int64) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to manage roles"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return usecase_errors.BadRequestError{Msg: "Direct chats have no roles"}
+	}
+	if callerInfo.MemberRole < enums.OWNER {
+		return usecase_errors.PermissionError{Msg: "Only the owner can manage roles"}
+	}
+	return nil
+}
+
+func (s *ChatRoleService) getRole(ctx context.Context, chatId, roleId int64) (domain.ChatRole, error) {
+	role, err := s.ChatRoleRepository.GetChatRole(ctx, chatId, roleId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return domain.ChatRole{}, usecase_errors.NotFoundError{Msg: "Role not found"}
+		}
+		return domain.ChatRole{}, err
+	}
+	return role, nil
+}
+
+// parseName normalises the role name, roles are looked up by name when they
+// are given to members so names are case insensitive
+func (s *ChatRoleService) parseName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", usecase_errors.BadRequestError{Msg: "Role name is empty"}
+	}
+	if _, builtIn := enums.ChatLabelsToRoles[name]; builtIn {
+		return "", usecase_errors.BadRequestError{Msg: "Role name is reserved"}
+	}
+	return name, nil
+}
+
+// roleAudit is the state of the role kept in the audit log
+func roleAudit(role domain.ChatRole) map[string]any {
+	return map[string]any{"name": role.Name, "rank": role.Rank, "permissions": role.Permissions}
+}
+
+func (s *ChatRoleService) roleToDTO(role domain.ChatRole) dto.ChatRoleDTO {
+	return dto.ChatRoleDTO{
+		ID:          role.ID,
+		Name:        role.Name,
+		Rank:        role.Rank,
+		Permissions: permissionLabels(role.Permissions),
+	}
+}
@@ -3,6 +3,9 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"libs/src/internal/domain/enums"
 	domain "libs/src/internal/domain/models"
 	"libs/src/internal/dto"
@@ -16,6 +19,9 @@ type ChatService struct {
 	UserRepository       repositories.IUserRepository
 	ChatRepository       repositories.IChatRepository
 	ChatMemberRepository repositories.IChatMemberRepository
+	MessageRepository    repositories.IMessageRepository
+	ChatAuditRepository  repositories.IChatAuditRepository
+	PinRepository        repositories.IChatPinRepository
 }
 
 func NewChatService(app *settings.App) *ChatService {
@@ -24,6 +30,9 @@ func NewChatService(app *settings.App) *ChatService {
 		UserRepository:       repositories.NewUserRepository(app),
 		ChatRepository:       repositories.NewChatRepository(app),
 		ChatMemberRepository: repositories.NewChatMemberRepository(app),
+		MessageRepository:    repositories.NewMessageRepository(app),
+		ChatAuditRepository:  repositories.NewChatAuditRepository(app),
+		PinRepository:        repositories.NewChatPinRepository(app),
 	}
 }
 
@@ -36,6 +45,8 @@ func (s *ChatService) CreateChat(ctx context.Context, request dto.CreateChatRequ
 		Title:       request.Title,
 		Description: request.Description,
 		OwnerID:     user.ID,
+		Type:        enums.GROUP,
+		Visibility:  byte(enums.ChatLabelsToVisibilities[request.Visibility]),
 	}
 
 	err := s.ChatRepository.Create(ctx, &newChat)
@@ -45,6 +56,12 @@ func (s *ChatService) CreateChat(ctx context.Context, request dto.CreateChatRequ
 		}
 		return dto.ChatDTO{}, err
 	}
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:   enums.EventTypesToLabels[enums.MEMBER_JOINED],
+		ChatId: newChat.ID,
+		UserId: user.ID,
+	})
 	return newChat.ToDTO(), nil
 }
 
@@ -55,22 +72,32 @@ func (s *ChatService) DeleteChat(ctx context.Context, caller dto.UserDTO, chatID
 
 	chat, err := s.ChatRepository.GetById(ctx, chatID)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.NotFoundError{Msg: "Chat with this ID not found"}
 		}
 		return err
 	}
 
-	if chat.OwnerID != caller.ID {
+	if chat.Type == enums.DIRECT || chat.OwnerID != caller.ID {
 		return usecase_errors.PermissionError{Msg: "You have no permission to delete this chat"}
 	}
-	err = s.ChatRepository.DeleteById(ctx, chatID)
+	err = s.ChatRepository.DeleteChat(ctx, chatID, &domain.ChatAuditEntry{
+		ChatID:  chatID,
+		ActorID: caller.ID,
+		Action:  enums.AUDIT_CHAT_DELETED,
+		Before:  map[string]any{"title": chat.Title},
+	})
 	if err != nil {
 		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.NotFoundError{Msg: "Chat with this ID not found"}
 		}
 		return err
 	}
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:   enums.EventTypesToLabels[enums.CHAT_DELETED],
+		ChatId: chatID,
+	})
 	return nil
 }
 
@@ -79,87 +106,169 @@ func (s *ChatService) ChangeChat(ctx context.Context, caller dto.UserDTO, chatId
 		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to change members"}
 	}
 
-	chat, err := s.ChatRepository.GetById(ctx, chatId)
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return usecase_errors.NotFoundError{Msg: "Chat with this ID not found"}
 		}
 		return err
 	}
-	if chat.OwnerID != caller.ID {
+	if callerInfo.ChatType == enums.DIRECT {
 		return usecase_errors.PermissionError{Msg: "You have no permission to change this chat"}
 	}
+	if err = authorize(callerInfo, enums.EDIT_CHAT); err != nil {
+		return err
+	}
+
+	chat, err := s.ChatRepository.GetById(ctx, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Chat with this ID not found"}
+		}
+		return err
+	}
 
 	filterData := map[string]*string{
 		"title":       request.NewTitle,
 		"description": request.NewDescription,
 	}
+	previous := map[string]string{
+		"title":       chat.Title,
+		"description": chat.Description,
+	}
 
 	updateData := make(map[string]any, len(filterData))
+	before := make(map[string]any, len(filterData)+1)
+	after := make(map[string]any, len(filterData)+1)
 
 	for k, v := range filterData {
 		if v != nil {
 			updateData[k] = v
+			before[k] = previous[k]
+			after[k] = *v
 		}
 	}
+	if request.NewVisibility != nil {
+		updateData["visibility"] = enums.ChatLabelsToVisibilities[*request.NewVisibility]
+		before["visibility"] = enums.ChatVisibilitiesToLabels[int(chat.Visibility)]
+		after["visibility"] = *request.NewVisibility
+	}
+	if len(updateData) == 0 {
+		return nil
+	}
 
-	err = s.ChatRepository.UpdateById(ctx, chatId, updateData)
+	err = s.ChatRepository.UpdateChat(ctx, chatId, updateData, &domain.ChatAuditEntry{
+		ChatID:  chatId,
+		ActorID: caller.ID,
+		Action:  enums.AUDIT_CHAT_EDITED,
+		Before:  before,
+		After:   after,
+	})
 	if err != nil {
-		if errors.As(err, &repositories.ErrDuplicate) {
+		if errors.Is(err, repositories.ErrDuplicate) {
 			return usecase_errors.AlreadyExistsError{Msg: "Chat with this name already exists"}
 		}
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Chat with this ID not found"}
+		}
+		return err
+	}
+
+	if request.NewTitle != nil && *request.NewTitle != chat.Title {
+		postSystemMessage(ctx, s.App, s.MessageRepository, caller, chatId,
+			fmt.Sprintf("%s changed the title to %s", caller.Username, *request.NewTitle),
+			domain.SystemEvent{Action: enums.SYSTEM_TITLE_CHANGED, Value: *request.NewTitle},
+		)
 	}
 	return nil
 }
 
-func (s *ChatService) GetListForUser(ctx context.Context, caller dto.UserDTO, page int) ([]dto.ChatDTO, error) {
-	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
-		return []dto.ChatDTO{}, nil
+// toListItems completes the chats of the caller with the number of messages
+// after the caller's read cursor, and direct chats with the other participant.
+func (s *ChatService) toListItems(ctx context.Context, caller dto.UserDTO, chats []domain.Chat) ([]dto.ChatListItemDTO, error) {
+	result := make([]dto.ChatListItemDTO, len(chats))
+	if len(chats) == 0 {
+		return result, nil
 	}
 
-	if page < 1 {
-		return []dto.ChatDTO{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	chatIds := make([]int64, len(chats))
+	directIds := make([]int64, 0)
+	for i, chat := range chats {
+		chatIds[i] = chat.ID
+		if chat.Type == enums.DIRECT {
+			directIds = append(directIds, chat.ID)
+		}
 	}
 
-	list, err := s.ChatRepository.GetListForUser(ctx, caller.ID, s.App.Config.Pagination.ChatList, (page-1)*s.App.Config.Pagination.ChatList)
+	members, err := s.ChatMemberRepository.Filter(ctx, "user_id = ? AND chat_id IN ?", caller.ID, chatIds)
 	if err != nil {
-		if errors.As(err, &repositories.ErrLimitMustBePositive) || errors.As(err, &repositories.ErrOffsetMustBePositive) {
-			return []dto.ChatDTO{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
-		}
-		return []dto.ChatDTO{}, err
+		return []dto.ChatListItemDTO{}, err
+	}
+
+	unread, err := s.MessageRepository.CountUnread(ctx, caller.ID, members)
+	if err != nil {
+		return []dto.ChatListItemDTO{}, err
 	}
 
-	result := make([]dto.ChatDTO, len(list))
+	peers := map[int64]dto.ChatPeerDTO{}
+	if len(directIds) > 0 {
+		peers, err = s.ChatMemberRepository.GetDirectPeers(ctx, caller.ID, directIds)
+		if err != nil {
+			return []dto.ChatListItemDTO{}, err
+		}
+	}
 
-	for i, v := range list {
-		result[i] = v.ToDTO()
+	for i, chat := range chats {
+		result[i] = dto.ChatListItemDTO{
+			ChatDTO:     chat.ToDTO(),
+			UnreadCount: unread[chat.ID],
+		}
+		if peer, ok := peers[chat.ID]; ok {
+			peer.Avatar = avatarURL(s.App, peer.Avatar, enums.AVATAR_SMALL)
+			result[i].Peer = &peer
+		}
 	}
 	return result, nil
 }
 
-func (s *ChatService) Search(ctx context.Context, caller dto.UserDTO, name string, page int) ([]dto.ChatDTO, error) {
+func (s *ChatService) GetListForUser(ctx context.Context, caller dto.UserDTO, page int) ([]dto.ChatListItemDTO, error) {
 	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
-		return []dto.ChatDTO{}, nil
+		return []dto.ChatListItemDTO{}, nil
 	}
 
 	if page < 1 {
-		return []dto.ChatDTO{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+		return []dto.ChatListItemDTO{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
 	}
 
-	list, err := s.ChatRepository.SearchForUser(ctx, caller.ID, name, s.App.Config.Pagination.ChatList, (page-1)*s.App.Config.Pagination.ChatList)
+	list, err := s.ChatRepository.GetListForUser(ctx, caller.ID, s.App.Config.Pagination.ChatList, (page-1)*s.App.Config.Pagination.ChatList)
 	if err != nil {
-		if errors.As(err, &repositories.ErrLimitMustBePositive) || errors.As(err, &repositories.ErrOffsetMustBePositive) {
-			return []dto.ChatDTO{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+		if errors.Is(err, repositories.ErrLimitMustBePositive) || errors.Is(err, repositories.ErrOffsetMustBePositive) {
+			return []dto.ChatListItemDTO{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
 		}
-		return []dto.ChatDTO{}, err
+		return []dto.ChatListItemDTO{}, err
 	}
 
-	result := make([]dto.ChatDTO, len(list))
+	return s.toListItems(ctx, caller, list)
+}
 
-	for i, v := range list {
-		result[i] = v.ToDTO()
+func (s *ChatService) Search(ctx context.Context, caller dto.UserDTO, name string, page int) ([]dto.ChatListItemDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return []dto.ChatListItemDTO{}, nil
 	}
-	return result, nil
+
+	if page < 1 {
+		return []dto.ChatListItemDTO{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	}
+
+	list, err := s.ChatRepository.SearchForUser(ctx, caller.ID, name, s.App.Config.Pagination.ChatList, (page-1)*s.App.Config.Pagination.ChatList)
+	if err != nil {
+		if errors.Is(err, repositories.ErrLimitMustBePositive) || errors.Is(err, repositories.ErrOffsetMustBePositive) {
+			return []dto.ChatListItemDTO{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+		}
+		return []dto.ChatListItemDTO{}, err
+	}
+
+	return s.toListItems(ctx, caller, list)
 }
 
 func (s *ChatService) GetById(ctx context.Context, caller dto.UserDTO, chatId int64) (dto.ChatDTO, error) {
@@ -177,10 +286,202 @@ func (s *ChatService) GetById(ctx context.Context, caller dto.UserDTO, chatId in
 
 	chat, err := s.ChatRepository.GetById(ctx, chatId)
 	if err != nil {
-		if errors.As(err, &repositories.ErrRecordNotFound) {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return dto.ChatDTO{}, usecase_errors.NotFoundError{Msg: "Chat with this ID not found"}
 		}
 		return dto.ChatDTO{}, err
 	}
-	return chat.ToDTO(), nil
+
+	chatDTO := chat.ToDTO()
+	chatDTO.PinnedMessages, err = s.pinnedMessages(ctx, caller.ID, chatId)
+	if err != nil {
+		return dto.ChatDTO{}, err
+	}
+	return chatDTO, nil
+}
+
+// pinnedMessages renders the pinned messages of the chat for the viewer, the
+// latest pinned first. Pins of messages that are gone are left out.
+func (s *ChatService) pinnedMessages(ctx context.Context, viewerId int64, chatId int64) ([]dto.PinnedMessageDTO, error) {
+	pins, err := s.PinRepository.GetForChat(ctx, chatId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.PinnedMessageDTO, 0, len(pins))
+	if len(pins) == 0 {
+		return result, nil
+	}
+
+	messageIds := make(bson.A, 0, len(pins))
+	for _, pin := range pins {
+		if id, err := primitive.ObjectIDFromHex(pin.MessageID); err == nil {
+			messageIds = append(messageIds, id)
+		}
+	}
+	messages, err := s.MessageRepository.GetAll(ctx, bson.M{"_id": bson.M{"$in": messageIds}, "chat_id": chatId}, 0, int64(len(messageIds)))
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[string]domain.Message, len(messages))
+	senderIds := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		byId[message.Id.Hex()] = message
+		senderIds = append(senderIds, message.SenderId)
+	}
+	usernames := make(map[int64]string, len(senderIds))
+	if len(senderIds) > 0 {
+		senders, err := s.UserRepository.Filter(ctx, "id IN ?", senderIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, sender := range senders {
+			usernames[sender.ID] = sender.Username
+		}
+	}
+
+	for _, pin := range pins {
+		message, ok := byId[pin.MessageID]
+		if !ok || message.IsDeleted {
+			continue
+		}
+		preview := message.ToPreview(usernames[message.SenderId])
+		preview.Reactions = message.ReactionsFor(viewerId)
+		result = append(result, dto.PinnedMessageDTO{
+			Message:  preview,
+			PinnedBy: pin.PinnedBy.Username,
+			PinnedAt: pin.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// OpenDirect returns the direct chat of the caller with the user, creating it
+// on the first call.
+func (s *ChatService) OpenDirect(ctx context.Context, caller dto.UserDTO, username string) (dto.ChatListItemDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.ChatListItemDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to start a conversation"}
+	}
+
+	peer, err := s.UserRepository.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatListItemDTO{}, usecase_errors.NotFoundError{Msg: "User not found"}
+		}
+		return dto.ChatListItemDTO{}, err
+	}
+	if peer.Role == enums.ANONYMOUS || !peer.IsActive {
+		return dto.ChatListItemDTO{}, usecase_errors.NotFoundError{Msg: "User not found"}
+	}
+	if peer.ID == caller.ID {
+		return dto.ChatListItemDTO{}, usecase_errors.BadRequestError{Msg: "You cannot start a conversation with yourself"}
+	}
+
+	directKey := domain.DirectChatKey(caller.ID, peer.ID)
+	chat, err := s.getDirect(ctx, directKey)
+	if err != nil && !errors.Is(err, repositories.ErrRecordNotFound) {
+		return dto.ChatListItemDTO{}, err
+	}
+
+	if errors.Is(err, repositories.ErrRecordNotFound) {
+		chat = domain.Chat{
+			OwnerID:   caller.ID,
+			Type:      enums.DIRECT,
+			DirectKey: &directKey,
+		}
+		err = s.ChatRepository.CreateDirect(ctx, &chat, peer.ID)
+		if errors.Is(err, repositories.ErrDuplicate) {
+			// Both users opened the conversation at the same time
+			chat, err = s.getDirect(ctx, directKey)
+		} else if err == nil {
+			for _, userId := range []int64{caller.ID, peer.ID} {
+				publishEvent(ctx, s.App, dto.EventDTO{
+					Type:   enums.EventTypesToLabels[enums.MEMBER_JOINED],
+					ChatId: chat.ID,
+					UserId: userId,
+				})
+			}
+		}
+		if err != nil {
+			return dto.ChatListItemDTO{}, err
+		}
+	}
+
+	items, err := s.toListItems(ctx, caller, []domain.Chat{chat})
+	if err != nil {
+		return dto.ChatListItemDTO{}, err
+	}
+	return items[0], nil
+}
+
+func (s *ChatService) getDirect(ctx context.Context, directKey string) (domain.Chat, error) {
+	chats, err := s.ChatRepository.Filter(ctx, "direct_key = ?", directKey)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if len(chats) == 0 {
+		return domain.Chat{}, repositories.ErrRecordNotFound
+	}
+	return chats[0], nil
+}
+
+// Discover lists the public chats anyone can join
+func (s *ChatService) Discover(ctx context.Context, caller dto.UserDTO, search string, page int) (dto.FilterChatsResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.FilterChatsResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to discover chats"}
+	}
+
+	if page < 1 {
+		return dto.FilterChatsResponse{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	}
+
+	limit := s.App.Config.Pagination.GlobalChatList
+	chats, err := s.ChatRepository.DiscoverPublic(ctx, search, limit, (page-1)*limit)
+	if err != nil {
+		if errors.Is(err, repositories.ErrLimitMustBePositive) || errors.Is(err, repositories.ErrOffsetMustBePositive) {
+			return dto.FilterChatsResponse{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+		}
+		return dto.FilterChatsResponse{}, err
+	}
+	if chats == nil {
+		chats = []dto.ChatPreview{}
+	}
+	return dto.FilterChatsResponse{Chats: chats}, nil
+}
+
+// GetAuditLog returns the moderation actions taken in the chat, newest first.
+// It is visible to the members ranked as admins or above.
+func (s *ChatService) GetAuditLog(ctx context.Context, caller dto.UserDTO, chatId int64, page int) (dto.ChatAuditLogResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.ChatAuditLogResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to read the audit log"}
+	}
+	if page < 1 {
+		return dto.ChatAuditLogResponse{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	}
+
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.ChatAuditLogResponse{}, usecase_errors.NotFoundError{Msg: "You are not a member of the chat"}
+		}
+		return dto.ChatAuditLogResponse{}, err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return dto.ChatAuditLogResponse{}, usecase_errors.BadRequestError{Msg: "Direct chats have no audit log"}
+	}
+	if roleRank(callerInfo) < enums.CHAT_ADMIN_RANK {
+		return dto.ChatAuditLogResponse{}, usecase_errors.PermissionError{Msg: "Only admins can read the audit log"}
+	}
+
+	limit := s.App.Config.Pagination.AuditLog
+	entries, err := s.ChatAuditRepository.GetForChat(ctx, chatId, limit, (page-1)*limit)
+	if err != nil {
+		return dto.ChatAuditLogResponse{}, err
+	}
+
+	result := make([]dto.ChatAuditEntryDTO, len(entries))
+	for i := range entries {
+		result[i] = entries[i].ToDTO()
+	}
+	return dto.ChatAuditLogResponse{Entries: result}, nil
 }
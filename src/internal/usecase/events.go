@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"libs/src/internal/dto"
+	"libs/src/settings"
+)
+
+// publishEvent notifies connected clients about a change that is already
+// persisted, so a broker failure is logged instead of failing the request.
+func publishEvent(ctx context.Context, app *settings.App, event dto.EventDTO) {
+	if err := app.Broker.Publish(ctx, event); err != nil {
+		app.Logger.Error(fmt.Sprintf("Error publishing event %s to chat %d: %v", event.Type, event.ChatId, err))
+	}
+}
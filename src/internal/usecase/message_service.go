@@ -2,59 +2,1120 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"libs/src/internal/domain/enums"
 	domain "libs/src/internal/domain/models"
 	"libs/src/internal/dto"
 	"libs/src/internal/repositories"
+	"libs/src/internal/storage"
 	usecase_errors "libs/src/internal/usecase/errors"
+	"libs/src/pkg/utils"
 	"libs/src/settings"
+	"mime/multipart"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type MessageService struct {
-	App                  *settings.App
-	MessageRepository    repositories.IMessageRepository
-	ChatRepository       repositories.IChatRepository
-	ChatMemberRepository repositories.IChatMemberRepository
+	App                   *settings.App
+	MessageRepository     repositories.IMessageRepository
+	UserRepository        repositories.IUserRepository
+	ChatRepository        repositories.IChatRepository
+	ChatMemberRepository  repositories.IChatMemberRepository
+	RestrictionRepository repositories.IChatRestrictionRepository
+	PinRepository         repositories.IChatPinRepository
+	MentionRepository     repositories.IMentionRepository
 }
 
 func NewMessageService(app *settings.App) *MessageService {
 	return &MessageService{
-		App:                  app,
-		MessageRepository:    repositories.NewMessageRepository(app),
-		ChatRepository:       repositories.NewChatRepository(app),
-		ChatMemberRepository: repositories.NewChatMemberRepository(app),
+		App:                   app,
+		MessageRepository:     repositories.NewMessageRepository(app),
+		UserRepository:        repositories.NewUserRepository(app),
+		ChatRepository:        repositories.NewChatRepository(app),
+		ChatMemberRepository:  repositories.NewChatMemberRepository(app),
+		RestrictionRepository: repositories.NewChatRestrictionRepository(app),
+		PinRepository:         repositories.NewChatPinRepository(app),
+		MentionRepository:     repositories.NewMentionRepository(app),
 	}
 }
 
+// toPreviews renders the messages for the viewer, resolving the usernames they refer to.
+func (s *MessageService) toPreviews(ctx context.Context, viewerId int64, messages []domain.Message) ([]dto.MessagePreviewDTO, error) {
+	previews := make([]dto.MessagePreviewDTO, len(messages))
+	if len(messages) == 0 {
+		return previews, nil
+	}
+
+	userIds := make([]int64, 0, len(messages))
+	seen := make(map[int64]bool, len(messages))
+	for _, message := range messages {
+		for _, userId := range []int64{message.SenderId, message.LastReplyBy} {
+			if userId != 0 && !seen[userId] {
+				seen[userId] = true
+				userIds = append(userIds, userId)
+			}
+		}
+	}
+
+	users, err := s.UserRepository.Filter(ctx, "id IN ?", userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make(map[int64]string, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+
+	for i, message := range messages {
+		previews[i] = message.ToPreview(usernames[message.SenderId])
+		previews[i].LastReplyBy = usernames[message.LastReplyBy]
+		previews[i].Reactions = message.ReactionsFor(viewerId)
+	}
+	return previews, nil
+}
+
+// olderPage requests one message more than needed to find out whether the
+// history goes on past the page.
+func (s *MessageService) olderPage(ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, size int) ([]domain.Message, bool, error) {
+	messages, err := s.MessageRepository.GetOlder(ctx, chatId, threadId, createdAt, id, int64(size+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if len(messages) > size {
+		return messages[1:], true, nil
+	}
+	return messages, false, nil
+}
+
+func (s *MessageService) newerPage(ctx context.Context, chatId int64, threadId primitive.ObjectID, createdAt time.Time, id primitive.ObjectID, size int, inclusive bool) ([]domain.Message, bool, error) {
+	messages, err := s.MessageRepository.GetNewer(ctx, chatId, threadId, createdAt, id, int64(size+1), inclusive)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(messages) > size {
+		return messages[:size], true, nil
+	}
+	return messages, false, nil
+}
+
 func (s *MessageService) SendMessage(ctx context.Context, sender dto.UserDTO, messageRequest dto.SendMessageRequest, chatId int64) (*dto.MessagePreviewDTO, error) {
 	if sender.Role == enums.ANONYMOUS || !sender.IsActive {
 		return &dto.MessagePreviewDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to send a message"}
 	}
 
-	members, err := s.ChatMemberRepository.Filter(ctx, "chat_id = ? AND user_id = ?", chatId, sender.ID)
+	senderInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, sender.ID, chatId)
 	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return &dto.MessagePreviewDTO{}, usecase_errors.BadRequestError{Msg: "You are not a member of this chat"}
+		}
 		return &dto.MessagePreviewDTO{}, err
 	}
-	if len(members) != 1 {
-		return &dto.MessagePreviewDTO{}, usecase_errors.BadRequestError{Msg: "You are not a member of this chat"}
+	if err = authorize(senderInfo, enums.SEND_MESSAGES); err != nil {
+		return &dto.MessagePreviewDTO{}, err
+	}
+
+	if err = s.checkNotMuted(ctx, chatId, sender.ID); err != nil {
+		return &dto.MessagePreviewDTO{}, err
 	}
 
-	if members[0].MemberRole < enums.MEMBER {
-		return &dto.MessagePreviewDTO{}, usecase_errors.BadRequestError{Msg: "You cannot send a message to this chat"}
+	if len(messageRequest.Attachments) > s.App.Config.MessagesConfig.MaxAttachments {
+		return &dto.MessagePreviewDTO{}, usecase_errors.BadRequestError{Msg: fmt.Sprintf("A message can have at most %d attachments", s.App.Config.MessagesConfig.MaxAttachments)}
+	}
+	for _, attachment := range messageRequest.Attachments {
+		if attachment.Size > s.App.Config.MessagesConfig.MaxAttachmentSize {
+			return &dto.MessagePreviewDTO{}, usecase_errors.BadRequestError{Msg: fmt.Sprintf("Attachment %s is too large", attachment.Filename)}
+		}
 	}
 
 	message := domain.NewMessageObject(sender.ID, chatId, messageRequest.Message)
+
+	var mentioned []int64
+	message.Mentions, mentioned, err = s.resolveMentions(ctx, sender.ID, senderInfo, chatId, messageRequest.Message)
+	if err != nil {
+		return &dto.MessagePreviewDTO{}, err
+	}
+
+	if messageRequest.ReplyTo != "" {
+		parent, err := s.MessageRepository.GetChatMessage(ctx, chatId, messageRequest.ReplyTo)
+		if err != nil {
+			if errors.Is(err, repositories.ErrRecordNotFound) {
+				return &dto.MessagePreviewDTO{}, usecase_errors.NotFoundError{Msg: "Message to reply to not found"}
+			}
+			return &dto.MessagePreviewDTO{}, err
+		}
+		if parent.IsDeleted {
+			return &dto.MessagePreviewDTO{}, usecase_errors.NotFoundError{Msg: "Message to reply to not found"}
+		}
+
+		// A reply to a reply goes to the same thread
+		message.ReplyTo = &parent.Id
+		if parent.ReplyTo != nil {
+			message.ReplyTo = parent.ReplyTo
+		}
+	}
+
+	message.Attachments, err = s.saveAttachments(ctx, chatId, messageRequest.Attachments)
+	if err != nil {
+		return &dto.MessagePreviewDTO{}, err
+	}
+
 	err = s.MessageRepository.Create(ctx, message)
+	if err != nil {
+		s.deleteAttachments(ctx, message.Attachments)
+		return &dto.MessagePreviewDTO{}, err
+	}
+
+	if message.ReplyTo != nil {
+		// The reply is already saved, a stale summary of the thread shouldn't fail it
+		err = s.MessageRepository.AddReply(ctx, *message.ReplyTo, message.CreatedAt, sender.ID)
+		if err != nil {
+			s.App.Logger.Error(fmt.Sprintf("Error updating the thread of message %s: %v", message.ReplyTo.Hex(), err))
+		}
+	}
+
+	messagePreview := message.ToPreview(sender.Username)
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:    enums.EventTypesToLabels[enums.MESSAGE_CREATED],
+		ChatId:  chatId,
+		Payload: messagePreview,
+	})
+	s.notifyMentioned(ctx, message, messagePreview, mentioned)
+
+	return &messagePreview, nil
+}
+
+func (s *MessageService) GetHistory(ctx context.Context, caller dto.UserDTO, chatId int64, cursor string, mode string, limit int) (dto.MessageHistoryResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.MessageHistoryResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to read messages"}
+	}
+
+	request, err := s.parsePageRequest(cursor, mode, limit)
+	if err != nil {
+		return dto.MessageHistoryResponse{}, err
+	}
+
+	members, err := s.ChatMemberRepository.Filter(ctx, "chat_id = ? AND user_id = ?", chatId, caller.ID)
+	if err != nil {
+		return dto.MessageHistoryResponse{}, err
+	}
+	if len(members) != 1 {
+		return dto.MessageHistoryResponse{}, usecase_errors.BadRequestError{Msg: "You are not a member of this chat"}
+	}
+
+	return s.streamPage(ctx, caller.ID, chatId, primitive.NilObjectID, request)
+}
+
+// GetThread returns the root message of a thread with a page of its replies,
+// paginated the same way as the chat history.
+func (s *MessageService) GetThread(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string, cursor string, mode string, limit int) (dto.MessageThreadResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.MessageThreadResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to read messages"}
+	}
+
+	request, err := s.parsePageRequest(cursor, mode, limit)
+	if err != nil {
+		return dto.MessageThreadResponse{}, err
+	}
+
+	parent, _, err := s.getChatMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return dto.MessageThreadResponse{}, err
+	}
+	if parent.ReplyTo != nil {
+		return dto.MessageThreadResponse{}, usecase_errors.BadRequestError{Msg: "The message is a reply, open the thread of its parent"}
+	}
+
+	parentPreview, err := s.toPreviews(ctx, caller.ID, []domain.Message{parent})
+	if err != nil {
+		return dto.MessageThreadResponse{}, err
+	}
+
+	page, err := s.streamPage(ctx, caller.ID, chatId, parent.Id, request)
+	if err != nil {
+		return dto.MessageThreadResponse{}, err
+	}
+	return dto.MessageThreadResponse{Parent: parentPreview[0], MessageHistoryResponse: page}, nil
+}
+
+// pageRequest is a validated position in a stream of messages
+type pageRequest struct {
+	cursor    string
+	mode      int
+	size      int
+	createdAt time.Time
+	anchorId  primitive.ObjectID
+}
+
+func (s *MessageService) parsePageRequest(cursor string, mode string, limit int) (pageRequest, error) {
+	cursorMode, ex := enums.CursorLabelsToModes[strings.ToLower(mode)]
+	if !ex {
+		return pageRequest{}, usecase_errors.BadRequestError{Msg: "Invalid mode"}
+	}
+
+	request := pageRequest{cursor: cursor, mode: cursorMode, size: s.App.Config.Pagination.MessagesList}
+	if limit > 0 && limit < request.size {
+		request.size = limit
+	}
+
+	if cursor != "" {
+		var err error
+		request.createdAt, request.anchorId, err = utils.DecodeCursor(cursor)
+		if err != nil {
+			return pageRequest{}, usecase_errors.BadRequestError{Msg: "Invalid cursor"}
+		}
+	} else if cursorMode != enums.BEFORE {
+		return pageRequest{}, usecase_errors.BadRequestError{Msg: "Cursor is required for this mode"}
+	}
+	return request, nil
+}
+
+// streamPage loads a page of the chat history, or of a thread when threadId is set.
+func (s *MessageService) streamPage(ctx context.Context, viewerId int64, chatId int64, threadId primitive.ObjectID, request pageRequest) (dto.MessageHistoryResponse, error) {
+	var older, newer []domain.Message
+	var hasOlder, hasNewer bool
+	var err error
+
+	switch request.mode {
+	case enums.BEFORE:
+		older, hasOlder, err = s.olderPage(ctx, chatId, threadId, request.createdAt, request.anchorId, request.size)
+		hasNewer = request.cursor != ""
+	case enums.AFTER:
+		newer, hasNewer, err = s.newerPage(ctx, chatId, threadId, request.createdAt, request.anchorId, request.size, false)
+		hasOlder = true
+	case enums.AROUND:
+		half := request.size / 2
+		older, hasOlder, err = s.olderPage(ctx, chatId, threadId, request.createdAt, request.anchorId, half)
+		if err == nil {
+			newer, hasNewer, err = s.newerPage(ctx, chatId, threadId, request.createdAt, request.anchorId, request.size-half, true)
+		}
+	}
+	if err != nil {
+		return dto.MessageHistoryResponse{}, err
+	}
+
+	messages := append(older, newer...)
+
+	previews, err := s.toPreviews(ctx, viewerId, messages)
+	if err != nil {
+		return dto.MessageHistoryResponse{}, err
+	}
+
+	response := dto.MessageHistoryResponse{Messages: previews}
+	if len(messages) == 0 {
+		// Nothing on that side of the position yet, keep pointing at it
+		if hasOlder {
+			response.OlderCursor = request.cursor
+		}
+		if hasNewer {
+			response.NewerCursor = request.cursor
+		}
+		return response, nil
+	}
+
+	if hasOlder {
+		response.OlderCursor = utils.EncodeCursor(messages[0].CreatedAt, messages[0].Id)
+	}
+	if hasNewer {
+		last := messages[len(messages)-1]
+		response.NewerCursor = utils.EncodeCursor(last.CreatedAt, last.Id)
+	}
+	return response, nil
+}
+
+// saveAttachments stores the uploads under the chat prefix of the storage,
+// nothing is left behind if one of them fails.
+func (s *MessageService) saveAttachments(ctx context.Context, chatId int64, files []*multipart.FileHeader) ([]domain.MessageAttachment, error) {
+	attachments := make([]domain.MessageAttachment, 0, len(files))
+	for _, file := range files {
+		mimeType, err := utils.DetectContentType(file)
+		if err != nil {
+			s.deleteAttachments(ctx, attachments)
+			return nil, err
+		}
+
+		id := uuid.New().String()
+		attachment := domain.MessageAttachment{
+			Id:           id,
+			Key:          path.Join("attachments", strconv.FormatInt(chatId, 10), id),
+			OriginalName: filepath.Base(file.Filename),
+			MimeType:     mimeType,
+			Size:         file.Size,
+		}
+		if err := storage.SaveFile(ctx, s.App.Storage, attachment.Key, file, mimeType); err != nil {
+			s.deleteAttachments(ctx, attachments)
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+func (s *MessageService) deleteAttachments(ctx context.Context, attachments []domain.MessageAttachment) {
+	for _, attachment := range attachments {
+		if err := s.App.Storage.Delete(ctx, attachment.Key); err != nil {
+			s.App.Logger.Warn(fmt.Sprintf("Failed to delete attachment %s: %v", attachment.Key, err))
+		}
+	}
+}
+
+// getChatMessage loads a message of the chat after checking that the caller is
+// still a member of it.
+func (s *MessageService) getChatMessage(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string) (domain.Message, dto.MemberInfo, error) {
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return domain.Message{}, dto.MemberInfo{}, usecase_errors.BadRequestError{Msg: "You are not a member of this chat"}
+		}
+		return domain.Message{}, dto.MemberInfo{}, err
+	}
+
+	message, err := s.MessageRepository.GetChatMessage(ctx, chatId, messageId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return domain.Message{}, dto.MemberInfo{}, usecase_errors.NotFoundError{Msg: "Message not found"}
+		}
+		return domain.Message{}, dto.MemberInfo{}, err
+	}
+	return message, callerInfo, nil
+}
+
+func (s *MessageService) EditMessage(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string, editRequest dto.EditMessageRequest) (*dto.MessagePreviewDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return &dto.MessagePreviewDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to edit a message"}
+	}
+
+	if strings.TrimSpace(editRequest.Message) == "" {
+		return &dto.MessagePreviewDTO{}, usecase_errors.BadRequestError{Msg: "Message cannot be empty"}
+	}
+
+	message, callerInfo, err := s.getChatMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return &dto.MessagePreviewDTO{}, err
+	}
+
+	if message.SenderId != caller.ID {
+		return &dto.MessagePreviewDTO{}, usecase_errors.PermissionError{Msg: "You can edit only your own messages"}
+	}
+	if message.Type == enums.SYSTEM_MESSAGE {
+		return &dto.MessagePreviewDTO{}, usecase_errors.BadRequestError{Msg: "System messages cannot be edited"}
+	}
+	if message.IsDeleted {
+		return &dto.MessagePreviewDTO{}, usecase_errors.NotFoundError{Msg: "Message not found"}
+	}
+	if err = s.checkNotMuted(ctx, chatId, caller.ID); err != nil {
+		return &dto.MessagePreviewDTO{}, err
+	}
+
+	if message.Content == editRequest.Message {
+		messagePreview := message.ToPreview(caller.Username)
+		return &messagePreview, nil
+	}
+
+	mentions, mentioned, err := s.resolveMentions(ctx, caller.ID, callerInfo, chatId, editRequest.Message)
 	if err != nil {
 		return &dto.MessagePreviewDTO{}, err
 	}
 
-	messagePreview := &dto.MessagePreviewDTO{
-		Id:             message.Id.Hex(),
-		Content:        message.Content,
-		SenderUsername: sender.Username,
-		CreatedAt:      message.CreatedAt,
-		UpdatedAt:      message.UpdatedAt,
+	editedAt := time.Now()
+	err = s.MessageRepository.Edit(ctx, message.Id, editRequest.Message, mentions, editedAt)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return &dto.MessagePreviewDTO{}, usecase_errors.NotFoundError{Msg: "Message not found"}
+		}
+		return &dto.MessagePreviewDTO{}, err
+	}
+
+	// Only the members the edit mentions for the first time are notified
+	previous := message.Mentions
+	message.Content = editRequest.Message
+	message.Mentions = mentions
+	message.IsUpdated = true
+	message.UpdatedAt = editedAt
+	messagePreview := message.ToPreview(caller.Username)
+	messagePreview.Reactions = message.ReactionsFor(caller.ID)
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:    enums.EventTypesToLabels[enums.MESSAGE_UPDATED],
+		ChatId:  chatId,
+		Payload: messagePreview,
+	})
+	s.notifyMentioned(ctx, &message, messagePreview, newlyMentioned(previous, mentioned))
+
+	return &messagePreview, nil
+}
+
+// DeleteMessage is allowed to the sender and to members who can delete
+// messages. Deleting an already deleted message is a no-op.
+func (s *MessageService) DeleteMessage(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to delete a message"}
+	}
+
+	message, callerInfo, err := s.getChatMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return err
+	}
+
+	if message.SenderId != caller.ID {
+		if err = authorize(callerInfo, enums.DELETE_MESSAGES); err != nil {
+			return err
+		}
+	}
+	if message.IsDeleted {
+		return nil
+	}
+
+	err = s.MessageRepository.SoftDelete(ctx, message.Id, time.Now())
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// A tombstone has nothing left to show, so it doesn't stay pinned
+	err = s.PinRepository.Unpin(ctx, chatId, message.Id.Hex())
+	if err != nil && !errors.Is(err, repositories.ErrRecordNotFound) {
+		s.App.Logger.Error(fmt.Sprintf("Error unpinning deleted message %s: %v", message.Id.Hex(), err))
+	}
+	if err = s.MentionRepository.RemoveForMessage(ctx, message.Id.Hex()); err != nil {
+		s.App.Logger.Error(fmt.Sprintf("Error removing mentions of deleted message %s: %v", message.Id.Hex(), err))
+	}
+	if message.ReplyTo != nil {
+		if err = s.MessageRepository.RemoveReply(ctx, *message.ReplyTo); err != nil {
+			s.App.Logger.Error(fmt.Sprintf("Error updating the thread of message %s: %v", message.ReplyTo.Hex(), err))
+		}
+	}
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:    enums.EventTypesToLabels[enums.MESSAGE_DELETED],
+		ChatId:  chatId,
+		UserId:  caller.ID,
+		Payload: message.Id.Hex(),
+	})
+
+	return nil
+}
+
+// GetRevisions returns the previous contents of a message, oldest first. It is
+// meant for moderation, so only members who can delete messages can see it.
+func (s *MessageService) GetRevisions(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string) ([]dto.MessageRevisionDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return nil, usecase_errors.UnauthorizedError{Msg: "You must be logged in to read message revisions"}
+	}
+
+	message, callerInfo, err := s.getChatMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authorize(callerInfo, enums.DELETE_MESSAGES); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]dto.MessageRevisionDTO, len(message.Revisions))
+	for i, revision := range message.Revisions {
+		revisions[i] = revision.ToDTO()
+	}
+	return revisions, nil
+}
+
+// MarkRead moves the read cursor of the caller to the message. The cursor
+// never goes back, marking an older message is a no-op.
+func (s *MessageService) MarkRead(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to read messages"}
+	}
+
+	message, _, err := s.getChatMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return err
+	}
+	if message.ReplyTo != nil {
+		return usecase_errors.BadRequestError{Msg: "Thread replies cannot be marked as read"}
+	}
+
+	moved, err := s.ChatMemberRepository.AdvanceReadCursor(ctx, chatId, caller.ID, message.Id.Hex(), message.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if moved {
+		publishEvent(ctx, s.App, dto.EventDTO{
+			Type:    enums.EventTypesToLabels[enums.MESSAGES_READ],
+			ChatId:  chatId,
+			UserId:  caller.ID,
+			Payload: message.Id.Hex(),
+		})
+	}
+	return nil
+}
+
+// GetReaders returns the members who have read the message, the "seen by" list.
+func (s *MessageService) GetReaders(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string, page int) ([]dto.MessageReaderDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return nil, usecase_errors.UnauthorizedError{Msg: "You must be logged in to read messages"}
+	}
+
+	if page < 1 {
+		return nil, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	}
+
+	message, _, err := s.getChatMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := s.App.Config.Pagination.UsersInChatList
+	readers, err := s.ChatMemberRepository.GetReaders(ctx, chatId, message.SenderId, message.Id.Hex(), message.CreatedAt, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range readers {
+		readers[i].Avatar = avatarURL(s.App, readers[i].Avatar, enums.AVATAR_SMALL)
+	}
+	return readers, nil
+}
+
+// AddReaction reacts to the message with the emoji on behalf of the caller and
+// returns the updated reactions of the message.
+func (s *MessageService) AddReaction(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string, emoji string) ([]dto.ReactionDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return nil, usecase_errors.UnauthorizedError{Msg: "You must be logged in to react to a message"}
+	}
+
+	if !utils.IsEmoji(emoji) {
+		return nil, usecase_errors.BadRequestError{Msg: "Reaction must be a single emoji"}
+	}
+
+	message, err := s.getReactableMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkNotMuted(ctx, chatId, caller.ID); err != nil {
+		return nil, err
+	}
+
+	err = s.MessageRepository.AddReaction(ctx, message.Id, emoji, caller.ID, s.App.Config.MessagesConfig.MaxDistinctReactions)
+	if err != nil {
+		if errors.Is(err, repositories.ErrTooManyReactions) {
+			return nil, usecase_errors.BadRequestError{Msg: "The message has too many different reactions"}
+		}
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, usecase_errors.NotFoundError{Msg: "Message not found"}
+		}
+		return nil, err
+	}
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:    enums.EventTypesToLabels[enums.REACTION_ADDED],
+		ChatId:  chatId,
+		UserId:  caller.ID,
+		Payload: dto.ReactionEventDTO{MessageId: messageId, Emoji: emoji},
+	})
+
+	return s.reactionsOf(ctx, caller, chatId, messageId)
+}
+
+// RemoveReaction takes the reaction of the caller back, removing a reaction
+// the caller does not have is a no-op.
+func (s *MessageService) RemoveReaction(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string, emoji string) ([]dto.ReactionDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return nil, usecase_errors.UnauthorizedError{Msg: "You must be logged in to react to a message"}
+	}
+
+	message, err := s.getReactableMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.MessageRepository.RemoveReaction(ctx, message.Id, emoji, caller.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(ctx, s.App, dto.EventDTO{
+		Type:    enums.EventTypesToLabels[enums.REACTION_REMOVED],
+		ChatId:  chatId,
+		UserId:  caller.ID,
+		Payload: dto.ReactionEventDTO{MessageId: messageId, Emoji: emoji},
+	})
+
+	return s.reactionsOf(ctx, caller, chatId, messageId)
+}
+
+// getReactableMessage applies the same rules as SendMessage: the caller must be
+// a member allowed to write to the chat.
+func (s *MessageService) getReactableMessage(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string) (domain.Message, error) {
+	message, callerInfo, err := s.getChatMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return domain.Message{}, err
+	}
+	if err = authorize(callerInfo, enums.SEND_MESSAGES); err != nil {
+		return domain.Message{}, err
+	}
+	if message.IsDeleted {
+		return domain.Message{}, usecase_errors.NotFoundError{Msg: "Message not found"}
+	}
+	return message, nil
+}
+
+// checkNotMuted keeps muted members from writing to the chat in any way
+func (s *MessageService) checkNotMuted(ctx context.Context, chatId, userId int64) error {
+	mute, err := s.RestrictionRepository.GetActive(ctx, chatId, userId, enums.MUTE)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if mute.ExpiresAt != nil {
+		return usecase_errors.PermissionError{Msg: fmt.Sprintf("You are muted in this chat until %s", mute.ExpiresAt.Format(time.RFC3339))}
+	}
+	return usecase_errors.PermissionError{Msg: "You are muted in this chat"}
+}
+
+func (s *MessageService) reactionsOf(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string) ([]dto.ReactionDTO, error) {
+	message, err := s.MessageRepository.GetChatMessage(ctx, chatId, messageId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, usecase_errors.NotFoundError{Msg: "Message not found"}
+		}
+		return nil, err
+	}
+	return message.ReactionsFor(caller.ID), nil
+}
+
+// GetAttachmentURL gives a short-lived link to a file attached to a message,
+// only members of the chat can get it.
+func (s *MessageService) GetAttachmentURL(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string, attachmentId string) (string, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return "", usecase_errors.UnauthorizedError{Msg: "You must be logged in to download attachments"}
+	}
+
+	message, _, err := s.getChatMessage(ctx, caller, chatId, messageId)
+	if err != nil {
+		return "", err
+	}
+	if message.IsDeleted {
+		return "", usecase_errors.NotFoundError{Msg: "Message not found"}
+	}
+
+	for _, attachment := range message.Attachments {
+		if attachment.Id == attachmentId {
+			expires := time.Duration(s.App.Config.StorageConfig.SignedUrlTtlMs) * time.Millisecond
+			return s.App.Storage.SignedURL(ctx, attachment.Key, attachment.OriginalName, expires)
+		}
+	}
+	return "", usecase_errors.NotFoundError{Msg: "Attachment not found"}
+}
+
+// getPinnableChat checks that the caller may pin messages in the chat
+func (s *MessageService) getPinnableChat(ctx context.Context, caller dto.UserDTO, chatId int64) error {
+	callerInfo, err := s.ChatMemberRepository.GetMemberInfo(ctx, caller.ID, chatId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Chat with this ID not found"}
+		}
+		return err
+	}
+	if callerInfo.ChatType == enums.DIRECT {
+		return usecase_errors.PermissionError{Msg: "You have no permission to pin messages in this chat"}
+	}
+	return authorize(callerInfo, enums.PIN_MESSAGES)
+}
+
+// PinMessage pins a message of the chat, a chat has at most
+// MessagesConfig.MaxPinned pinned messages.
+func (s *MessageService) PinMessage(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to pin a message"}
+	}
+
+	if err := s.getPinnableChat(ctx, caller, chatId); err != nil {
+		return err
+	}
+
+	message, err := s.MessageRepository.GetChatMessage(ctx, chatId, messageId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Message not found"}
+		}
+		return err
+	}
+	if message.IsDeleted {
+		return usecase_errors.NotFoundError{Msg: "Message not found"}
+	}
+	if message.Type == enums.SYSTEM_MESSAGE {
+		return usecase_errors.BadRequestError{Msg: "System messages cannot be pinned"}
+	}
+
+	maxPinned := s.App.Config.MessagesConfig.MaxPinned
+	err = s.PinRepository.Pin(ctx, &domain.ChatPin{
+		ChatID:     chatId,
+		MessageID:  message.Id.Hex(),
+		PinnedByID: caller.ID,
+	}, maxPinned)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return usecase_errors.AlreadyExistsError{Msg: "Message is already pinned"}
+		}
+		if errors.Is(err, repositories.ErrTooManyPins) {
+			return usecase_errors.BadRequestError{Msg: fmt.Sprintf("A chat can have at most %d pinned messages", maxPinned)}
+		}
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Chat with this ID not found"}
+		}
+		return err
+	}
+
+	postSystemMessage(ctx, s.App, s.MessageRepository, caller, chatId,
+		fmt.Sprintf("%s pinned a message", caller.Username),
+		domain.SystemEvent{Action: enums.SYSTEM_MESSAGE_PINNED, MessageId: message.Id.Hex()},
+	)
+	return nil
+}
+
+func (s *MessageService) UnpinMessage(ctx context.Context, caller dto.UserDTO, chatId int64, messageId string) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to unpin a message"}
+	}
+
+	if err := s.getPinnableChat(ctx, caller, chatId); err != nil {
+		return err
+	}
+
+	err := s.PinRepository.Unpin(ctx, chatId, messageId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return usecase_errors.NotFoundError{Msg: "Message is not pinned"}
+		}
+		return err
+	}
+
+	postSystemMessage(ctx, s.App, s.MessageRepository, caller, chatId,
+		fmt.Sprintf("%s unpinned a message", caller.Username),
+		domain.SystemEvent{Action: enums.SYSTEM_MESSAGE_UNPINNED, MessageId: messageId},
+	)
+	return nil
+}
+
+// resolveMentions finds the @usernames of the content that belong to members
+// of the chat, other @words are left as plain text. It returns the mentions
+// to store on the message and the members to notify, the author excluded.
+// @all mentions every member and is reserved to admins.
+func (s *MessageService) resolveMentions(ctx context.Context, authorId int64, authorInfo dto.MemberInfo, chatId int64, content string) ([]domain.MessageMention, []int64, error) {
+	tokens := utils.ParseMentions(content)
+	if len(tokens) == 0 {
+		return nil, nil, nil
+	}
+
+	mentionsAll := false
+	usernames := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Username == utils.MentionAll {
+			mentionsAll = true
+		} else {
+			usernames = append(usernames, token.Username)
+		}
+	}
+	if mentionsAll && roleRank(authorInfo) < enums.CHAT_ADMIN_RANK {
+		return nil, nil, usecase_errors.PermissionError{Msg: "Only admins can mention everyone in the chat"}
+	}
+
+	userIds := make(map[string]int64, len(usernames))
+	if len(usernames) > 0 {
+		users, err := s.UserRepository.Filter(ctx, "username IN ? AND id IN (SELECT user_id FROM chat_members WHERE chat_id = ?)", usernames, chatId)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, user := range users {
+			userIds[user.Username] = user.ID
+		}
+	}
+
+	var mentions []domain.MessageMention
+	notified := make(map[int64]bool)
+	var mentioned []int64
+	notify := func(userId int64) {
+		if userId != authorId && !notified[userId] {
+			notified[userId] = true
+			mentioned = append(mentioned, userId)
+		}
+	}
+
+	for _, token := range tokens {
+		userId, found := userIds[token.Username]
+		if !found && token.Username != utils.MentionAll {
+			continue
+		}
+		mentions = append(mentions, domain.MessageMention{
+			UserId:   userId,
+			Username: token.Username,
+			Offset:   token.Offset,
+			Length:   token.Length,
+		})
+		if found {
+			notify(userId)
+		}
+	}
+
+	if mentionsAll {
+		members, err := s.ChatMemberRepository.Filter(ctx, "chat_id = ?", chatId)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, member := range members {
+			notify(member.UserID)
+		}
+	}
+	return mentions, mentioned, nil
+}
+
+// newlyMentioned leaves out the members the previous mentions already
+// notified, all of them if they included @all
+func newlyMentioned(previous []domain.MessageMention, mentioned []int64) []int64 {
+	notified := make(map[int64]bool, len(previous))
+	for _, mention := range previous {
+		if mention.Username == utils.MentionAll {
+			return nil
+		}
+		notified[mention.UserId] = true
+	}
+
+	var result []int64
+	for _, userId := range mentioned {
+		if !notified[userId] {
+			result = append(result, userId)
+		}
+	}
+	return result
+}
+
+// notifyMentioned puts the message in the mention inboxes of the members and
+// tells them. The message is already stored, so a failure is logged instead
+// of failing the request.
+func (s *MessageService) notifyMentioned(ctx context.Context, message *domain.Message, preview dto.MessagePreviewDTO, userIds []int64) {
+	if len(userIds) == 0 {
+		return
+	}
+
+	mentions := make([]domain.Mention, len(userIds))
+	for i, userId := range userIds {
+		mentions[i] = domain.Mention{
+			UserID:    userId,
+			ChatID:    message.ChatId,
+			MessageID: message.Id.Hex(),
+			SenderID:  message.SenderId,
+		}
+	}
+	if err := s.MentionRepository.AddMany(ctx, mentions); err != nil {
+		s.App.Logger.Error(fmt.Sprintf("Error adding mentions of message %s: %v", message.Id.Hex(), err))
+		return
+	}
+
+	for _, userId := range userIds {
+		publishEvent(ctx, s.App, dto.EventDTO{
+			Type:    enums.EventTypesToLabels[enums.MENTIONED],
+			ChatId:  message.ChatId,
+			UserId:  userId,
+			Payload: preview,
+		})
+	}
+}
+
+// GetMentions returns the mention inbox of the caller, newest first. Mentions
+// in chats the caller has left are not shown, neither are deleted messages.
+func (s *MessageService) GetMentions(ctx context.Context, caller dto.UserDTO, unreadOnly bool, page int) (dto.MentionListResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.MentionListResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to read your mentions"}
+	}
+	if page < 1 {
+		return dto.MentionListResponse{}, usecase_errors.BadRequestError{Msg: "Invalid page"}
+	}
+
+	limit := s.App.Config.Pagination.Mentions
+	mentions, err := s.MentionRepository.GetForUser(ctx, caller.ID, unreadOnly, limit, (page-1)*limit)
+	if err != nil {
+		return dto.MentionListResponse{}, err
+	}
+	unreadCount, err := s.MentionRepository.CountUnread(ctx, caller.ID)
+	if err != nil {
+		return dto.MentionListResponse{}, err
+	}
+
+	result := dto.MentionListResponse{Mentions: make([]dto.MentionDTO, 0, len(mentions)), UnreadCount: unreadCount}
+	if len(mentions) == 0 {
+		return result, nil
+	}
+
+	ids := make(bson.A, 0, len(mentions))
+	for _, mention := range mentions {
+		if id, err := primitive.ObjectIDFromHex(mention.MessageID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	messages, err := s.MessageRepository.GetAll(ctx, bson.M{"_id": bson.M{"$in": ids}}, 0, int64(len(ids)))
+	if err != nil {
+		return dto.MentionListResponse{}, err
+	}
+	previews, err := s.toPreviews(ctx, caller.ID, messages)
+	if err != nil {
+		return dto.MentionListResponse{}, err
+	}
+
+	byId := make(map[string]dto.MessagePreviewDTO, len(previews))
+	for i, message := range messages {
+		if !message.IsDeleted {
+			byId[message.Id.Hex()] = previews[i]
+		}
+	}
+	for _, mention := range mentions {
+		preview, found := byId[mention.MessageID]
+		if !found {
+			continue
+		}
+		result.Mentions = append(result.Mentions, dto.MentionDTO{
+			Id:        mention.ID,
+			ChatId:    mention.ChatID,
+			ChatTitle: mention.Chat.Title,
+			Message:   preview,
+			IsRead:    mention.IsRead,
+			CreatedAt: mention.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// MarkMentionsRead marks the given mentions of the caller as read, all of them
+// when no ids are given
+func (s *MessageService) MarkMentionsRead(ctx context.Context, caller dto.UserDTO, ids []int64) error {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return usecase_errors.UnauthorizedError{Msg: "You must be logged in to read your mentions"}
+	}
+
+	return s.MentionRepository.MarkRead(ctx, caller.ID, ids)
+}
+
+// SearchMessages looks for the words of the query in the messages of the chats
+// the caller is a member of, newest first. The cursor is the next_cursor of a
+// previous page.
+func (s *MessageService) SearchMessages(ctx context.Context, caller dto.UserDTO, request dto.SearchMessagesRequest) (dto.MessageSearchResponse, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.MessageSearchResponse{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to search messages"}
+	}
+
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
+		return dto.MessageSearchResponse{}, usecase_errors.BadRequestError{Msg: "Search query cannot be empty"}
+	}
+	if !request.From.IsZero() && !request.To.IsZero() && !request.From.Before(request.To) {
+		return dto.MessageSearchResponse{}, usecase_errors.BadRequestError{Msg: "The period must end after it starts"}
+	}
+
+	size := s.App.Config.Pagination.MessagesList
+	if request.Limit > 0 && request.Limit < size {
+		size = request.Limit
+	}
+
+	var createdAt time.Time
+	var anchorId primitive.ObjectID
+	if request.Cursor != "" {
+		var err error
+		createdAt, anchorId, err = utils.DecodeCursor(request.Cursor)
+		if err != nil {
+			return dto.MessageSearchResponse{}, usecase_errors.BadRequestError{Msg: "Invalid cursor"}
+		}
+	}
+
+	members, err := s.ChatMemberRepository.Filter(ctx, "user_id = ?", caller.ID)
+	if err != nil {
+		return dto.MessageSearchResponse{}, err
+	}
+	search := repositories.MessageSearch{Query: query, From: request.From, To: request.To}
+	for _, member := range members {
+		if request.ChatId == 0 || member.ChatID == request.ChatId {
+			search.ChatIds = append(search.ChatIds, member.ChatID)
+		}
+	}
+	if request.ChatId != 0 && len(search.ChatIds) == 0 {
+		return dto.MessageSearchResponse{}, usecase_errors.BadRequestError{Msg: "You are not a member of this chat"}
+	}
+
+	result := dto.MessageSearchResponse{Results: []dto.MessageSearchResultDTO{}}
+	if len(search.ChatIds) == 0 {
+		return result, nil
+	}
+
+	if request.Sender != "" {
+		sender, err := s.UserRepository.GetByUsername(ctx, request.Sender)
+		if err != nil {
+			if errors.Is(err, repositories.ErrRecordNotFound) {
+				return dto.MessageSearchResponse{}, usecase_errors.NotFoundError{Msg: "Sender not found"}
+			}
+			return dto.MessageSearchResponse{}, err
+		}
+		search.SenderId = sender.ID
+	}
+
+	messages, err := s.MessageRepository.Search(ctx, search, createdAt, anchorId, int64(size+1))
+	if err != nil {
+		return dto.MessageSearchResponse{}, err
+	}
+	if len(messages) > size {
+		messages = messages[:size]
+		last := messages[size-1]
+		result.NextCursor = utils.EncodeCursor(last.CreatedAt, last.Id)
+	}
+	if len(messages) == 0 {
+		return result, nil
+	}
+
+	previews, err := s.toPreviews(ctx, caller.ID, messages)
+	if err != nil {
+		return dto.MessageSearchResponse{}, err
+	}
+
+	chatIds := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		if !slices.Contains(chatIds, message.ChatId) {
+			chatIds = append(chatIds, message.ChatId)
+		}
+	}
+	chats, err := s.ChatRepository.Filter(ctx, "id IN ?", chatIds)
+	if err != nil {
+		return dto.MessageSearchResponse{}, err
+	}
+	titles := make(map[int64]string, len(chats))
+	for _, chat := range chats {
+		titles[chat.ID] = chat.Title
+	}
+
+	terms := utils.SearchTerms(query)
+	for i, message := range messages {
+		snippet, matches := utils.Snippet(message.Content, terms, s.App.Config.MessagesConfig.SnippetLength)
+		highlights := make([]dto.TextRangeDTO, len(matches))
+		for j, match := range matches {
+			highlights[j] = dto.TextRangeDTO{Offset: match.Offset, Length: match.Length}
+		}
+		result.Results = append(result.Results, dto.MessageSearchResultDTO{
+			ChatId:     message.ChatId,
+			ChatTitle:  titles[message.ChatId],
+			Message:    previews[i],
+			Snippet:    snippet,
+			Highlights: highlights,
+		})
 	}
-	return messagePreview, nil
+	return result, nil
 }
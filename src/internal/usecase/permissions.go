@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	usecase_errors "libs/src/internal/usecase/errors"
+)
+
+// rolePermissions returns the permission set of the member's role in the chat.
+// The owner always has every permission so a chat cannot lock itself out.
+func rolePermissions(info dto.MemberInfo) int {
+	if info.MemberRole == enums.OWNER {
+		return enums.ALL_PERMISSIONS
+	}
+	if info.Permissions != nil {
+		return *info.Permissions
+	}
+	return enums.DefaultRolePermissions[int(info.MemberRole)]
+}
+
+// roleRank returns the rank of the member's role, a member can only act on
+// members ranked below them
+func roleRank(info dto.MemberInfo) int {
+	if info.Rank != nil {
+		return *info.Rank
+	}
+	return enums.ChatRolesToRanks[int(info.MemberRole)]
+}
+
+// roleName returns the name of the member's custom role or built-in role
+func roleName(info dto.MemberInfo) string {
+	if info.RoleName != "" {
+		return info.RoleName
+	}
+	return enums.ChatRolesToLabels[int(info.MemberRole)]
+}
+
+// authorize is the permission check shared by the services. The caller's
+// membership has to be looked up beforehand with GetMemberInfo.
+func authorize(info dto.MemberInfo, permission int) error {
+	if rolePermissions(info)&permission != permission {
+		return usecase_errors.PermissionError{Msg: fmt.Sprintf("You do not have the %s permission in this chat", enums.PermissionsToLabels[permission])}
+	}
+	return nil
+}
+
+func permissionLabels(permissions int) []string {
+	labels := make([]string, 0, len(enums.PermissionsToLabels))
+	for permission := 1; permission <= enums.ALL_PERMISSIONS; permission <<= 1 {
+		if permissions&permission != 0 {
+			labels = append(labels, enums.PermissionsToLabels[permission])
+		}
+	}
+	return labels
+}
+
+func parsePermissions(labels []string) (int, error) {
+	permissions := 0
+	for _, label := range labels {
+		permission, ok := enums.LabelsToPermissions[label]
+		if !ok {
+			return 0, usecase_errors.BadRequestError{Msg: fmt.Sprintf("Unknown permission %s", label)}
+		}
+		permissions |= permission
+	}
+	return permissions, nil
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"libs/src/internal/domain/enums"
+	domain "libs/src/internal/domain/models"
+	"libs/src/internal/dto"
+	"libs/src/internal/repositories"
+	"libs/src/settings"
+)
+
+// postSystemMessage reports the event in the history of the chat and delivers
+// it like any other message. The event itself is already persisted, so a
+// failure is logged instead of failing the request.
+func postSystemMessage(ctx context.Context, app *settings.App, messageRepository repositories.IMessageRepository, actor dto.UserDTO, chatId int64, content string, event domain.SystemEvent) {
+	message := domain.NewSystemMessage(actor.ID, chatId, content, event)
+	if err := messageRepository.Create(ctx, message); err != nil {
+		app.Logger.Error(fmt.Sprintf("Error posting system message %s to chat %d: %v", enums.SystemEventsToLabels[int(event.Action)], chatId, err))
+		return
+	}
+
+	publishEvent(ctx, app, dto.EventDTO{
+		Type:    enums.EventTypesToLabels[enums.MESSAGE_CREATED],
+		ChatId:  chatId,
+		Payload: message.ToPreview(actor.Username),
+	})
+}
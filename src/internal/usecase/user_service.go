@@ -1,11 +1,13 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"io"
 	"libs/src/internal/domain/enums"
 	domain "libs/src/internal/domain/models"
 	"libs/src/internal/dto"
@@ -14,7 +16,6 @@ import (
 	"libs/src/pkg/utils"
 	"libs/src/settings"
 	"mime/multipart"
-	"path/filepath"
 	"strconv"
 	"time"
 )
@@ -35,15 +36,50 @@ func NewUserService(app *settings.App) *UserService {
 	}
 }
 
-func (s *UserService) saveUserAvatar(oldImage string, newImage *multipart.FileHeader) (string, error) {
+// saveUserAvatar stores the thumbnails of the new avatar and removes the old
+// ones, it returns the new value of User.Image.
+func (s *UserService) saveUserAvatar(ctx context.Context, oldImage string, newImage *multipart.FileHeader) (string, error) {
+	file, err := newImage.Open()
+	if err != nil {
+		return "", err
+	}
+	content, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	format, thumbnails, err := utils.ResizeAvatar(content, enums.AvatarSizes)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidImage) {
+			return "", usecase_errors.BadRequestError{Msg: "Avatar must be a JPEG or PNG image"}
+		}
+		return "", err
+	}
+
+	extensions := map[string]string{"jpeg": ".jpg", "png": ".png"}
+	image := domain.AvatarsPrefix + uuid.New().String() + extensions[format]
+	for i, size := range enums.AvatarSizes {
+		thumbnail := thumbnails[size]
+		err := s.App.Storage.Save(ctx, domain.AvatarKey(image, size), bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/"+format)
+		if err != nil {
+			s.deleteAvatar(ctx, image, enums.AvatarSizes[:i])
+			return "", err
+		}
+	}
+
 	if oldImage != "" {
-		utils.DeleteIfExist(filepath.Join(s.App.Config.AppConfig.UploadDir, oldImage))
+		s.deleteAvatar(ctx, oldImage, enums.AvatarSizes)
 	}
+	return image, nil
+}
 
-	fileName := uuid.New().String() + filepath.Ext(newImage.Filename)
-	filePath := filepath.Join(s.App.Config.AppConfig.UploadDir, fileName)
-	err := utils.UploadFile(newImage, filePath)
-	return fileName, err
+func (s *UserService) deleteAvatar(ctx context.Context, image string, sizes []int) {
+	for _, size := range sizes {
+		if err := s.App.Storage.Delete(ctx, domain.AvatarKey(image, size)); err != nil {
+			s.App.Logger.Warn(fmt.Sprintf("Failed to delete avatar %s: %v", domain.AvatarKey(image, size), err))
+		}
+	}
 }
 
 func (s *UserService) CreateSuperUser(ctx context.Context, username string, email string, password string) error {
@@ -89,7 +125,7 @@ func (s *UserService) GetUserProfile(ctx context.Context, username string) (*dto
 		Description: oneUser.Description,
 		Role:        enums.RolesToLabels[int(oneUser.Role)],
 		IsOnline:    s.IsOnline(ctx, oneUser.ID),
-		Image:       oneUser.Image,
+		Image:       avatarURL(s.App, oneUser.Image, enums.AVATAR_LARGE),
 		CreatedAt:   oneUser.CreatedAt,
 	}
 
@@ -107,7 +143,7 @@ func (s *UserService) ChangeUserProfile(ctx context.Context, caller dto.UserDTO,
 	}
 
 	if data.NewImage != nil {
-		fileName, err := s.saveUserAvatar(caller.Image, data.NewImage)
+		fileName, err := s.saveUserAvatar(ctx, caller.Image, data.NewImage)
 		if err != nil {
 			return err
 		}
@@ -132,6 +168,50 @@ func (s *UserService) ChangeUserProfile(ctx context.Context, caller dto.UserDTO,
 	return nil
 }
 
+func (s *UserService) GetSettings(ctx context.Context, caller dto.UserDTO) (dto.UserSettingsDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.UserSettingsDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to get your settings"}
+	}
+
+	user, err := s.UserRepository.GetById(ctx, caller.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return dto.UserSettingsDTO{}, usecase_errors.NotFoundError{Msg: "User not found"}
+		}
+		return dto.UserSettingsDTO{}, err
+	}
+
+	return dto.UserSettingsDTO{
+		DirectAdds: enums.DirectAddsToLabels[int(user.DirectAdds)],
+	}, nil
+}
+
+func (s *UserService) ChangeSettings(ctx context.Context, caller dto.UserDTO, request dto.ChangeUserSettingsRequest) (dto.UserSettingsDTO, error) {
+	if caller.Role == enums.ANONYMOUS || !caller.IsActive {
+		return dto.UserSettingsDTO{}, usecase_errors.UnauthorizedError{Msg: "You must be logged in to change your settings"}
+	}
+
+	updateData := map[string]any{}
+	if request.DirectAdds != nil {
+		directAdds, ok := enums.LabelsToDirectAdds[*request.DirectAdds]
+		if !ok {
+			return dto.UserSettingsDTO{}, usecase_errors.BadRequestError{Msg: "Invalid direct adds option"}
+		}
+		updateData["direct_adds"] = directAdds
+	}
+
+	if len(updateData) > 0 {
+		err := s.UserRepository.UpdateById(ctx, caller.ID, updateData)
+		if err != nil {
+			if errors.Is(err, repositories.ErrRecordNotFound) {
+				return dto.UserSettingsDTO{}, usecase_errors.NotFoundError{Msg: "User not found"}
+			}
+			return dto.UserSettingsDTO{}, err
+		}
+	}
+	return s.GetSettings(ctx, caller)
+}
+
 func (s *UserService) ResetPassword(ctx context.Context, request dto.ResetPasswordRequest) (int, error) {
 	users, err := s.UserRepository.Filter(ctx, "email = ? OR username = ?", request.UsernameOrEmail, request.UsernameOrEmail)
 
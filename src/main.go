@@ -25,6 +25,8 @@ func main() {
 
 	repositories.CreateIndexes(settings.AppVar)
 
+	go settings.AppVar.Broker.Run(settings.AppVar.Ctx)
+
 	server.RunServer()
 
 	if err := diCont.Stop(settings.AppVar.Ctx); err != nil {
@@ -77,3 +77,13 @@ func GenerateSecureCode(min, max int) (int, error) {
 	}
 	return int(num.Int64()) + min, nil
 }
+
+// GenerateToken returns a random url safe token made of size random bytes
+func GenerateToken(size int) (string, error) {
+	b := make([]byte, size)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
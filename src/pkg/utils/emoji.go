@@ -0,0 +1,96 @@
+package utils
+
+import "unicode/utf8"
+
+// maxEmojiLength covers the longest ZWJ sequences, like family emojis with skin tones
+const maxEmojiLength = 64
+
+func isPictographic(r rune) bool {
+	switch {
+	case r == 0x00A9 || r == 0x00AE || r == 0x203C || r == 0x2049 || r == 0x2122 || r == 0x2139:
+		return true
+	case r >= 0x2194 && r <= 0x21AA:
+		return true
+	case r >= 0x231A && r <= 0x23FF:
+		return true
+	case r == 0x24C2 || r == 0x25AA || r == 0x25AB || r == 0x25B6 || r == 0x25C0:
+		return true
+	case r >= 0x25FB && r <= 0x25FE:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r >= 0x2934 && r <= 0x2935:
+		return true
+	case r >= 0x2B05 && r <= 0x2B55:
+		return true
+	case r == 0x3030 || r == 0x303D || r == 0x3297 || r == 0x3299:
+		return true
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return !isRegionalIndicator(r) && !isSkinTone(r)
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isSkinTone(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+func isKeycapBase(r rune) bool {
+	return (r >= '0' && r <= '9') || r == '#' || r == '*'
+}
+
+const (
+	zeroWidthJoiner = 0x200D
+	variationEmoji  = 0xFE0F
+	keycap          = 0x20E3
+)
+
+// IsEmoji reports whether s is exactly one emoji: a pictograph with optional
+// modifiers and ZWJ sequences, a flag made of two regional indicators, a
+// subdivision flag or a keycap.
+func IsEmoji(s string) bool {
+	if s == "" || len(s) > maxEmojiLength || !utf8.ValidString(s) {
+		return false
+	}
+	runes := []rune(s)
+
+	// Keycap: base, optional variation selector, combining enclosing keycap
+	if isKeycapBase(runes[0]) {
+		rest := runes[1:]
+		if len(rest) > 0 && rest[0] == variationEmoji {
+			rest = rest[1:]
+		}
+		return len(rest) == 1 && rest[0] == keycap
+	}
+
+	// Country flag
+	if isRegionalIndicator(runes[0]) {
+		return len(runes) == 2 && isRegionalIndicator(runes[1])
+	}
+
+	// Pictographs joined by ZWJ, each one optionally followed by a variation
+	// selector, a skin tone or tag characters (subdivision flags)
+	expectPictograph := true
+	for _, r := range runes {
+		if expectPictograph {
+			if !isPictographic(r) {
+				return false
+			}
+			expectPictograph = false
+			continue
+		}
+
+		switch {
+		case r == zeroWidthJoiner:
+			expectPictograph = true
+		case r == variationEmoji, isSkinTone(r), r >= 0xE0020 && r <= 0xE007F:
+		default:
+			return false
+		}
+	}
+	return !expectPictograph
+}
@@ -32,7 +32,6 @@ type FakeMessage struct {
 	SenderID int64
 	Content  string
 
-	IsRead    bool
 	IsUpdated bool
 	IsDeleted bool
 
@@ -70,7 +69,6 @@ func NewFakeMessage(chatId, senderId int64) *FakeMessage {
 		ChatID:    chatId,
 		SenderID:  senderId,
 		Content:   gofakeit.Sentence(10),
-		IsRead:    gofakeit.Bool(),
 		IsUpdated: gofakeit.Bool(),
 		IsDeleted: gofakeit.Bool(),
 		CreatedAt: createdAt,
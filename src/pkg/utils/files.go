@@ -3,26 +3,22 @@ package utils
 import (
 	"io"
 	"mime/multipart"
-	"os"
+	"net/http"
 )
 
-func UploadFile(fileHeader *multipart.FileHeader, path string) error {
+// DetectContentType sniffs the MIME type of the uploaded file from its first
+// bytes, the name and the type the client claims are not trusted.
+func DetectContentType(fileHeader *multipart.FileHeader) (string, error) {
 	file, err := fileHeader.Open()
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer file.Close()
 
-	out, err := os.Create(path)
-	if err != nil {
-		return err
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
 	}
-	defer out.Close()
-
-	_, err = io.Copy(out, file)
-	return err
-}
-
-func DeleteIfExist(path string) bool {
-	return os.Remove(path) == nil
+	return http.DetectContentType(head[:n]), nil
 }
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+)
+
+var ErrInvalidImage = errors.New("not a valid JPEG or PNG image")
+
+// maxImagePixels stops decompression bombs: small files declaring huge images
+const maxImagePixels = 50_000_000
+
+// ResizeAvatar decodes a JPEG or PNG image by its content and re-encodes it
+// as a centered square in each of the sizes, in the format of the upload.
+// Re-encoding drops all metadata of the upload, EXIF included, so the EXIF
+// orientation is applied to the pixels first.
+func ResizeAvatar(content []byte, sizes []int) (string, map[int][]byte, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil || (format != "jpeg" && format != "png") {
+		return "", nil, ErrInvalidImage
+	}
+	if config.Width == 0 || config.Height == 0 || config.Width*config.Height > maxImagePixels {
+		return "", nil, ErrInvalidImage
+	}
+
+	var source image.Image
+	if format == "jpeg" {
+		source, err = jpeg.Decode(bytes.NewReader(content))
+		if err == nil {
+			source = orient(source, jpegOrientation(content))
+		}
+	} else {
+		source, err = png.Decode(bytes.NewReader(content))
+	}
+	if err != nil {
+		return "", nil, ErrInvalidImage
+	}
+
+	bounds := source.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	square := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	resized := make(map[int][]byte, len(sizes))
+	for _, size := range sizes {
+		// Small images are not scaled up
+		target := image.NewNRGBA(image.Rect(0, 0, min(size, side), min(size, side)))
+		draw.CatmullRom.Scale(target, target.Bounds(), source, square, draw.Src, nil)
+
+		var out bytes.Buffer
+		if format == "jpeg" {
+			err = jpeg.Encode(&out, target, &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&out, target)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		resized[size] = out.Bytes()
+	}
+	return format, resized, nil
+}
+
+// jpegOrientation reads the EXIF orientation tag of a JPEG, 1 is upright
+func jpegOrientation(content []byte) int {
+	if len(content) < 4 || content[0] != 0xFF || content[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(content) && content[i] == 0xFF; {
+		marker := content[i+1]
+		length := int(binary.BigEndian.Uint16(content[i+2:]))
+		// Start of scan, the metadata segments are behind
+		if marker == 0xDA || length < 2 || i+2+length > len(content) {
+			return 1
+		}
+
+		segment := content[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+// orient turns the image upright according to its EXIF orientation
+func orient(source image.Image, orientation int) image.Image {
+	if orientation == 1 {
+		return source
+	}
+
+	bounds := source.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	// Orientations 5 to 8 swap the width and the height
+	target := image.NewNRGBA(image.Rect(0, 0, w, h))
+	if orientation >= 5 {
+		target = image.NewNRGBA(image.Rect(0, 0, h, w))
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var tx, ty int
+			switch orientation {
+			case 2:
+				tx, ty = w-1-x, y
+			case 3:
+				tx, ty = w-1-x, h-1-y
+			case 4:
+				tx, ty = x, h-1-y
+			case 5:
+				tx, ty = y, x
+			case 6:
+				tx, ty = h-1-y, x
+			case 7:
+				tx, ty = h-1-y, w-1-x
+			case 8:
+				tx, ty = y, w-1-x
+			}
+			target.Set(tx, ty, source.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return target
+}
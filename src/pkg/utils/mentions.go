@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// MentionAll is the mention of every member of the chat
+const MentionAll = "all"
+
+// mentionPattern matches @username unless it is part of a word, like in an
+// email address. Usernames are at most 35 characters long.
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@.])@([A-Za-z0-9_]{1,35})\b`)
+
+// MentionToken is an @username found in a text. Offset and Length count
+// characters, the "@" included.
+type MentionToken struct {
+	Username string
+	Offset   int
+	Length   int
+}
+
+func ParseMentions(text string) []MentionToken {
+	matches := mentionPattern.FindAllStringSubmatchIndex(text, -1)
+	tokens := make([]MentionToken, 0, len(matches))
+	for _, match := range matches {
+		start, end := match[2]-1, match[3]
+		tokens = append(tokens, MentionToken{
+			Username: text[match[2]:match[3]],
+			Offset:   utf8.RuneCountInString(text[:start]),
+			Length:   utf8.RuneCountInString(text[start:end]),
+		})
+	}
+	return tokens
+}
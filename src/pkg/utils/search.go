@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TextRange locates a part of a text, Offset and Length count characters
+type TextRange struct {
+	Offset int
+	Length int
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// words splits the text into runs of letters and digits, the way the text
+// index tokenizes it
+func words(text []rune) []TextRange {
+	var result []TextRange
+	start := -1
+	for i, r := range text {
+		switch {
+		case isWordRune(r) && start < 0:
+			start = i
+		case !isWordRune(r) && start >= 0:
+			result = append(result, TextRange{Offset: start, Length: i - start})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		result = append(result, TextRange{Offset: start, Length: len(text) - start})
+	}
+	return result
+}
+
+// SearchTerms returns the words of a text search query that the results
+// contain, "-negated" words are left out
+func SearchTerms(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		runes := []rune(field)
+		for _, word := range words(runes) {
+			terms = append(terms, string(runes[word.Offset:word.Offset+word.Length]))
+		}
+	}
+	return terms
+}
+
+// Snippet cuts at most size characters of the text around the first term it
+// contains and locates every term in the cut. An ellipsis marks the ends that
+// were cut off.
+func Snippet(text string, terms []string, size int) (string, []TextRange) {
+	runes := []rune(text)
+
+	var matches []TextRange
+	for _, word := range words(runes) {
+		value := string(runes[word.Offset : word.Offset+word.Length])
+		for _, term := range terms {
+			if strings.EqualFold(value, term) {
+				matches = append(matches, word)
+				break
+			}
+		}
+	}
+
+	start, end := 0, len(runes)
+	if len(runes) > size {
+		if len(matches) > 0 {
+			start = max(0, matches[0].Offset-size/4)
+		}
+		end = min(len(runes), start+size)
+		start = max(0, end-size)
+	}
+
+	var snippet strings.Builder
+	shift := -start
+	if start > 0 {
+		snippet.WriteString("…")
+		shift++
+	}
+	snippet.WriteString(string(runes[start:end]))
+	if end < len(runes) {
+		snippet.WriteString("…")
+	}
+
+	var highlights []TextRange
+	for _, match := range matches {
+		if match.Offset >= start && match.Offset+match.Length <= end {
+			highlights = append(highlights, TextRange{Offset: match.Offset + shift, Length: match.Length})
+		}
+	}
+	return snippet.String(), highlights
+}
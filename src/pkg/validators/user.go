@@ -5,6 +5,7 @@ import (
 	"mime/multipart"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"unicode"
 )
 
@@ -39,7 +40,7 @@ func ValidatorImage(fl validator.FieldLevel) bool {
 		return false
 	}
 
-	ext := filepath.Ext(file.Filename)
+	ext := strings.ToLower(filepath.Ext(file.Filename))
 	allowedExtensions := map[string]bool{".jpg": true, ".jpeg": true, ".png": true}
 	if !allowedExtensions[ext] {
 		return false
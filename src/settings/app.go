@@ -7,6 +7,8 @@ import (
 	"go.uber.org/zap"
 	"gopkg.in/gomail.v2"
 	"gorm.io/gorm"
+	"libs/src/internal/realtime"
+	"libs/src/internal/storage"
 )
 
 var AppVar *App
@@ -18,11 +20,14 @@ type App struct {
 	MongoDB     *mongo.Database
 	RedisClient *redis.Client
 	Mail        *gomail.Dialer
+	Hub         *realtime.Hub
+	Broker      realtime.IBroker
+	Storage     storage.IStorage
 	Ctx         context.Context
 	Cancel      context.CancelFunc
 }
 
-func NewApp(db *gorm.DB, logger *zap.Logger, config *BaseConfig, mongodb *mongo.Database, redis *redis.Client, mail *gomail.Dialer) *App {
+func NewApp(db *gorm.DB, logger *zap.Logger, config *BaseConfig, mongodb *mongo.Database, redis *redis.Client, mail *gomail.Dialer, hub *realtime.Hub, broker realtime.IBroker, storage storage.IStorage) *App {
 	AppVar = &App{
 		Ctx:         AppVar.Ctx,
 		Cancel:      AppVar.Cancel,
@@ -32,6 +37,9 @@ func NewApp(db *gorm.DB, logger *zap.Logger, config *BaseConfig, mongodb *mongo.
 		MongoDB:     mongodb,
 		RedisClient: redis,
 		Mail:        mail,
+		Hub:         hub,
+		Broker:      broker,
+		Storage:     storage,
 	}
 
 	return AppVar
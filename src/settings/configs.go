@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -18,6 +19,22 @@ type AppConfig struct {
 	UploadDir  string `mapstructure:"upload_dir"`
 }
 
+// BaseURL is the public address of the API built from DomainName, which may
+// carry its own scheme and port. Without them the debug server is reached
+// over http on Port and the production one over https.
+func (c AppConfig) BaseURL() string {
+	if strings.Contains(c.DomainName, "://") {
+		return strings.TrimSuffix(c.DomainName, "/")
+	}
+	if !c.Debug {
+		return "https://" + c.DomainName
+	}
+	if strings.Contains(c.DomainName, ":") {
+		return "http://" + c.DomainName
+	}
+	return fmt.Sprintf("http://%s:%d", c.DomainName, c.Port)
+}
+
 type PostgresTimeout struct {
 	Small  int `mapstructure:"small"`
 	Medium int `mapstructure:"medium"`
@@ -93,17 +110,54 @@ type Pagination struct {
 	MessagesList    int `mapstructure:"messages_list"`
 	UsersInChatList int `mapstructure:"users_in_chat_list"`
 	SearchUsersList int `mapstructure:"search_users_list"`
+	AuditLog        int `mapstructure:"audit_log"`
+	Mentions        int `mapstructure:"mentions"`
+}
+
+type MessagesConfig struct {
+	MaxDistinctReactions int   `mapstructure:"max_distinct_reactions"`
+	MaxAttachments       int   `mapstructure:"max_attachments"`
+	MaxAttachmentSize    int64 `mapstructure:"max_attachment_size"`
+	MaxPinned            int   `mapstructure:"max_pinned"`
+	SnippetLength        int   `mapstructure:"snippet_length"`
+}
+
+type WebsocketConfig struct {
+	WriteWaitMs    int    `mapstructure:"write_wait_ms"`
+	PongWaitMs     int    `mapstructure:"pong_wait_ms"`
+	MaxMessageSize int64  `mapstructure:"max_message_size"`
+	SendBuffer     int    `mapstructure:"send_buffer"`
+	Broker         string `mapstructure:"broker"`
+}
+
+type S3Config struct {
+	Endpoint  string `mapstructure:"endpoint"`
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+	UseSSL    bool   `mapstructure:"use_ssl"`
+}
+
+type StorageConfig struct {
+	// Driver is "local" to keep uploads in UploadDir or "s3"
+	Driver         string   `mapstructure:"driver"`
+	SignedUrlTtlMs int      `mapstructure:"signed_url_ttl_ms"`
+	S3             S3Config `mapstructure:"s3"`
 }
 
 type BaseConfig struct {
-	AppConfig      AppConfig      `mapstructure:"app"`
-	Timeout        Timeout        `mapstructure:"context_timeout_ms"`
-	Pagination     Pagination     `mapstructure:"pagination"`
-	PostgresConfig PostgresConfig `mapstructure:"db"`
-	AuthConfig     AuthConfig     `mapstructure:"auth"`
-	MongoConfig    MongoConfig    `mapstructure:"mongo"`
-	RedisConfig    RedisConfig    `mapstructure:"redis"`
-	Mail           Mail           `mapstructure:"mail"`
+	AppConfig       AppConfig       `mapstructure:"app"`
+	Timeout         Timeout         `mapstructure:"context_timeout_ms"`
+	Pagination      Pagination      `mapstructure:"pagination"`
+	PostgresConfig  PostgresConfig  `mapstructure:"db"`
+	AuthConfig      AuthConfig      `mapstructure:"auth"`
+	MongoConfig     MongoConfig     `mapstructure:"mongo"`
+	RedisConfig     RedisConfig     `mapstructure:"redis"`
+	Mail            Mail            `mapstructure:"mail"`
+	WebsocketConfig WebsocketConfig `mapstructure:"websocket"`
+	MessagesConfig  MessagesConfig  `mapstructure:"messages"`
+	StorageConfig   StorageConfig   `mapstructure:"storage"`
 }
 
 func GetBaseConfig() (*BaseConfig, error) {
@@ -129,6 +183,9 @@ func GetBaseConfig() (*BaseConfig, error) {
 		"mail.from":      viper.GetString("MAIL_FROM"),
 		"mail.port":      viper.GetInt("MAIL_PORT"),
 		"mail.server":    viper.GetString("MAIL_SERVER"),
+
+		"storage.s3.access_key": viper.GetString("STORAGE_S3_ACCESS_KEY"),
+		"storage.s3.secret_key": viper.GetString("STORAGE_S3_SECRET_KEY"),
 	}
 
 	viper.SetConfigName("config")
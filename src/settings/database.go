@@ -12,6 +12,15 @@ var Models = []interface{}{
 	&domain.User{},
 	&domain.Chat{},
 	&domain.ChatMember{},
+	&domain.ChatInvite{},
+	&domain.ChatInvitation{},
+	&domain.JoinRequest{},
+	&domain.ChatRestriction{},
+	&domain.ChatRolePermission{},
+	&domain.ChatRole{},
+	&domain.ChatAuditEntry{},
+	&domain.ChatPin{},
+	&domain.Mention{},
 }
 
 func GetDb(baseConfig *BaseConfig) (*gorm.DB, error) {
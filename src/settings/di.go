@@ -43,6 +43,9 @@ func GetDI() *fx.App {
 			},
 			NewRedisClient,
 			NewMail,
+			NewHub,
+			NewBroker,
+			NewStorage,
 			NewApp,
 		),
 		fx.Invoke(func(app *App) {
@@ -0,0 +1,30 @@
+package settings
+
+import (
+	"libs/src/internal/realtime"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func NewHub(config *BaseConfig, logger *zap.Logger) *realtime.Hub {
+	return realtime.NewHub(logger, realtime.Options{
+		WriteWait:      time.Duration(config.WebsocketConfig.WriteWaitMs) * time.Millisecond,
+		PongWait:       time.Duration(config.WebsocketConfig.PongWaitMs) * time.Millisecond,
+		MaxMessageSize: config.WebsocketConfig.MaxMessageSize,
+		SendBuffer:     config.WebsocketConfig.SendBuffer,
+	})
+}
+
+func NewBroker(config *BaseConfig, client *redis.Client, hub *realtime.Hub) realtime.IBroker {
+	if config.WebsocketConfig.Broker == "memory" {
+		return realtime.NewMemoryBroker(hub)
+	}
+	return realtime.NewRedisBroker(
+		client,
+		config.RedisConfig.Prefixes.Message,
+		time.Duration(config.Timeout.Redis.Medium)*time.Millisecond,
+		hub,
+	)
+}
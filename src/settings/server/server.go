@@ -6,6 +6,7 @@ import (
 	admin_api "libs/src/internal/handlers/api/admin"
 	handler_middlewares "libs/src/internal/handlers/middlewares"
 	"libs/src/pkg/validators"
+	"libs/src/settings"
 
 	files "github.com/swaggo/files"
 	swagger "github.com/swaggo/gin-swagger"
@@ -57,6 +58,8 @@ func RunServer() {
 	router.Use(middlewares...)
 
 	router.GET("/docs/*any", swagger.WrapHandler(files.Handler))
+	router.GET(settings.MediaPath+"/*key", handler_api.ServeMedia)
+	router.GET(settings.AvatarsPath+"/*key", handler_api.ServeAvatar)
 
 	base := router.Group("")
 	{
@@ -73,6 +76,9 @@ func RunServer() {
 			auth.POST("/login", handler_api.Login)
 			auth.DELETE("/logout", handler_api.Logout)
 		}
+		accounts.GET("/settings", handler_api.GetUserSettings)
+		accounts.PATCH("/settings", handler_api.ChangeUserSettings)
+
 		profile := accounts.Group("/profile")
 		{
 			profile.GET("/:username", handler_api.UserProfile)
@@ -84,9 +90,23 @@ func RunServer() {
 	}
 	messenger := router.Group("/messenger")
 	{
+		messenger.GET("/ws", handler_api.Websocket)
+		messenger.POST("/direct/:username", handler_api.OpenDirectChat)
+		messenger.GET("/invite/:token", handler_api.PreviewInvite)
+		messenger.POST("/invite/:token", handler_api.RedeemInvite)
+		messenger.GET("/invitations", handler_api.GetInvitations)
+		messenger.GET("/invitations/sent", handler_api.GetSentInvitations)
+		messenger.POST("/invitations/:invitation_id/accept", handler_api.AcceptInvitation)
+		messenger.POST("/invitations/:invitation_id/decline", handler_api.DeclineInvitation)
+		messenger.GET("/join-requests", handler_api.GetMyJoinRequests)
+		messenger.GET("/mentions", handler_api.GetMentions)
+		messenger.POST("/mentions/read", handler_api.MarkMentionsRead)
+		messenger.GET("/messages/search", handler_api.SearchMessages)
+
 		chat := messenger.Group("/chat")
 		{
 			chat.GET("/all", handler_api.GetChatsForUser)
+			chat.GET("/discover", handler_api.DiscoverChats)
 			chat.GET("/:chat_id", handler_api.GetChatInfo)
 			chat.POST("/create", handler_api.CreateChat)
 			chat.POST("/invite", handler_api.InviteToChat)
@@ -97,7 +117,43 @@ func RunServer() {
 			chat.PATCH("/:chat_id/members/:member_username/change-role", handler_api.ChangeMemberRole)
 			chat.DELETE("/:chat_id/members/:member_username/delete", handler_api.DeleteMember)
 
+			chat.GET("/:chat_id/messages", handler_api.GetMessages)
 			chat.POST("/:chat_id/message/send", handler_api.SendMessage)
+			chat.PATCH("/:chat_id/message/:message_id", handler_api.EditMessage)
+			chat.DELETE("/:chat_id/message/:message_id", handler_api.DeleteMessage)
+			chat.GET("/:chat_id/message/:message_id/revisions", handler_api.GetMessageRevisions)
+			chat.GET("/:chat_id/message/:message_id/seen", handler_api.GetMessageReaders)
+			chat.GET("/:chat_id/message/:message_id/thread", handler_api.GetThread)
+			chat.PUT("/:chat_id/message/:message_id/reactions/:emoji", handler_api.AddReaction)
+			chat.DELETE("/:chat_id/message/:message_id/reactions/:emoji", handler_api.RemoveReaction)
+			chat.PUT("/:chat_id/message/:message_id/pin", handler_api.PinMessage)
+			chat.DELETE("/:chat_id/message/:message_id/pin", handler_api.UnpinMessage)
+			chat.GET("/:chat_id/message/:message_id/attachments/:attachment_id", handler_api.DownloadAttachment)
+			chat.POST("/:chat_id/join", handler_api.JoinChat)
+			chat.POST("/:chat_id/leave", handler_api.LeaveChat)
+			chat.POST("/:chat_id/transfer-ownership", handler_api.TransferOwnership)
+			chat.GET("/:chat_id/invites", handler_api.GetInvites)
+			chat.POST("/:chat_id/invites", handler_api.CreateInvite)
+			chat.DELETE("/:chat_id/invites/:invite_id", handler_api.RevokeInvite)
+			chat.GET("/:chat_id/join-requests", handler_api.GetJoinRequests)
+			chat.POST("/:chat_id/join-requests", handler_api.RequestToJoin)
+			chat.POST("/:chat_id/join-requests/:request_id/approve", handler_api.ApproveJoinRequest)
+			chat.POST("/:chat_id/join-requests/:request_id/reject", handler_api.RejectJoinRequest)
+			chat.GET("/:chat_id/bans", handler_api.GetBans)
+			chat.PUT("/:chat_id/bans/:member_username", handler_api.BanMember)
+			chat.DELETE("/:chat_id/bans/:member_username", handler_api.UnbanMember)
+			chat.GET("/:chat_id/mutes", handler_api.GetMutes)
+			chat.PUT("/:chat_id/mutes/:member_username", handler_api.MuteMember)
+			chat.DELETE("/:chat_id/mutes/:member_username", handler_api.UnmuteMember)
+			chat.GET("/:chat_id/permissions", handler_api.GetRolePermissions)
+			chat.PUT("/:chat_id/permissions/:role", handler_api.ChangeRolePermissions)
+			chat.DELETE("/:chat_id/permissions/:role", handler_api.ResetRolePermissions)
+			chat.GET("/:chat_id/roles", handler_api.GetChatRoles)
+			chat.POST("/:chat_id/roles", handler_api.CreateChatRole)
+			chat.PATCH("/:chat_id/roles/:role_id", handler_api.ChangeChatRole)
+			chat.DELETE("/:chat_id/roles/:role_id", handler_api.DeleteChatRole)
+			chat.GET("/:chat_id/audit-log", handler_api.GetAuditLog)
+			chat.POST("/:chat_id/read", handler_api.MarkRead)
 		}
 	}
 
@@ -0,0 +1,32 @@
+package settings
+
+import (
+	"context"
+	"libs/src/internal/storage"
+	"time"
+)
+
+// MediaPath is the route serving the signed URLs of the local storage
+const MediaPath = "/media"
+
+// AvatarsPath is the public route serving the avatars from the storage
+const AvatarsPath = "/avatars"
+
+func NewStorage(config *BaseConfig) storage.IStorage {
+	if config.StorageConfig.Driver != "s3" {
+		return storage.NewLocalStorage(config.AppConfig.UploadDir, config.AppConfig.BaseURL()+MediaPath, config.AppConfig.SecretKey)
+	}
+
+	s3Config := config.StorageConfig.S3
+	s3Storage, err := storage.NewS3Storage(s3Config.Endpoint, s3Config.AccessKey, s3Config.SecretKey, s3Config.Bucket, s3Config.Region, s3Config.UseSSL)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s3Storage.EnsureBucket(ctx); err != nil {
+		panic(err)
+	}
+	return s3Storage
+}
@@ -0,0 +1,54 @@
+package integration
+
+import (
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	"libs/src/internal/realtime"
+	"libs/src/settings"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+func (suite *AppTestSuite) TestRedisBrokerFanOut() {
+	// Two hubs sharing one redis behave like two backend instances
+	firstHub := settings.NewHub(settings.AppVar.Config, settings.AppVar.Logger)
+	secondHub := settings.NewHub(settings.AppVar.Config, settings.AppVar.Logger)
+	firstBroker := settings.NewBroker(settings.AppVar.Config, settings.AppVar.RedisClient, firstHub)
+	secondBroker := settings.NewBroker(settings.AppVar.Config, settings.AppVar.RedisClient, secondHub)
+
+	go firstBroker.Run(suite.Ctx)
+	go secondBroker.Run(suite.Ctx)
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		suite.NoError(err)
+		client := realtime.NewClient(secondHub, conn, 1)
+		secondHub.Register(client, []int64{77})
+		client.Run()
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	suite.NoError(err)
+	defer conn.Close()
+
+	// Give both subscriptions time to be established
+	time.Sleep(200 * time.Millisecond)
+
+	err = firstBroker.Publish(suite.Ctx, dto.EventDTO{Type: enums.EventTypesToLabels[enums.MESSAGE_CREATED], ChatId: 77})
+	suite.NoError(err)
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, data, err := conn.ReadMessage()
+	suite.NoError(err)
+
+	var event dto.EventDTO
+	suite.NoError(json.Unmarshal(data, &event))
+	suite.Equal(int64(77), event.ChatId)
+	suite.Equal(enums.EventTypesToLabels[enums.MESSAGE_CREATED], event.Type)
+}
@@ -80,12 +80,575 @@ func (suite *AppTestSuite) TestInviteToChat() {
 	suite.NoError(err)
 	suite.Equal(http.StatusOK, inviteResult.StatusCode)
 
-	// Check if the invitee is now a member of the chat
+	var invitation dto.ChatInvitationDTO
+	err = json.NewDecoder(inviteResult.Body).Decode(&invitation)
+	suite.NoError(err)
+	suite.Equal("pending", invitation.Status)
+
+	// The invitee is not a member until the invitation is accepted
 	userRepo := repositories.NewUserRepository(settings.AppVar)
 	invitee, err := userRepo.GetByUsername(suite.Ctx, "TestInvitee")
 	suite.NoError(err)
 
+	_, err = chatService.ChatMemberRepository.GetMemberInfo(suite.Ctx, invitee.ID, chatID)
+	suite.ErrorIs(err, repositories.ErrRecordNotFound)
+
+	inviteeSess, err := authService.Login(suite.Ctx, dto.UserDTO{ID: 1, Role: enums.ANONYMOUS, IsActive: false}, dto.LoginRequest{UsernameOrEmail: "TestInvitee", Password: "test123"})
+	suite.NoError(err)
+
+	inboxRequest, _ := http.NewRequest("GET", "http://127.0.0.1:8000/messenger/invitations", nil)
+	inboxRequest.AddCookie(&http.Cookie{Name: "sessionID", Value: inviteeSess})
+	inboxResult, err := suite.client.Do(inboxRequest)
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, inboxResult.StatusCode)
+
+	var inbox dto.ChatInvitationListResponse
+	err = json.NewDecoder(inboxResult.Body).Decode(&inbox)
+	suite.NoError(err)
+	suite.Len(inbox.Invitations, 1)
+	suite.Equal("TestInviter", inbox.Invitations[0].Inviter)
+
+	acceptRequest, _ := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:8000/messenger/invitations/%d/accept", invitation.ID), nil)
+	acceptRequest.AddCookie(&http.Cookie{Name: "sessionID", Value: inviteeSess})
+	acceptResult, err := suite.client.Do(acceptRequest)
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, acceptResult.StatusCode)
+
+	// Check if the invitee is now a member of the chat
 	inviteeInfo, err := chatService.ChatMemberRepository.GetMemberInfo(suite.Ctx, invitee.ID, chatID)
 	suite.NoError(err)
 	suite.Equal(inviteeInfo.MemberRole, dto.ChatMemberDTO{MemberRole: 0}.MemberRole)
+
+	// The inviter sees the invitation was accepted
+	sentRequest, _ := http.NewRequest("GET", "http://127.0.0.1:8000/messenger/invitations/sent", nil)
+	sentRequest.AddCookie(&http.Cookie{Name: "sessionID", Value: sess})
+	sentResult, err := suite.client.Do(sentRequest)
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, sentResult.StatusCode)
+
+	var sent dto.ChatInvitationListResponse
+	err = json.NewDecoder(sentResult.Body).Decode(&sent)
+	suite.NoError(err)
+	suite.Len(sent.Invitations, 1)
+	suite.Equal("accepted", sent.Invitations[0].Status)
+	suite.NotNil(sent.Invitations[0].RespondedAt)
+}
+
+func (suite *AppTestSuite) TestOpenDirectChat() {
+	directUrl := "http://127.0.0.1:8000/messenger/direct/%s"
+
+	suite.login("TestDirectFirst", "TestDirectSecond", "TestDirectThird")
+
+	openDirect := func(username, peer string) dto.ChatListItemDTO {
+		response := suite.do("POST", fmt.Sprintf(directUrl, peer), username, nil)
+		suite.Equal(http.StatusOK, response.StatusCode)
+
+		var chat dto.ChatListItemDTO
+		suite.NoError(json.NewDecoder(response.Body).Decode(&chat))
+		return chat
+	}
+
+	first := openDirect("TestDirectFirst", "TestDirectSecond")
+	suite.Equal("direct", first.Type)
+	suite.Equal("TestDirectSecond", first.Peer.Username)
+
+	// Opening the conversation again, from either side, gives the same chat
+	suite.Equal(first.ID, openDirect("TestDirectFirst", "TestDirectSecond").ID)
+	reverse := openDirect("TestDirectSecond", "TestDirectFirst")
+	suite.Equal(first.ID, reverse.ID)
+	suite.Equal("TestDirectFirst", reverse.Peer.Username)
+
+	// Direct chats have no title, so they do not collide with each other
+	other := openDirect("TestDirectFirst", "TestDirectThird")
+	suite.NotEqual(first.ID, other.ID)
+}
+
+func (suite *AppTestSuite) TestDiscoverAndJoin() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	discoverUrl := "http://127.0.0.1:8000/messenger/chat/discover?search=%s"
+	joinUrl := "http://127.0.0.1:8000/messenger/chat/%d/join"
+
+	suite.login("TestPublicOwner", "TestPublicJoiner")
+
+	createChat := func(title, visibility string) dto.ChatDTO {
+		result := suite.do("POST", chatCreateUrl, "TestPublicOwner", dto.CreateChatRequest{Title: title, Description: "TestDiscoverAndJoin", Visibility: visibility})
+		suite.Equal(http.StatusOK, result.StatusCode)
+
+		var chat dto.ChatDTO
+		suite.NoError(json.NewDecoder(result.Body).Decode(&chat))
+		return chat
+	}
+	publicChat := createChat("DiscoverablePublic", "public")
+	privateChat := createChat("DiscoverablePrivate", "private")
+
+	// Only the public chat is listed
+	result := suite.do("GET", fmt.Sprintf(discoverUrl, "Discoverable"), "TestPublicJoiner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	var discovered dto.FilterChatsResponse
+	suite.NoError(json.NewDecoder(result.Body).Decode(&discovered))
+	suite.Len(discovered.Chats, 1)
+	suite.Equal(publicChat.ID, discovered.Chats[0].ID)
+	suite.Equal(int64(1), discovered.Chats[0].MembersCount)
+
+	join := func(chatId int64) int {
+		return suite.do("POST", fmt.Sprintf(joinUrl, chatId), "TestPublicJoiner", nil).StatusCode
+	}
+	suite.Equal(http.StatusForbidden, join(privateChat.ID))
+	suite.Equal(http.StatusOK, join(publicChat.ID))
+	suite.Equal(http.StatusConflict, join(publicChat.ID))
+}
+
+func (suite *AppTestSuite) TestInviteLinks() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	invitesUrl := "http://127.0.0.1:8000/messenger/chat/%d/invites"
+	inviteUrl := "http://127.0.0.1:8000/messenger/invite/%s"
+
+	suite.login("TestLinkOwner", "TestLinkGuest", "TestLinkLate")
+
+	result := suite.do("POST", chatCreateUrl, "TestLinkOwner", dto.CreateChatRequest{Title: "TestInviteLinks", Description: "TestInviteLinks"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var chat dto.ChatDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&chat))
+
+	// Only admins of the chat can mint links
+	result = suite.do("POST", fmt.Sprintf(invitesUrl, chat.ID), "TestLinkGuest", dto.CreateInviteRequest{})
+	suite.Equal(http.StatusNotFound, result.StatusCode)
+
+	result = suite.do("POST", fmt.Sprintf(invitesUrl, chat.ID), "TestLinkOwner", dto.CreateInviteRequest{ExpiresIn: 3600, MaxUses: 1})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var invite dto.ChatInviteDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&invite))
+	suite.True(invite.IsActive)
+
+	// Preview does not join
+	result = suite.do("GET", fmt.Sprintf(inviteUrl, invite.Token), "TestLinkGuest", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var preview dto.InvitePreviewDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&preview))
+	suite.Equal(chat.ID, preview.Chat.ID)
+	suite.Equal(int64(1), preview.Chat.MembersCount)
+	suite.False(preview.IsMember)
+
+	result = suite.do("POST", fmt.Sprintf(inviteUrl, invite.Token), "TestLinkGuest", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(inviteUrl, invite.Token), "TestLinkLate", nil)
+	suite.Equal(http.StatusNotFound, result.StatusCode)
+
+	// A second link, revoked before anyone uses it
+	result = suite.do("POST", fmt.Sprintf(invitesUrl, chat.ID), "TestLinkOwner", dto.CreateInviteRequest{})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var revoked dto.ChatInviteDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&revoked))
+
+	result = suite.do("DELETE", fmt.Sprintf(invitesUrl+"/%d", chat.ID, revoked.ID), "TestLinkOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(inviteUrl, revoked.Token), "TestLinkLate", nil)
+	suite.Equal(http.StatusNotFound, result.StatusCode)
+
+	result = suite.do("GET", fmt.Sprintf(invitesUrl, chat.ID), "TestLinkOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var list dto.ChatInviteListResponse
+	suite.NoError(json.NewDecoder(result.Body).Decode(&list))
+	suite.Len(list.Invites, 2)
+	for _, listed := range list.Invites {
+		suite.False(listed.IsActive)
+	}
+}
+
+func (suite *AppTestSuite) TestJoinRequests() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	requestsUrl := "http://127.0.0.1:8000/messenger/chat/%d/join-requests"
+	myRequestsUrl := "http://127.0.0.1:8000/messenger/join-requests"
+
+	suite.login("TestQueueOwner", "TestQueueWelcome", "TestQueueRejected")
+
+	result := suite.do("POST", chatCreateUrl, "TestQueueOwner", dto.CreateChatRequest{Title: "TestJoinRequests", Description: "TestJoinRequests"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var chat dto.ChatDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&chat))
+
+	result = suite.do("POST", fmt.Sprintf(requestsUrl, chat.ID), "TestQueueWelcome", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(requestsUrl, chat.ID), "TestQueueWelcome", nil)
+	suite.Equal(http.StatusConflict, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(requestsUrl, chat.ID), "TestQueueRejected", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	// Only admins see the queue
+	result = suite.do("GET", fmt.Sprintf(requestsUrl, chat.ID), "TestQueueWelcome", nil)
+	suite.Equal(http.StatusNotFound, result.StatusCode)
+
+	result = suite.do("GET", fmt.Sprintf(requestsUrl, chat.ID), "TestQueueOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var queue dto.JoinRequestListResponse
+	suite.NoError(json.NewDecoder(result.Body).Decode(&queue))
+	suite.Len(queue.Requests, 2)
+	suite.Equal("TestQueueWelcome", queue.Requests[0].Username)
+
+	result = suite.do("POST", fmt.Sprintf(requestsUrl+"/%d/approve", chat.ID, queue.Requests[0].ID), "TestQueueOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(requestsUrl+"/%d/reject", chat.ID, queue.Requests[1].ID), "TestQueueOwner", dto.RejectJoinRequestRequest{Reason: "Members only"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	// The approved requester is a member now
+	result = suite.do("GET", fmt.Sprintf("http://127.0.0.1:8000/messenger/chat/%d", chat.ID), "TestQueueWelcome", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	result = suite.do("GET", myRequestsUrl, "TestQueueRejected", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var mine dto.JoinRequestListResponse
+	suite.NoError(json.NewDecoder(result.Body).Decode(&mine))
+	suite.Len(mine.Requests, 1)
+	suite.Equal("rejected", mine.Requests[0].Status)
+	suite.Equal("Members only", mine.Requests[0].Reason)
+}
+
+func (suite *AppTestSuite) TestLeaveChat() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	leaveUrl := "http://127.0.0.1:8000/messenger/chat/%d/leave"
+
+	chatMemberService := services.NewChatMemberService(settings.AppVar)
+	chatRepo := repositories.NewChatRepository(settings.AppVar)
+	userRepo := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestLeaveOwner", "TestLeaveHeir")
+	heir, err := userRepo.GetByUsername(suite.Ctx, "TestLeaveHeir")
+	suite.NoError(err)
+
+	createChat := func(title string) int64 {
+		result := suite.do("POST", chatCreateUrl, "TestLeaveOwner", dto.CreateChatRequest{Title: title, Description: "TestLeaveChat"})
+		suite.Equal(http.StatusOK, result.StatusCode)
+		var chat dto.ChatDTO
+		suite.NoError(json.NewDecoder(result.Body).Decode(&chat))
+		suite.NoError(chatMemberService.CreateMember(suite.Ctx, heir.ToDTO(), chat.ID, nil))
+		return chat.ID
+	}
+
+	// The owner has to choose what happens to the chat
+	handedOver := createChat("TestLeaveHandedOver")
+	result := suite.do("POST", fmt.Sprintf(leaveUrl, handedOver), "TestLeaveOwner", nil)
+	suite.Equal(http.StatusBadRequest, result.StatusCode)
+
+	result = suite.do("POST", fmt.Sprintf(leaveUrl, handedOver), "TestLeaveOwner", dto.LeaveChatRequest{OwnerAction: "transfer", NewOwner: "TestLeaveHeir"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	chat, err := chatRepo.GetById(suite.Ctx, handedOver)
+	suite.NoError(err)
+	suite.Equal(heir.ID, chat.OwnerID)
+	heirInfo, err := chatMemberService.ChatMemberRepository.GetMemberInfo(suite.Ctx, heir.ID, handedOver)
+	suite.NoError(err)
+	suite.Equal(byte(enums.OWNER), heirInfo.MemberRole)
+
+	// The previous owner is gone from the chat
+	result = suite.do("POST", fmt.Sprintf(leaveUrl, handedOver), "TestLeaveOwner", nil)
+	suite.Equal(http.StatusNotFound, result.StatusCode)
+
+	// A plain member leaves without a choice, the owner deletes the chat
+	deleted := createChat("TestLeaveDeleted")
+	result = suite.do("POST", fmt.Sprintf(leaveUrl, deleted), "TestLeaveHeir", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(leaveUrl, deleted), "TestLeaveOwner", dto.LeaveChatRequest{OwnerAction: "delete"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	_, err = chatRepo.GetById(suite.Ctx, deleted)
+	suite.ErrorIs(err, repositories.ErrRecordNotFound)
+}
+
+func (suite *AppTestSuite) TestBansAndMutes() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	banUrl := "http://127.0.0.1:8000/messenger/chat/%d/bans/%s"
+	muteUrl := "http://127.0.0.1:8000/messenger/chat/%d/mutes/%s"
+	sendUrl := "http://127.0.0.1:8000/messenger/chat/%d/message/send"
+	joinUrl := "http://127.0.0.1:8000/messenger/chat/%d/join"
+
+	chatMemberService := services.NewChatMemberService(settings.AppVar)
+	userRepo := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestModOwner", "TestModMember", "TestModStranger")
+	member, err := userRepo.GetByUsername(suite.Ctx, "TestModMember")
+	suite.NoError(err)
+
+	result := suite.do("POST", chatCreateUrl, "TestModOwner", dto.CreateChatRequest{Title: "TestBansAndMutes", Description: "TestBansAndMutes", Visibility: "public"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var chat dto.ChatDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&chat))
+	suite.NoError(chatMemberService.CreateMember(suite.Ctx, member.ToDTO(), chat.ID, nil))
+
+	// Muted members cannot write until the mute is lifted
+	result = suite.do("PUT", fmt.Sprintf(muteUrl, chat.ID, "TestModMember"), "TestModOwner", dto.RestrictMemberRequest{Reason: "flood", Duration: 600})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(sendUrl, chat.ID), "TestModMember", dto.SendMessageRequest{Message: "hello"})
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+	result = suite.do("DELETE", fmt.Sprintf(muteUrl, chat.ID, "TestModMember"), "TestModOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(sendUrl, chat.ID), "TestModMember", dto.SendMessageRequest{Message: "hello"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	// Members cannot moderate
+	result = suite.do("PUT", fmt.Sprintf(banUrl, chat.ID, "TestModOwner"), "TestModMember", nil)
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+
+	// A ban removes the member, banned users cannot come back
+	result = suite.do("PUT", fmt.Sprintf(banUrl, chat.ID, "TestModMember"), "TestModOwner", dto.RestrictMemberRequest{Reason: "spam"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	_, err = chatMemberService.ChatMemberRepository.GetMemberInfo(suite.Ctx, member.ID, chat.ID)
+	suite.ErrorIs(err, repositories.ErrRecordNotFound)
+	result = suite.do("POST", fmt.Sprintf(joinUrl, chat.ID), "TestModMember", nil)
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+
+	// Users can be banned before they ever join
+	result = suite.do("PUT", fmt.Sprintf(banUrl, chat.ID, "TestModStranger"), "TestModOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	result = suite.do("GET", fmt.Sprintf("http://127.0.0.1:8000/messenger/chat/%d/bans", chat.ID), "TestModOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var bans dto.ChatRestrictionListResponse
+	suite.NoError(json.NewDecoder(result.Body).Decode(&bans))
+	suite.Len(bans.Restrictions, 2)
+
+	result = suite.do("DELETE", fmt.Sprintf(banUrl, chat.ID, "TestModMember"), "TestModOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(joinUrl, chat.ID), "TestModMember", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	// Every ban, mute and lift is on record, newest first
+	result = suite.do("GET", fmt.Sprintf("http://127.0.0.1:8000/messenger/chat/%d/audit-log", chat.ID), "TestModOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var log dto.ChatAuditLogResponse
+	suite.NoError(json.NewDecoder(result.Body).Decode(&log))
+	actions := make([]string, len(log.Entries))
+	for i, entry := range log.Entries {
+		actions[i] = entry.Action
+	}
+	suite.Equal([]string{"restriction_lifted", "member_banned", "member_banned", "restriction_lifted", "member_muted"}, actions)
+	suite.Equal("TestModMember", log.Entries[2].Target)
+	suite.Equal("member", log.Entries[2].Before["role"])
+	suite.Equal("spam", log.Entries[2].After["reason"])
+	suite.Equal("mute", log.Entries[3].Before["type"])
+}
+
+func (suite *AppTestSuite) TestRolePermissions() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	editUrl := "http://127.0.0.1:8000/messenger/chat/edit/%d"
+	permissionsUrl := "http://127.0.0.1:8000/messenger/chat/%d/permissions"
+	sendUrl := "http://127.0.0.1:8000/messenger/chat/%d/message/send"
+
+	chatMemberService := services.NewChatMemberService(settings.AppVar)
+	userRepo := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestPermOwner", "TestPermMember")
+	member, err := userRepo.GetByUsername(suite.Ctx, "TestPermMember")
+	suite.NoError(err)
+
+	result := suite.do("POST", chatCreateUrl, "TestPermOwner", dto.CreateChatRequest{Title: "TestRolePermissions", Description: "TestRolePermissions"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var chat dto.ChatDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&chat))
+	suite.NoError(chatMemberService.CreateMember(suite.Ctx, member.ToDTO(), chat.ID, nil))
+
+	// Members cannot edit the chat by default
+	newTitle := "TestRolePermissionsRenamed"
+	result = suite.do("PATCH", fmt.Sprintf(editUrl, chat.ID), "TestPermMember", dto.ChangeChatRequest{NewTitle: &newTitle})
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+
+	// Only the owner customises the roles
+	grant := dto.ChangeRolePermissionsRequest{Permissions: []string{"send_messages", "edit_chat"}}
+	result = suite.do("PUT", fmt.Sprintf(permissionsUrl, chat.ID)+"/member", "TestPermMember", grant)
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+	result = suite.do("PUT", fmt.Sprintf(permissionsUrl, chat.ID)+"/member", "TestPermOwner", grant)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	result = suite.do("PATCH", fmt.Sprintf(editUrl, chat.ID), "TestPermMember", dto.ChangeChatRequest{NewTitle: &newTitle})
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	result = suite.do("GET", fmt.Sprintf(permissionsUrl, chat.ID), "TestPermMember", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var permissions dto.RolePermissionsListResponse
+	suite.NoError(json.NewDecoder(result.Body).Decode(&permissions))
+	suite.Len(permissions.Roles, 3)
+	suite.Equal("member", permissions.Roles[0].Role)
+	suite.Equal([]string{"send_messages", "edit_chat"}, permissions.Roles[0].Permissions)
+	suite.False(permissions.Roles[0].IsDefault)
+
+	// Read-only members until the role is reset
+	result = suite.do("PUT", fmt.Sprintf(permissionsUrl, chat.ID)+"/member", "TestPermOwner", dto.ChangeRolePermissionsRequest{Permissions: []string{}})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(sendUrl, chat.ID), "TestPermMember", dto.SendMessageRequest{Message: "hello"})
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+
+	result = suite.do("DELETE", fmt.Sprintf(permissionsUrl, chat.ID)+"/member", "TestPermOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(sendUrl, chat.ID), "TestPermMember", dto.SendMessageRequest{Message: "hello"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+}
+
+func (suite *AppTestSuite) TestCustomRoles() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	rolesUrl := "http://127.0.0.1:8000/messenger/chat/%d/roles"
+	membersUrl := "http://127.0.0.1:8000/messenger/chat/%d/members/"
+
+	chatMemberService := services.NewChatMemberService(settings.AppVar)
+	userRepo := repositories.NewUserRepository(settings.AppVar)
+
+	usernames := []string{"TestRoleOwner", "TestRoleModerator", "TestRoleMember"}
+	suite.login(usernames...)
+
+	memberRoles := func(chatId int64) map[string]string {
+		result := suite.do("GET", fmt.Sprintf(membersUrl, chatId)+"all", "TestRoleOwner", nil)
+		suite.Equal(http.StatusOK, result.StatusCode)
+		var members dto.MemberListPreview
+		suite.NoError(json.NewDecoder(result.Body).Decode(&members))
+		roles := map[string]string{}
+		for _, member := range members.Members {
+			roles[member.Username] = member.Role
+		}
+		return roles
+	}
+
+	result := suite.do("POST", chatCreateUrl, "TestRoleOwner", dto.CreateChatRequest{Title: "TestCustomRoles", Description: "TestCustomRoles"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var chat dto.ChatDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&chat))
+	for _, username := range usernames[1:] {
+		user, err := userRepo.GetByUsername(suite.Ctx, username)
+		suite.NoError(err)
+		suite.NoError(chatMemberService.CreateMember(suite.Ctx, user.ToDTO(), chat.ID, nil))
+	}
+
+	// Only the owner defines roles
+	moderator := dto.CreateChatRoleRequest{Name: "Moderator", Rank: 50, Permissions: []string{"send_messages", "kick"}}
+	result = suite.do("POST", fmt.Sprintf(rolesUrl, chat.ID), "TestRoleModerator", moderator)
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+	result = suite.do("POST", fmt.Sprintf(rolesUrl, chat.ID), "TestRoleOwner", moderator)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var role dto.ChatRoleDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&role))
+	suite.Equal("moderator", role.Name)
+
+	result = suite.do("POST", fmt.Sprintf(rolesUrl, chat.ID), "TestRoleOwner", moderator)
+	suite.Equal(http.StatusConflict, result.StatusCode)
+
+	result = suite.do("PATCH", fmt.Sprintf(membersUrl, chat.ID)+"TestRoleModerator/change-role", "TestRoleOwner", dto.ChangeMemberRoleRequest{NewRole: "moderator"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal("moderator", memberRoles(chat.ID)["TestRoleModerator"])
+
+	// The moderator kicks the members ranked below, not the owner
+	result = suite.do("DELETE", fmt.Sprintf(membersUrl, chat.ID)+"TestRoleOwner/delete", "TestRoleModerator", nil)
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+	result = suite.do("DELETE", fmt.Sprintf(membersUrl, chat.ID)+"TestRoleMember/delete", "TestRoleModerator", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	// Members of a deleted role become plain members
+	result = suite.do("DELETE", fmt.Sprintf(rolesUrl, chat.ID)+fmt.Sprintf("/%d", role.ID), "TestRoleOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal("member", memberRoles(chat.ID)["TestRoleModerator"])
+}
+
+func (suite *AppTestSuite) TestAuditLog() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	auditLogUrl := "http://127.0.0.1:8000/messenger/chat/%d/audit-log"
+	membersUrl := "http://127.0.0.1:8000/messenger/chat/%d/members/"
+
+	chatMemberService := services.NewChatMemberService(settings.AppVar)
+	userRepo := repositories.NewUserRepository(settings.AppVar)
+
+	usernames := []string{"TestAuditOwner", "TestAuditAdmin", "TestAuditMember"}
+	suite.login(usernames...)
+
+	result := suite.do("POST", chatCreateUrl, "TestAuditOwner", dto.CreateChatRequest{Title: "TestAuditLog", Description: "TestAuditLog"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var chat dto.ChatDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&chat))
+	for _, username := range usernames[1:] {
+		user, err := userRepo.GetByUsername(suite.Ctx, username)
+		suite.NoError(err)
+		suite.NoError(chatMemberService.CreateMember(suite.Ctx, user.ToDTO(), chat.ID, nil))
+	}
+
+	result = suite.do("PATCH", fmt.Sprintf(membersUrl, chat.ID)+"TestAuditAdmin/change-role", "TestAuditOwner", dto.ChangeMemberRoleRequest{NewRole: "admin"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	newTitle := "TestAuditLogRenamed"
+	result = suite.do("PATCH", fmt.Sprintf("http://127.0.0.1:8000/messenger/chat/edit/%d", chat.ID), "TestAuditOwner", dto.ChangeChatRequest{NewTitle: &newTitle})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("DELETE", fmt.Sprintf(membersUrl, chat.ID)+"TestAuditMember/delete", "TestAuditAdmin", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	// Plain members can't read the log
+	member, err := userRepo.GetByUsername(suite.Ctx, "TestAuditMember")
+	suite.NoError(err)
+	suite.NoError(chatMemberService.CreateMember(suite.Ctx, member.ToDTO(), chat.ID, nil))
+	result = suite.do("GET", fmt.Sprintf(auditLogUrl, chat.ID), "TestAuditMember", nil)
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+
+	result = suite.do("GET", fmt.Sprintf(auditLogUrl, chat.ID), "TestAuditAdmin", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var log dto.ChatAuditLogResponse
+	suite.NoError(json.NewDecoder(result.Body).Decode(&log))
+	suite.Len(log.Entries, 3)
+
+	suite.Equal("member_kicked", log.Entries[0].Action)
+	suite.Equal("TestAuditAdmin", log.Entries[0].Actor)
+	suite.Equal("TestAuditMember", log.Entries[0].Target)
+
+	suite.Equal("chat_edited", log.Entries[1].Action)
+	suite.Equal("TestAuditLog", log.Entries[1].Before["title"])
+	suite.Equal(newTitle, log.Entries[1].After["title"])
+
+	suite.Equal("role_changed", log.Entries[2].Action)
+	suite.Equal("member", log.Entries[2].Before["role"])
+	suite.Equal("admin", log.Entries[2].After["role"])
+}
+
+func (suite *AppTestSuite) TestSystemMessages() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	historyUrl := "http://127.0.0.1:8000/messenger/chat/%d/messages"
+	membersUrl := "http://127.0.0.1:8000/messenger/chat/%d/members/"
+
+	chatMemberService := services.NewChatMemberService(settings.AppVar)
+	userRepo := repositories.NewUserRepository(settings.AppVar)
+
+	usernames := []string{"TestSystemOwner", "TestSystemMember"}
+	suite.login(usernames...)
+
+	result := suite.do("POST", chatCreateUrl, "TestSystemOwner", dto.CreateChatRequest{Title: "TestSystemMessages", Description: "TestSystemMessages"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var chat dto.ChatDTO
+	suite.NoError(json.NewDecoder(result.Body).Decode(&chat))
+
+	member, err := userRepo.GetByUsername(suite.Ctx, "TestSystemMember")
+	suite.NoError(err)
+	suite.NoError(chatMemberService.CreateMember(suite.Ctx, member.ToDTO(), chat.ID, nil))
+
+	result = suite.do("PATCH", fmt.Sprintf(membersUrl, chat.ID)+"TestSystemMember/change-role", "TestSystemOwner", dto.ChangeMemberRoleRequest{NewRole: "admin"})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	newTitle := "TestSystemMessagesRenamed"
+	result = suite.do("PATCH", fmt.Sprintf("http://127.0.0.1:8000/messenger/chat/edit/%d", chat.ID), "TestSystemOwner", dto.ChangeChatRequest{NewTitle: &newTitle})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	result = suite.do("DELETE", fmt.Sprintf(membersUrl, chat.ID)+"TestSystemMember/delete", "TestSystemOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	result = suite.do("GET", fmt.Sprintf(historyUrl, chat.ID), "TestSystemOwner", nil)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	var history dto.MessageHistoryResponse
+	suite.NoError(json.NewDecoder(result.Body).Decode(&history))
+	suite.Len(history.Messages, 4)
+	for _, message := range history.Messages {
+		suite.Equal("system", message.Type)
+	}
+
+	suite.Equal("member_joined", history.Messages[0].Event.Action)
+	suite.Equal("TestSystemMember", history.Messages[0].SenderUsername)
+	suite.Equal("TestSystemMember joined the chat", history.Messages[0].Content)
+
+	suite.Equal("role_changed", history.Messages[1].Event.Action)
+	suite.Equal("TestSystemMember", history.Messages[1].Event.Target)
+	suite.Equal("admin", history.Messages[1].Event.Value)
+
+	suite.Equal("title_changed", history.Messages[2].Event.Action)
+	suite.Equal(newTitle, history.Messages[2].Event.Value)
+
+	suite.Equal("member_kicked", history.Messages[3].Event.Action)
+	suite.Equal("TestSystemOwner", history.Messages[3].SenderUsername)
+	suite.Equal("TestSystemOwner removed TestSystemMember", history.Messages[3].Content)
 }
@@ -24,6 +24,7 @@ func GetTestConfig() *settings.BaseConfig {
 			Debug:      true,
 			Mode:       "dev",
 			DomainName: "127.0.0.1",
+			UploadDir:  filepath.Join(os.TempDir(), "gochat-test-uploads"),
 		},
 		PostgresConfig: settings.PostgresConfig{
 			Host:     os.Getenv("DB_HOST"),
@@ -67,6 +68,33 @@ func GetTestConfig() *settings.BaseConfig {
 				Large:  1500,
 			},
 		},
+		Pagination: settings.Pagination{
+			ChatList:        25,
+			GlobalChatList:  20,
+			MessagesList:    100,
+			UsersInChatList: 20,
+			SearchUsersList: 20,
+			AuditLog:        50,
+			Mentions:        50,
+		},
 		Mail: settings.Mail{},
+		StorageConfig: settings.StorageConfig{
+			Driver:         "local",
+			SignedUrlTtlMs: 900000,
+		},
+		MessagesConfig: settings.MessagesConfig{
+			MaxDistinctReactions: 20,
+			MaxAttachments:       10,
+			MaxAttachmentSize:    26214400,
+			MaxPinned:            20,
+			SnippetLength:        160,
+		},
+		WebsocketConfig: settings.WebsocketConfig{
+			WriteWaitMs:    10000,
+			PongWaitMs:     60000,
+			MaxMessageSize: 4096,
+			SendBuffer:     256,
+			Broker:         "redis",
+		},
 	}
 }
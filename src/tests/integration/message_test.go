@@ -0,0 +1,482 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"libs/src/internal/dto"
+	"libs/src/internal/repositories"
+	services "libs/src/internal/usecase"
+	"libs/src/settings"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func (suite *AppTestSuite) TestEditAndDeleteMessage() {
+	chatCreateUrl := "http://127.0.0.1:8000/messenger/chat/create"
+	sendUrl := "http://127.0.0.1:8000/messenger/chat/%d/message/send"
+	messageUrl := "http://127.0.0.1:8000/messenger/chat/%d/message/%s"
+	historyUrl := "http://127.0.0.1:8000/messenger/chat/%d/messages"
+
+	suite.login("TestMessageEditor")
+
+	// Create a chat and send a message to it
+	response := suite.do("POST", chatCreateUrl, "TestMessageEditor", dto.CreateChatRequest{Title: "TestEditMessages", Description: "TestEditMessages"})
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var chat dto.ChatDTO
+	suite.NoError(json.NewDecoder(response.Body).Decode(&chat))
+
+	response = suite.do("POST", fmt.Sprintf(sendUrl, chat.ID), "TestMessageEditor", dto.SendMessageRequest{Message: "original"})
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var message dto.MessagePreviewDTO
+	suite.NoError(json.NewDecoder(response.Body).Decode(&message))
+
+	// Edit it, the previous content goes to the revisions
+	response = suite.do("PATCH", fmt.Sprintf(messageUrl, chat.ID, message.Id), "TestMessageEditor", dto.EditMessageRequest{Message: "$edited"})
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var edited dto.MessagePreviewDTO
+	suite.NoError(json.NewDecoder(response.Body).Decode(&edited))
+	suite.Equal("$edited", edited.Content)
+	suite.True(edited.IsEdited)
+
+	// Delete it, the history keeps a tombstone
+	response = suite.do("DELETE", fmt.Sprintf(messageUrl, chat.ID, message.Id), "TestMessageEditor", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+
+	response = suite.do("GET", fmt.Sprintf(historyUrl, chat.ID), "TestMessageEditor", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var history dto.MessageHistoryResponse
+	suite.NoError(json.NewDecoder(response.Body).Decode(&history))
+	suite.Len(history.Messages, 1)
+	suite.True(history.Messages[0].IsDeleted)
+	suite.Empty(history.Messages[0].Content)
+
+	response = suite.do("PATCH", fmt.Sprintf(messageUrl, chat.ID, message.Id), "TestMessageEditor", dto.EditMessageRequest{Message: "too late"})
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+
+	// The owner moderates the chat and can see every revision
+	response = suite.do("GET", fmt.Sprintf(messageUrl+"/revisions", chat.ID, message.Id), "TestMessageEditor", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var revisions []dto.MessageRevisionDTO
+	suite.NoError(json.NewDecoder(response.Body).Decode(&revisions))
+	suite.Len(revisions, 2)
+	suite.Equal("original", revisions[0].Content)
+	suite.Equal("$edited", revisions[1].Content)
+}
+
+func (suite *AppTestSuite) TestReadReceipts() {
+	chatsUrl := "http://127.0.0.1:8000/messenger/chat/all"
+	readUrl := "http://127.0.0.1:8000/messenger/chat/%d/read"
+	seenUrl := "http://127.0.0.1:8000/messenger/chat/%d/message/%s/seen"
+
+	chatService := services.NewChatService(settings.AppVar)
+	memberService := services.NewChatMemberService(settings.AppVar)
+	messageService := services.NewMessageService(settings.AppVar)
+	userRepository := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestReadSender", "TestReadReader")
+	sender, err := userRepository.GetByUsername(suite.Ctx, "TestReadSender")
+	suite.NoError(err)
+	reader, err := userRepository.GetByUsername(suite.Ctx, "TestReadReader")
+	suite.NoError(err)
+
+	chat, err := chatService.CreateChat(suite.Ctx, dto.CreateChatRequest{Title: "TestReadReceipts", Description: "TestReadReceipts"}, sender.ToDTO())
+	suite.NoError(err)
+	suite.NoError(memberService.CreateMember(suite.Ctx, reader.ToDTO(), chat.ID, nil))
+
+	var messages []*dto.MessagePreviewDTO
+	for _, content := range []string{"first", "second", "third"} {
+		message, err := messageService.SendMessage(suite.Ctx, sender.ToDTO(), dto.SendMessageRequest{Message: content}, chat.ID)
+		suite.NoError(err)
+		messages = append(messages, message)
+	}
+
+	unreadCount := func() int64 {
+		response := suite.do("GET", chatsUrl, "TestReadReader", nil)
+		suite.Equal(http.StatusOK, response.StatusCode)
+		var chats []dto.ChatListItemDTO
+		suite.NoError(json.NewDecoder(response.Body).Decode(&chats))
+		for _, item := range chats {
+			if item.ID == chat.ID {
+				return item.UnreadCount
+			}
+		}
+		suite.Fail("chat is missing from the list")
+		return 0
+	}
+
+	suite.Equal(int64(3), unreadCount())
+
+	response := suite.do("POST", fmt.Sprintf(readUrl, chat.ID), "TestReadReader", dto.MarkReadRequest{MessageId: messages[1].Id})
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(int64(1), unreadCount())
+
+	// The cursor never goes back
+	response = suite.do("POST", fmt.Sprintf(readUrl, chat.ID), "TestReadReader", dto.MarkReadRequest{MessageId: messages[0].Id})
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(int64(1), unreadCount())
+
+	response = suite.do("GET", fmt.Sprintf(seenUrl, chat.ID, messages[0].Id), "TestReadReader", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var readers []dto.MessageReaderDTO
+	suite.NoError(json.NewDecoder(response.Body).Decode(&readers))
+	suite.Len(readers, 1)
+	suite.Equal("TestReadReader", readers[0].Username)
+
+	response = suite.do("GET", fmt.Sprintf(seenUrl, chat.ID, messages[2].Id), "TestReadReader", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	readers = nil
+	suite.NoError(json.NewDecoder(response.Body).Decode(&readers))
+	suite.Empty(readers)
+}
+
+func (suite *AppTestSuite) TestThreadReplies() {
+	historyUrl := "http://127.0.0.1:8000/messenger/chat/%d/messages"
+	threadUrl := "http://127.0.0.1:8000/messenger/chat/%d/message/%s/thread"
+
+	chatService := services.NewChatService(settings.AppVar)
+	messageService := services.NewMessageService(settings.AppVar)
+	userRepository := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestThreadAuthor")
+	author, err := userRepository.GetByUsername(suite.Ctx, "TestThreadAuthor")
+	suite.NoError(err)
+
+	chat, err := chatService.CreateChat(suite.Ctx, dto.CreateChatRequest{Title: "TestThreadReplies", Description: "TestThreadReplies"}, author.ToDTO())
+	suite.NoError(err)
+
+	root, err := messageService.SendMessage(suite.Ctx, author.ToDTO(), dto.SendMessageRequest{Message: "root"}, chat.ID)
+	suite.NoError(err)
+	reply, err := messageService.SendMessage(suite.Ctx, author.ToDTO(), dto.SendMessageRequest{Message: "reply", ReplyTo: root.Id}, chat.ID)
+	suite.NoError(err)
+	nested, err := messageService.SendMessage(suite.Ctx, author.ToDTO(), dto.SendMessageRequest{Message: "nested", ReplyTo: reply.Id}, chat.ID)
+	suite.NoError(err)
+
+	get := func(url string, target any) {
+		response := suite.do("GET", url, "TestThreadAuthor", nil)
+		suite.Equal(http.StatusOK, response.StatusCode)
+		suite.NoError(json.NewDecoder(response.Body).Decode(target))
+	}
+
+	// Replies stay out of the chat history, the root shows the thread summary
+	var history dto.MessageHistoryResponse
+	get(fmt.Sprintf(historyUrl, chat.ID), &history)
+	suite.Len(history.Messages, 1)
+	suite.Equal(int64(2), history.Messages[0].ReplyCount)
+	suite.Equal("TestThreadAuthor", history.Messages[0].LastReplyBy)
+	suite.NotNil(history.Messages[0].LastReplyAt)
+
+	var thread dto.MessageThreadResponse
+	get(fmt.Sprintf(threadUrl, chat.ID, root.Id), &thread)
+	suite.Equal(root.Id, thread.Parent.Id)
+	suite.Len(thread.Messages, 2)
+	suite.Equal("reply", thread.Messages[0].Content)
+	suite.Equal("nested", thread.Messages[1].Content)
+	suite.Equal(root.Id, thread.Messages[1].ReplyTo)
+
+	// A reply saved late does not take over the last reply info
+	rootId, err := primitive.ObjectIDFromHex(root.Id)
+	suite.NoError(err)
+	suite.NoError(messageService.MessageRepository.AddReply(suite.Ctx, rootId, time.Now().Add(-time.Hour), author.ID+1))
+	history = dto.MessageHistoryResponse{}
+	get(fmt.Sprintf(historyUrl, chat.ID), &history)
+	suite.Equal(int64(3), history.Messages[0].ReplyCount)
+	suite.Equal("TestThreadAuthor", history.Messages[0].LastReplyBy)
+
+	// A deleted reply no longer counts in the summary
+	suite.NoError(messageService.DeleteMessage(suite.Ctx, author.ToDTO(), chat.ID, nested.Id))
+	history = dto.MessageHistoryResponse{}
+	get(fmt.Sprintf(historyUrl, chat.ID), &history)
+	suite.Equal(int64(1), history.Messages[0].ReplyCount)
+}
+
+func (suite *AppTestSuite) TestReactions() {
+	historyUrl := "http://127.0.0.1:8000/messenger/chat/%d/messages"
+	reactionUrl := "http://127.0.0.1:8000/messenger/chat/%d/message/%s/reactions/%s"
+
+	chatService := services.NewChatService(settings.AppVar)
+	messageService := services.NewMessageService(settings.AppVar)
+	userRepository := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestReactionAuthor")
+	author, err := userRepository.GetByUsername(suite.Ctx, "TestReactionAuthor")
+	suite.NoError(err)
+
+	chat, err := chatService.CreateChat(suite.Ctx, dto.CreateChatRequest{Title: "TestReactions", Description: "TestReactions"}, author.ToDTO())
+	suite.NoError(err)
+	message, err := messageService.SendMessage(suite.Ctx, author.ToDTO(), dto.SendMessageRequest{Message: "react to me"}, chat.ID)
+	suite.NoError(err)
+
+	// Reacting twice with the same emoji counts once
+	for range 2 {
+		response := suite.do("PUT", fmt.Sprintf(reactionUrl, chat.ID, message.Id, url.PathEscape("👍")), "TestReactionAuthor", nil)
+		suite.Equal(http.StatusOK, response.StatusCode)
+	}
+	response := suite.do("PUT", fmt.Sprintf(reactionUrl, chat.ID, message.Id, "ok"), "TestReactionAuthor", nil)
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+
+	response = suite.do("GET", fmt.Sprintf(historyUrl, chat.ID), "TestReactionAuthor", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var history dto.MessageHistoryResponse
+	suite.NoError(json.NewDecoder(response.Body).Decode(&history))
+	suite.Len(history.Messages, 1)
+	suite.Equal([]dto.ReactionDTO{{Emoji: "👍", Count: 1, ReactedByMe: true}}, history.Messages[0].Reactions)
+
+	response = suite.do("DELETE", fmt.Sprintf(reactionUrl, chat.ID, message.Id, url.PathEscape("👍")), "TestReactionAuthor", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var reactions []dto.ReactionDTO
+	suite.NoError(json.NewDecoder(response.Body).Decode(&reactions))
+	suite.Empty(reactions)
+}
+
+func (suite *AppTestSuite) TestAttachments() {
+	sendUrl := "http://127.0.0.1:8000/messenger/chat/%d/message/send"
+
+	chatService := services.NewChatService(settings.AppVar)
+	userRepository := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestAttachmentSender", "TestAttachmentStranger")
+	sender, err := userRepository.GetByUsername(suite.Ctx, "TestAttachmentSender")
+	suite.NoError(err)
+
+	chat, err := chatService.CreateChat(suite.Ctx, dto.CreateChatRequest{Title: "TestAttachments", Description: "TestAttachments"}, sender.ToDTO())
+	suite.NoError(err)
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	suite.NoError(writer.WriteField("message", "see the notes"))
+	part, err := writer.CreateFormFile("attachments", "notes.txt")
+	suite.NoError(err)
+	_, err = part.Write([]byte("meeting notes"))
+	suite.NoError(err)
+	suite.NoError(writer.Close())
+
+	request, _ := http.NewRequest("POST", fmt.Sprintf(sendUrl, chat.ID), body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	request.AddCookie(&http.Cookie{Name: "sessionID", Value: suite.sessions["TestAttachmentSender"]})
+	response, err := suite.client.Do(request)
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var message dto.MessagePreviewDTO
+	suite.NoError(json.NewDecoder(response.Body).Decode(&message))
+	suite.Equal("see the notes", message.Content)
+	suite.Len(message.Attachments, 1)
+	suite.Equal("notes.txt", message.Attachments[0].Name)
+	suite.Equal("text/plain; charset=utf-8", message.Attachments[0].MimeType)
+
+	download := func(username string) *http.Response {
+		return suite.do("GET", "http://127.0.0.1:8000"+message.Attachments[0].Url, username, nil)
+	}
+
+	response = download("TestAttachmentSender")
+	suite.Equal(http.StatusOK, response.StatusCode)
+	content, err := io.ReadAll(response.Body)
+	suite.NoError(err)
+	suite.Equal("meeting notes", string(content))
+
+	// Only members of the chat can download it
+	response = download("TestAttachmentStranger")
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *AppTestSuite) TestPinnedMessages() {
+	chatUrl := "http://127.0.0.1:8000/messenger/chat/%d"
+	historyUrl := "http://127.0.0.1:8000/messenger/chat/%d/messages"
+	pinUrl := "http://127.0.0.1:8000/messenger/chat/%d/message/%s/pin"
+
+	chatService := services.NewChatService(settings.AppVar)
+	chatMemberService := services.NewChatMemberService(settings.AppVar)
+	messageService := services.NewMessageService(settings.AppVar)
+	userRepository := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestPinOwner", "TestPinMember")
+	owner, err := userRepository.GetByUsername(suite.Ctx, "TestPinOwner")
+	suite.NoError(err)
+	member, err := userRepository.GetByUsername(suite.Ctx, "TestPinMember")
+	suite.NoError(err)
+
+	chat, err := chatService.CreateChat(suite.Ctx, dto.CreateChatRequest{Title: "TestPinnedMessages", Description: "TestPinnedMessages"}, owner.ToDTO())
+	suite.NoError(err)
+	suite.NoError(chatMemberService.CreateMember(suite.Ctx, member.ToDTO(), chat.ID, nil))
+	message, err := messageService.SendMessage(suite.Ctx, member.ToDTO(), dto.SendMessageRequest{Message: "read the rules"}, chat.ID)
+	suite.NoError(err)
+
+	chatInfo := func() dto.ChatDTO {
+		response := suite.do("GET", fmt.Sprintf(chatUrl, chat.ID), "TestPinMember", nil)
+		suite.Equal(http.StatusOK, response.StatusCode)
+		var info dto.ChatDTO
+		suite.NoError(json.NewDecoder(response.Body).Decode(&info))
+		return info
+	}
+
+	// Plain members can't pin by default
+	response := suite.do("PUT", fmt.Sprintf(pinUrl, chat.ID, message.Id), "TestPinMember", nil)
+	suite.Equal(http.StatusForbidden, response.StatusCode)
+
+	response = suite.do("PUT", fmt.Sprintf(pinUrl, chat.ID, message.Id), "TestPinOwner", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	response = suite.do("PUT", fmt.Sprintf(pinUrl, chat.ID, message.Id), "TestPinOwner", nil)
+	suite.Equal(http.StatusConflict, response.StatusCode)
+
+	pinned := chatInfo().PinnedMessages
+	suite.Len(pinned, 1)
+	suite.Equal(message.Id, pinned[0].Message.Id)
+	suite.Equal("TestPinMember", pinned[0].Message.SenderUsername)
+	suite.Equal("TestPinOwner", pinned[0].PinnedBy)
+
+	// Pinning shows up in the history as a system message, after the one
+	// about the member joining
+	response = suite.do("GET", fmt.Sprintf(historyUrl, chat.ID), "TestPinMember", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	var history dto.MessageHistoryResponse
+	suite.NoError(json.NewDecoder(response.Body).Decode(&history))
+	suite.Len(history.Messages, 3)
+	suite.Equal("user", history.Messages[1].Type)
+	suite.Equal("system", history.Messages[2].Type)
+	suite.Equal("message_pinned", history.Messages[2].Event.Action)
+	suite.Equal(message.Id, history.Messages[2].Event.MessageId)
+
+	response = suite.do("PUT", fmt.Sprintf(pinUrl, chat.ID, history.Messages[2].Id), "TestPinOwner", nil)
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+
+	response = suite.do("DELETE", fmt.Sprintf(pinUrl, chat.ID, message.Id), "TestPinOwner", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	response = suite.do("DELETE", fmt.Sprintf(pinUrl, chat.ID, message.Id), "TestPinOwner", nil)
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+	suite.Empty(chatInfo().PinnedMessages)
+}
+
+func (suite *AppTestSuite) TestMentions() {
+	mentionsUrl := "http://127.0.0.1:8000/messenger/mentions"
+	readUrl := "http://127.0.0.1:8000/messenger/mentions/read"
+
+	chatService := services.NewChatService(settings.AppVar)
+	chatMemberService := services.NewChatMemberService(settings.AppVar)
+	messageService := services.NewMessageService(settings.AppVar)
+	userRepository := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestMentionOwner", "TestMentionMember")
+	owner, err := userRepository.GetByUsername(suite.Ctx, "TestMentionOwner")
+	suite.NoError(err)
+	member, err := userRepository.GetByUsername(suite.Ctx, "TestMentionMember")
+	suite.NoError(err)
+
+	chat, err := chatService.CreateChat(suite.Ctx, dto.CreateChatRequest{Title: "TestMentions", Description: "TestMentions"}, owner.ToDTO())
+	suite.NoError(err)
+	suite.NoError(chatMemberService.CreateMember(suite.Ctx, member.ToDTO(), chat.ID, nil))
+
+	inbox := func(username string) dto.MentionListResponse {
+		response := suite.do("GET", mentionsUrl, username, nil)
+		suite.Equal(http.StatusOK, response.StatusCode)
+		var mentions dto.MentionListResponse
+		suite.NoError(json.NewDecoder(response.Body).Decode(&mentions))
+		return mentions
+	}
+
+	message, err := messageService.SendMessage(suite.Ctx, member.ToDTO(), dto.SendMessageRequest{Message: "ping @TestMentionOwner and @nobody"}, chat.ID)
+	suite.NoError(err)
+	suite.Equal([]dto.MentionEntityDTO{{Username: "TestMentionOwner", Offset: 5, Length: 17}}, message.Mentions)
+
+	mentions := inbox("TestMentionOwner")
+	suite.Equal(int64(1), mentions.UnreadCount)
+	suite.Len(mentions.Mentions, 1)
+	suite.Equal(message.Id, mentions.Mentions[0].Message.Id)
+	suite.Equal("TestMentions", mentions.Mentions[0].ChatTitle)
+	suite.False(mentions.Mentions[0].IsRead)
+
+	// Only admins can mention everyone
+	_, err = messageService.SendMessage(suite.Ctx, member.ToDTO(), dto.SendMessageRequest{Message: "@all hello"}, chat.ID)
+	suite.Error(err)
+	_, err = messageService.SendMessage(suite.Ctx, owner.ToDTO(), dto.SendMessageRequest{Message: "@all standup"}, chat.ID)
+	suite.NoError(err)
+
+	mentions = inbox("TestMentionMember")
+	suite.Equal(int64(1), mentions.UnreadCount)
+	suite.Len(mentions.Mentions, 1)
+
+	response := suite.do("POST", readUrl, "TestMentionOwner", nil)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	mentions = inbox("TestMentionOwner")
+	suite.Equal(int64(0), mentions.UnreadCount)
+	suite.True(mentions.Mentions[0].IsRead)
+}
+
+func (suite *AppTestSuite) TestSearchMessages() {
+	searchUrl := "http://127.0.0.1:8000/messenger/messages/search?"
+
+	chatService := services.NewChatService(settings.AppVar)
+	chatMemberService := services.NewChatMemberService(settings.AppVar)
+	messageService := services.NewMessageService(settings.AppVar)
+	userRepository := repositories.NewUserRepository(settings.AppVar)
+
+	suite.login("TestSearchOwner", "TestSearchMember", "TestSearchStranger")
+	owner, err := userRepository.GetByUsername(suite.Ctx, "TestSearchOwner")
+	suite.NoError(err)
+	member, err := userRepository.GetByUsername(suite.Ctx, "TestSearchMember")
+	suite.NoError(err)
+	stranger, err := userRepository.GetByUsername(suite.Ctx, "TestSearchStranger")
+	suite.NoError(err)
+
+	chat, err := chatService.CreateChat(suite.Ctx, dto.CreateChatRequest{Title: "TestSearchMessages", Description: "TestSearchMessages"}, owner.ToDTO())
+	suite.NoError(err)
+	suite.NoError(chatMemberService.CreateMember(suite.Ctx, member.ToDTO(), chat.ID, nil))
+	otherChat, err := chatService.CreateChat(suite.Ctx, dto.CreateChatRequest{Title: "TestSearchElsewhere", Description: "TestSearchElsewhere"}, stranger.ToDTO())
+	suite.NoError(err)
+
+	for _, send := range []struct {
+		sender dto.UserDTO
+		chatId int64
+		text   string
+	}{
+		{owner.ToDTO(), chat.ID, "the zanzibar plan is ready"},
+		{member.ToDTO(), chat.ID, "Zanzibar again"},
+		{member.ToDTO(), chat.ID, "nothing to see here"},
+		{stranger.ToDTO(), otherChat.ID, "secret zanzibar notes"},
+	} {
+		_, err = messageService.SendMessage(suite.Ctx, send.sender, dto.SendMessageRequest{Message: send.text}, send.chatId)
+		suite.NoError(err)
+	}
+
+	search := func(params url.Values) (int, dto.MessageSearchResponse) {
+		response := suite.do("GET", searchUrl+params.Encode(), "TestSearchOwner", nil)
+		var results dto.MessageSearchResponse
+		if response.StatusCode == http.StatusOK {
+			suite.NoError(json.NewDecoder(response.Body).Decode(&results))
+		}
+		return response.StatusCode, results
+	}
+
+	// Messages of chats the caller is not in are never found
+	status, results := search(url.Values{"q": {"zanzibar"}})
+	suite.Equal(http.StatusOK, status)
+	suite.Len(results.Results, 2)
+	suite.Equal("Zanzibar again", results.Results[0].Snippet)
+	suite.Equal([]dto.TextRangeDTO{{Offset: 0, Length: 8}}, results.Results[0].Highlights)
+	suite.Equal("TestSearchMessages", results.Results[0].ChatTitle)
+	suite.Empty(results.NextCursor)
+
+	status, results = search(url.Values{"q": {"zanzibar"}, "sender": {"TestSearchOwner"}})
+	suite.Equal(http.StatusOK, status)
+	suite.Len(results.Results, 1)
+	suite.Equal("TestSearchOwner", results.Results[0].Message.SenderUsername)
+
+	status, results = search(url.Values{"q": {"zanzibar"}, "limit": {"1"}})
+	suite.Equal(http.StatusOK, status)
+	suite.Len(results.Results, 1)
+	suite.NotEmpty(results.NextCursor)
+	status, results = search(url.Values{"q": {"zanzibar"}, "limit": {"1"}, "cursor": {results.NextCursor}})
+	suite.Equal(http.StatusOK, status)
+	suite.Len(results.Results, 1)
+	suite.Equal("the zanzibar plan is ready", results.Results[0].Message.Content)
+
+	status, results = search(url.Values{"q": {"zanzibar"}, "from": {time.Now().Add(time.Hour).Format(time.RFC3339)}})
+	suite.Equal(http.StatusOK, status)
+	suite.Empty(results.Results)
+
+	status, _ = search(url.Values{"q": {"zanzibar"}, "chat_id": {fmt.Sprint(otherChat.ID)}})
+	suite.Equal(http.StatusBadRequest, status)
+}
@@ -1,9 +1,15 @@
 package integration
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/redis/go-redis/v9"
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	"libs/src/internal/repositories"
+	services "libs/src/internal/usecase"
 	"libs/src/settings"
 	"libs/src/settings/server"
 	"net/http"
@@ -18,6 +24,8 @@ type AppTestSuite struct {
 	suite.Suite
 	Ctx    context.Context
 	client *http.Client
+	// sessions of the users logged in with login, by username
+	sessions map[string]string
 }
 
 func (suite *AppTestSuite) SetupSuite() {
@@ -51,6 +59,7 @@ func (suite *AppTestSuite) SetupSuite() {
 	})
 
 	mail := gomail.Dialer{}
+	hub := settings.NewHub(baseCfg, logger)
 
 	app := settings.NewApp(
 		db,
@@ -59,9 +68,15 @@ func (suite *AppTestSuite) SetupSuite() {
 		mongo,
 		redisDB,
 		&mail,
+		hub,
+		settings.NewBroker(baseCfg, redisDB, hub),
+		settings.NewStorage(baseCfg),
 	)
 	settings.AppVar = app
 	settings.MakeMigrations(settings.AppVar)
+	repositories.CreateIndexes(settings.AppVar)
+
+	go settings.AppVar.Broker.Run(ctx)
 
 	go func() {
 		server.RunServer()
@@ -70,9 +85,34 @@ func (suite *AppTestSuite) SetupSuite() {
 	time.Sleep(500 * time.Millisecond)
 
 	suite.client = &http.Client{}
+	suite.sessions = map[string]string{}
 	suite.Ctx = ctx
 }
 
+// login creates a superuser for each username and opens a session for it
+func (suite *AppTestSuite) login(usernames ...string) {
+	userService := services.NewUserService(settings.AppVar)
+	authService := services.NewAuthService(settings.AppVar)
+
+	for _, username := range usernames {
+		suite.NoError(userService.CreateSuperUser(suite.Ctx, username, username+"@test.com", "test123"))
+		sess, err := authService.Login(suite.Ctx, dto.UserDTO{ID: 1, Role: enums.ANONYMOUS, IsActive: false}, dto.LoginRequest{UsernameOrEmail: username, Password: "test123"})
+		suite.NoError(err)
+		suite.sessions[username] = sess
+	}
+}
+
+// do sends the request on behalf of a user logged in with login, the body is
+// encoded as JSON
+func (suite *AppTestSuite) do(method, url, username string, body any) *http.Response {
+	data, _ := json.Marshal(body)
+	request, _ := http.NewRequest(method, url, bytes.NewBuffer(data))
+	request.AddCookie(&http.Cookie{Name: "sessionID", Value: suite.sessions[username]})
+	response, err := suite.client.Do(request)
+	suite.NoError(err)
+	return response
+}
+
 func TestAppTestSuite(t *testing.T) {
 	suite.Run(t, new(AppTestSuite))
 }
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
 	"libs/src/internal/domain/enums"
 	"libs/src/internal/dto"
@@ -11,6 +13,7 @@ import (
 	"libs/src/settings"
 	"mime/multipart"
 	"net/http"
+	"strings"
 )
 
 func (suite *AppTestSuite) TestGetProfile() {
@@ -131,3 +134,74 @@ func (suite *AppTestSuite) TestResetPassword() {
 	suite.NoError(err)
 	suite.Equal(http.StatusNotFound, badResetPassResult.StatusCode)
 }
+
+func (suite *AppTestSuite) TestServeAvatar() {
+	urlGetProfile := "http://127.0.0.1:8000/accounts/profile/"
+	url := "http://127.0.0.1:8000/accounts/profile/edit"
+
+	userService := services.NewUserService(settings.AppVar)
+	authService := services.NewAuthService(settings.AppVar)
+
+	suite.NoError(userService.CreateSuperUser(suite.Ctx, "TestServeAvatar", "TestServeAvatar@test.com", "test123"))
+	sess, err := authService.Login(suite.Ctx, dto.UserDTO{ID: 1, Role: enums.ANONYMOUS, IsActive: false}, dto.LoginRequest{UsernameOrEmail: "TestServeAvatar", Password: "test123"})
+	suite.NoError(err)
+
+	avatar := image.NewRGBA(image.Rect(0, 0, 600, 600))
+	var encoded bytes.Buffer
+	suite.NoError(png.Encode(&encoded, avatar))
+
+	var changeBody bytes.Buffer
+	writer := multipart.NewWriter(&changeBody)
+	part, err := writer.CreateFormFile("new_image", "avatar.png")
+	suite.NoError(err)
+	_, err = part.Write(encoded.Bytes())
+	suite.NoError(err)
+	suite.NoError(writer.Close())
+
+	request, _ := http.NewRequest("PATCH", url, &changeBody)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	request.AddCookie(&http.Cookie{Name: "sessionID", Value: sess})
+	response, err := suite.client.Do(request)
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, response.StatusCode)
+
+	response, err = suite.client.Get(urlGetProfile + "TestServeAvatar")
+	suite.NoError(err)
+	var profile dto.UserProfile
+	suite.NoError(json.NewDecoder(response.Body).Decode(&profile))
+	suite.True(strings.HasPrefix(profile.Image, "http://127.0.0.1:8000/avatars/"), profile.Image)
+
+	// Anyone can get it, and cache it forever
+	response, err = suite.client.Get(profile.Image)
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal("image/png", response.Header.Get("Content-Type"))
+	suite.Contains(response.Header.Get("Cache-Control"), "immutable")
+	etag := response.Header.Get("ETag")
+	suite.NotEmpty(etag)
+	content, err := io.ReadAll(response.Body)
+	suite.NoError(err)
+	thumbnail, err := png.DecodeConfig(bytes.NewReader(content))
+	suite.NoError(err)
+	suite.Equal(enums.AVATAR_LARGE, thumbnail.Width)
+
+	request, _ = http.NewRequest("GET", profile.Image, nil)
+	request.Header.Set("If-None-Match", etag)
+	response, err = suite.client.Do(request)
+	suite.NoError(err)
+	suite.Equal(http.StatusNotModified, response.StatusCode)
+
+	request, _ = http.NewRequest("GET", profile.Image, nil)
+	request.Header.Set("Range", "bytes=0-9")
+	response, err = suite.client.Do(request)
+	suite.NoError(err)
+	suite.Equal(http.StatusPartialContent, response.StatusCode)
+	partial, err := io.ReadAll(response.Body)
+	suite.NoError(err)
+	suite.Equal(content[:10], partial)
+
+	// Attachments stay behind their signed links
+	response, err = suite.client.Get("http://127.0.0.1:8000/avatars/../attachments/1/file")
+	suite.NoError(err)
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
@@ -0,0 +1,248 @@
+package unit
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"libs/src/internal/domain/enums"
+	domain "libs/src/internal/domain/models"
+	"libs/src/internal/dto"
+	"libs/src/internal/mocks"
+	"libs/src/internal/repositories"
+	services "libs/src/internal/usecase"
+	usecase_errors "libs/src/internal/usecase/errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateInvite(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatInviteService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "admin", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		caller  dto.UserDTO
+		request dto.CreateInviteRequest
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		expectedErr error
+		mustErr     bool
+	}{
+		{
+			testName:    "Anonymous",
+			caller:      dto.UserDTO{Role: enums.ANONYMOUS},
+			expectedErr: usecase_errors.UnauthorizedError{},
+			mustErr:     true,
+		},
+		{
+			testName:         "Not a member",
+			caller:           caller,
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedErr:      usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Direct chat",
+			caller:            caller,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.DIRECT, MemberRole: enums.OWNER},
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Plain member",
+			caller:            caller,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER},
+			expectedErr:       usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Unlimited",
+			caller:            caller,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			mustErr:           false,
+		},
+		{
+			testName:          "Limited",
+			caller:            caller,
+			request:           dto.CreateInviteRequest{ExpiresIn: 3600, MaxUses: 5},
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			mustErr:           false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockInviteRepo := new(mocks.IChatInviteRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.ChatInviteRepository = mockInviteRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr).Maybe()
+			mockInviteRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+
+			resp, err := service.CreateInvite(mockApp.Ctx, tc.caller, 1, tc.request)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				mockInviteRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, resp.Token)
+			assert.True(t, strings.HasSuffix(resp.Link, "/messenger/invite/"+resp.Token))
+			assert.Equal(t, caller.Username, resp.Creator)
+			assert.Equal(t, tc.request.MaxUses, resp.MaxUses)
+			assert.True(t, resp.IsActive)
+			if tc.request.ExpiresIn == 0 {
+				assert.Nil(t, resp.ExpiresAt)
+			} else {
+				assert.WithinDuration(t, time.Now().Add(time.Hour), *resp.ExpiresAt, time.Minute)
+			}
+		})
+	}
+}
+
+func TestRedeemInvite(t *testing.T) {
+	mockApp := GetAppMock()
+
+	caller := dto.UserDTO{ID: 2, Username: "guest", Role: enums.USER, IsActive: true}
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		testName string
+
+		caller dto.UserDTO
+
+		GetByTokenResp domain.ChatInvite
+		GetByTokenErr  error
+
+		ConsumeErr error
+		CountResp  int64
+
+		expectedErr     error
+		mustErr         bool
+		expectedRelease bool
+	}{
+		{
+			testName:    "Anonymous",
+			caller:      dto.UserDTO{Role: enums.ANONYMOUS},
+			expectedErr: usecase_errors.UnauthorizedError{},
+			mustErr:     true,
+		},
+		{
+			testName:      "Unknown token",
+			caller:        caller,
+			GetByTokenErr: repositories.ErrRecordNotFound,
+			expectedErr:   usecase_errors.NotFoundError{},
+			mustErr:       true,
+		},
+		{
+			testName:       "Revoked",
+			caller:         caller,
+			GetByTokenResp: domain.ChatInvite{ChatID: 1, RevokedAt: &past},
+			expectedErr:    usecase_errors.NotFoundError{},
+			mustErr:        true,
+		},
+		{
+			testName:       "Expired",
+			caller:         caller,
+			GetByTokenResp: domain.ChatInvite{ChatID: 1, ExpiresAt: &past},
+			expectedErr:    usecase_errors.NotFoundError{},
+			mustErr:        true,
+		},
+		{
+			testName:       "Used up",
+			caller:         caller,
+			GetByTokenResp: domain.ChatInvite{ChatID: 1, MaxUses: 3, Uses: 3},
+			expectedErr:    usecase_errors.NotFoundError{},
+			mustErr:        true,
+		},
+		{
+			testName:       "Used up concurrently",
+			caller:         caller,
+			GetByTokenResp: domain.ChatInvite{ChatID: 1, MaxUses: 3, Uses: 2},
+			ConsumeErr:     repositories.ErrRecordNotFound,
+			expectedErr:    usecase_errors.NotFoundError{},
+			mustErr:        true,
+		},
+		{
+			testName:        "Already a member",
+			caller:          caller,
+			GetByTokenResp:  domain.ChatInvite{ChatID: 1, ExpiresAt: &future},
+			CountResp:       1,
+			expectedErr:     usecase_errors.AlreadyExistsError{},
+			mustErr:         true,
+			expectedRelease: true,
+		},
+		{
+			testName:       "Database error",
+			caller:         caller,
+			GetByTokenResp: domain.ChatInvite{ChatID: 1},
+			ConsumeErr:     errors.New("internal db err"),
+			expectedErr:    errors.New(""),
+			mustErr:        true,
+		},
+		{
+			testName:       "Success",
+			caller:         caller,
+			GetByTokenResp: domain.ChatInvite{ChatID: 1, MaxUses: 3, Uses: 2, ExpiresAt: &future},
+			mustErr:        false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockInviteRepo := new(mocks.IChatInviteRepository)
+		mockChatRepo := new(mocks.IChatRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service := services.ChatInviteService{
+			App:                  mockApp,
+			ChatInviteRepository: mockInviteRepo,
+			ChatRepository:       mockChatRepo,
+			ChatMemberRepository: mockChatMemberRepo,
+			ChatMemberService: &services.ChatMemberService{
+				App:                   mockApp,
+				ChatMemberRepository:  mockChatMemberRepo,
+				RestrictionRepository: unrestricted(),
+				MessageRepository:     messageSink(),
+			},
+		}
+
+		t.Run(tc.testName, func(t *testing.T) {
+			tc.GetByTokenResp.ID = 7
+			mockInviteRepo.EXPECT().GetByToken(mockApp.Ctx, "token").Return(tc.GetByTokenResp, tc.GetByTokenErr).Maybe()
+			mockChatRepo.EXPECT().GetById(mockApp.Ctx, int64(1)).Return(domain.Chat{BaseModel: domain.BaseModel{ID: 1}, Title: "Invited"}, nil).Maybe()
+			mockInviteRepo.EXPECT().Consume(mockApp.Ctx, int64(7)).Return(tc.ConsumeErr).Maybe()
+			mockInviteRepo.EXPECT().Release(mockApp.Ctx, int64(7)).Return(nil).Maybe()
+			mockChatMemberRepo.EXPECT().Count(mockApp.Ctx, mock.Anything, int64(1), caller.ID).Return(tc.CountResp, nil).Maybe()
+			mockChatMemberRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+
+			resp, err := service.RedeemInvite(mockApp.Ctx, tc.caller, "token")
+
+			if tc.expectedRelease {
+				mockInviteRepo.AssertCalled(t, "Release", mockApp.Ctx, int64(7))
+			} else {
+				mockInviteRepo.AssertNotCalled(t, "Release", mockApp.Ctx, int64(7))
+			}
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				mockChatMemberRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), resp.ID)
+			mockChatMemberRepo.AssertCalled(t, "Create", mockApp.Ctx, mock.Anything)
+		})
+	}
+}
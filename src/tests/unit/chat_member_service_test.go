@@ -22,17 +22,30 @@ func TestCreateMember(t *testing.T) {
 	}
 
 	dbErr := errors.New("internal db err")
+	admin := dto.UserDTO{ID: 2, Username: "admin"}
 
 	testCases := []struct {
 		testName       string
 		userId         int64
 		chatId         int64
+		addedBy        *dto.UserDTO
 		RepoCountResp  int64
 		RepoCountErr   error
 		RepoCreateResp error
+		banned         bool
 		expectedResp   error
+		expectedEvent  domain.SystemEvent
+		expectedSender int64
 		mustErr        bool
 	}{
+		{
+			testName:     "User is banned",
+			userId:       1,
+			chatId:       1,
+			banned:       true,
+			expectedResp: usecase_errors.PermissionError{},
+			mustErr:      true,
+		},
 		{
 			testName:      "User already in chat",
 			userId:        1,
@@ -50,29 +63,53 @@ func TestCreateMember(t *testing.T) {
 			mustErr:      true,
 		},
 		{
-			testName: "Success",
-			userId:   1,
-			chatId:   1,
-			mustErr:  false,
+			testName:       "Success",
+			userId:         1,
+			chatId:         1,
+			expectedEvent:  domain.SystemEvent{Action: enums.SYSTEM_MEMBER_JOINED},
+			expectedSender: 1,
+			mustErr:        false,
+		},
+		{
+			testName:       "Added by an admin",
+			userId:         1,
+			chatId:         1,
+			addedBy:        &admin,
+			expectedEvent:  domain.SystemEvent{Action: enums.SYSTEM_MEMBER_ADDED, TargetId: 1, Target: "joiner"},
+			expectedSender: admin.ID,
+			mustErr:        false,
 		},
 	}
 
 	for _, tc := range testCases {
 		mockRepository := new(mocks.IChatMemberRepository)
+		mockMessageRepo := new(mocks.IMessageRepository)
 		service.ChatMemberRepository = mockRepository
+		service.MessageRepository = mockMessageRepo
+		service.RestrictionRepository = unrestricted()
+		if tc.banned {
+			mockRestrictionRepo := new(mocks.IChatRestrictionRepository)
+			mockRestrictionRepo.EXPECT().GetActive(mockApp.Ctx, tc.chatId, tc.userId, byte(enums.BAN)).Return(domain.ChatRestriction{Type: enums.BAN}, nil)
+			service.RestrictionRepository = mockRestrictionRepo
+		}
 
 		t.Run(tc.testName, func(t *testing.T) {
-			mockRepository.EXPECT().Count(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Return(tc.RepoCountResp, tc.RepoCountErr)
-			mockRepository.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(tc.RepoCreateResp)
+			mockRepository.EXPECT().Count(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Return(tc.RepoCountResp, tc.RepoCountErr).Maybe()
+			mockRepository.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(tc.RepoCreateResp).Maybe()
+			mockMessageRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
 
-			err := service.CreateMember(mockApp.Ctx, tc.userId, tc.chatId)
+			err := service.CreateMember(mockApp.Ctx, dto.UserDTO{ID: tc.userId, Username: "joiner"}, tc.chatId, tc.addedBy)
 
 			if tc.mustErr {
 				assert.Error(t, err)
 				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
 			} else {
 				assert.NoError(t, err)
-
+				mockMessageRepo.AssertCalled(t, "Create", mockApp.Ctx, mock.MatchedBy(func(message *domain.Message) bool {
+					return message.Type == enums.SYSTEM_MESSAGE && message.SenderId == tc.expectedSender &&
+						message.ChatId == tc.chatId && *message.Event == tc.expectedEvent
+				}))
 			}
 		})
 	}
@@ -80,7 +117,8 @@ func TestCreateMember(t *testing.T) {
 func TestInviteToChat(t *testing.T) {
 	mockApp := GetAppMock()
 	service := services.ChatMemberService{
-		App: mockApp,
+		App:               mockApp,
+		MessageRepository: messageSink(),
 	}
 
 	testCases := []struct {
@@ -96,8 +134,14 @@ func TestInviteToChat(t *testing.T) {
 		GetByUsernameResp domain.User
 		GetByUsernameErr  error
 
-		expectedResp error
-		mustErr      bool
+		MemberCountResp  int64
+		DirectCountResp  int64
+		CreateInvitedErr error
+		CreateMemberErr  error
+
+		expectedResp   error
+		expectedStatus string
+		mustErr        bool
 	}{
 		{
 			testName:         "Inviter not in chat",
@@ -159,6 +203,40 @@ func TestInviteToChat(t *testing.T) {
 			expectedResp: usecase_errors.NotFoundError{},
 			mustErr:      true,
 		},
+		{
+			testName:        "Invitee is already a member",
+			inviterId:       1,
+			inviteeUsername: "invitee",
+			chatId:          1,
+			GetMemberInfoResp: dto.MemberInfo{
+				MemberRole: enums.CHAT_ADMIN,
+			},
+			GetByUsernameResp: domain.User{
+				Username: "invitee",
+				Role:     enums.USER,
+				IsActive: true,
+			},
+			MemberCountResp: 1,
+			expectedResp:    usecase_errors.AlreadyExistsError{},
+			mustErr:         true,
+		},
+		{
+			testName:        "Invitee is already invited",
+			inviterId:       1,
+			inviteeUsername: "invitee",
+			chatId:          1,
+			GetMemberInfoResp: dto.MemberInfo{
+				MemberRole: enums.CHAT_ADMIN,
+			},
+			GetByUsernameResp: domain.User{
+				Username: "invitee",
+				Role:     enums.USER,
+				IsActive: true,
+			},
+			CreateInvitedErr: repositories.ErrDuplicate,
+			expectedResp:     usecase_errors.AlreadyExistsError{},
+			mustErr:          true,
+		},
 		{
 			testName:        "Success",
 			inviterId:       1,
@@ -172,41 +250,211 @@ func TestInviteToChat(t *testing.T) {
 				Role:     enums.USER,
 				IsActive: true,
 			},
-			mustErr: false,
+			expectedStatus: "pending",
+			mustErr:        false,
+		},
+		{
+			testName:        "Contacts only, inviter is a stranger",
+			inviterId:       1,
+			inviteeUsername: "invitee",
+			chatId:          1,
+			GetMemberInfoResp: dto.MemberInfo{
+				MemberRole: enums.CHAT_ADMIN,
+			},
+			GetByUsernameResp: domain.User{
+				Username:   "invitee",
+				Role:       enums.USER,
+				IsActive:   true,
+				DirectAdds: enums.DIRECT_ADDS_CONTACTS,
+			},
+			expectedStatus: "pending",
+			mustErr:        false,
+		},
+		{
+			testName:        "Contacts only, inviter is a contact",
+			inviterId:       1,
+			inviteeUsername: "invitee",
+			chatId:          1,
+			GetMemberInfoResp: dto.MemberInfo{
+				MemberRole: enums.CHAT_ADMIN,
+			},
+			GetByUsernameResp: domain.User{
+				Username:   "invitee",
+				Role:       enums.USER,
+				IsActive:   true,
+				DirectAdds: enums.DIRECT_ADDS_CONTACTS,
+			},
+			DirectCountResp: 1,
+			expectedStatus:  "accepted",
+			mustErr:         false,
+		},
+		{
+			testName:        "Contacts only, adding the member fails",
+			inviterId:       1,
+			inviteeUsername: "invitee",
+			chatId:          1,
+			GetMemberInfoResp: dto.MemberInfo{
+				MemberRole: enums.CHAT_ADMIN,
+			},
+			GetByUsernameResp: domain.User{
+				Username:   "invitee",
+				Role:       enums.USER,
+				IsActive:   true,
+				DirectAdds: enums.DIRECT_ADDS_CONTACTS,
+			},
+			DirectCountResp: 1,
+			CreateMemberErr: repositories.ErrDuplicate,
+			expectedResp:    repositories.ErrDuplicate,
+			mustErr:         true,
 		},
 	}
 
 	for _, tc := range testCases {
 		mockChatMemberRepo := new(mocks.IChatMemberRepository)
 		mockUserRepo := new(mocks.IUserRepository)
+		mockChatRepo := new(mocks.IChatRepository)
+		mockInvitationRepo := new(mocks.IChatInvitationRepository)
 		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = unrestricted()
 		service.UserRepository = mockUserRepo
+		service.ChatRepository = mockChatRepo
+		service.ChatInvitationRepository = mockInvitationRepo
 
 		t.Run(tc.testName, func(t *testing.T) {
 			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, mock.Anything, mock.Anything).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr)
 			mockUserRepo.EXPECT().GetByUsername(mockApp.Ctx, mock.Anything).Return(tc.GetByUsernameResp, tc.GetByUsernameErr)
+			mockChatRepo.EXPECT().Count(mockApp.Ctx, "direct_key = ?", mock.Anything).Return(tc.DirectCountResp, nil).Maybe()
+			mockInvitationRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(tc.CreateInvitedErr).Maybe()
+			mockInvitationRepo.EXPECT().DeleteById(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
 
 			// Mocking the CreateMember method
-			mockChatMemberRepo.EXPECT().Count(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
-			mockChatMemberRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil)
+			mockChatMemberRepo.EXPECT().Count(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Return(tc.MemberCountResp, nil).Maybe()
+			mockChatMemberRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(tc.CreateMemberErr).Maybe()
 
-			err := service.InviteToChat(mockApp.Ctx, &dto.UserDTO{ID: tc.inviterId, Role: enums.USER, IsActive: true}, tc.inviteeUsername, tc.chatId)
+			resp, err := service.InviteToChat(mockApp.Ctx, &dto.UserDTO{ID: tc.inviterId, Role: enums.USER, IsActive: true}, tc.inviteeUsername, tc.chatId)
 
 			if tc.mustErr {
 				assert.Error(t, err)
 				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				if tc.CreateMemberErr != nil {
+					mockInvitationRepo.AssertCalled(t, "DeleteById", mockApp.Ctx, mock.Anything)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, resp.Status)
+			assert.Equal(t, tc.inviteeUsername, resp.Invitee)
+			mockInvitationRepo.AssertNotCalled(t, "DeleteById", mockApp.Ctx, mock.Anything)
+			if tc.expectedStatus == "accepted" {
+				mockChatMemberRepo.AssertCalled(t, "Create", mockApp.Ctx, mock.Anything)
 			} else {
-				assert.NoError(t, err)
+				mockChatMemberRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App:               mockApp,
+		MessageRepository: messageSink(),
+	}
+
+	caller := dto.UserDTO{ID: 2, Username: "invitee", Role: enums.USER, IsActive: true}
+	invitation := domain.ChatInvitation{
+		BaseModel: domain.BaseModel{ID: 5},
+		ChatID:    1,
+		InviterID: 1,
+		InviteeID: caller.ID,
+		Chat:      domain.Chat{BaseModel: domain.BaseModel{ID: 1}, Title: "Invited"},
+		Inviter:   domain.User{BaseModel: domain.BaseModel{ID: 1}, Username: "inviter", Role: enums.USER, IsActive: true},
+	}
+
+	testCases := []struct {
+		testName string
+
+		caller dto.UserDTO
+
+		GetPendingErr   error
+		MemberCountResp int64
+		RespondErr      error
+
+		expectedErr error
+		mustErr     bool
+	}{
+		{
+			testName:    "Anonymous",
+			caller:      dto.UserDTO{Role: enums.ANONYMOUS},
+			expectedErr: usecase_errors.UnauthorizedError{},
+			mustErr:     true,
+		},
+		{
+			testName:      "Not invited",
+			caller:        caller,
+			GetPendingErr: repositories.ErrRecordNotFound,
+			expectedErr:   usecase_errors.NotFoundError{},
+			mustErr:       true,
+		},
+		{
+			testName:    "Answered concurrently",
+			caller:      caller,
+			RespondErr:  repositories.ErrRecordNotFound,
+			expectedErr: usecase_errors.NotFoundError{},
+			mustErr:     true,
+		},
+		{
+			testName:        "Joined in the meantime",
+			caller:          caller,
+			MemberCountResp: 1,
+			mustErr:         false,
+		},
+		{
+			testName: "Success",
+			caller:   caller,
+			mustErr:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockInvitationRepo := new(mocks.IChatInvitationRepository)
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = unrestricted()
+		service.ChatInvitationRepository = mockInvitationRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockInvitationRepo.EXPECT().GetPending(mockApp.Ctx, int64(5), caller.ID).Return(invitation, tc.GetPendingErr).Maybe()
+			mockInvitationRepo.EXPECT().Respond(mockApp.Ctx, int64(5), caller.ID, byte(enums.INVITATION_ACCEPTED)).Return(tc.RespondErr).Maybe()
+			mockChatMemberRepo.EXPECT().Count(mockApp.Ctx, mock.Anything, int64(1), caller.ID).Return(tc.MemberCountResp, nil).Maybe()
+			mockChatMemberRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+
+			resp, err := service.AcceptInvitation(mockApp.Ctx, tc.caller, 5)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Invited", resp.Title)
+			mockInvitationRepo.AssertCalled(t, "Respond", mockApp.Ctx, int64(5), caller.ID, byte(enums.INVITATION_ACCEPTED))
 		})
 	}
 }
+
 func TestChangeMemberRole(t *testing.T) {
 	mockApp := GetAppMock()
 	service := services.ChatMemberService{
-		App: mockApp,
+		App:               mockApp,
+		MessageRepository: messageSink(),
 	}
 
+	roleManager := enums.SEND_MESSAGES | enums.MANAGE_ROLES
+	moderatorRank := 50
+	moderator := domain.ChatRole{BaseModel: domain.BaseModel{ID: 7}, ChatID: 1, Name: "moderator", Rank: moderatorRank, Permissions: roleManager}
+	senior := domain.ChatRole{BaseModel: domain.BaseModel{ID: 8}, ChatID: 1, Name: "senior", Rank: 60}
+
 	testCases := []struct {
 		testName string
 
@@ -216,6 +464,8 @@ func TestChangeMemberRole(t *testing.T) {
 		memberId   int64
 		newRole    string
 
+		GetRoleByNameResp domain.ChatRole
+
 		GetMemberInfoCallerResp dto.MemberInfo
 		GetMemberInfoCallerErr  error
 
@@ -228,6 +478,7 @@ func TestChangeMemberRole(t *testing.T) {
 		SetNewRoleResp error
 
 		expectedResp error
+		roleChanged  bool
 		mustErr      bool
 	}{
 		{
@@ -359,10 +610,100 @@ func TestChangeMemberRole(t *testing.T) {
 				Role:     enums.USER,
 			},
 			GetMemberInfoTargetResp: dto.MemberInfo{
-				MemberRole: enums.ADMIN,
+				MemberRole: enums.CHAT_ADMIN,
 			},
 			mustErr: false,
 		},
+		{
+			testName:   "Owner demotes themself",
+			callerId:   1,
+			chatId:     1,
+			memberId:   1,
+			targetName: "userTest",
+			newRole:    "admin",
+			GetMemberInfoCallerResp: dto.MemberInfo{
+				MemberRole: enums.OWNER,
+			},
+			GetByUsernameResp: domain.User{
+				Username: "userTest",
+				IsActive: true,
+				Role:     enums.USER,
+			},
+			GetMemberInfoTargetResp: dto.MemberInfo{
+				MemberRole: enums.OWNER,
+			},
+			expectedResp: usecase_errors.PermissionError{},
+			mustErr:      true,
+		},
+		{
+			testName:   "Admin hands out their own role",
+			callerId:   1,
+			chatId:     1,
+			memberId:   2,
+			targetName: "userTest",
+			newRole:    "admin",
+			GetMemberInfoCallerResp: dto.MemberInfo{
+				MemberRole:  enums.CHAT_ADMIN,
+				Permissions: &roleManager,
+			},
+			GetByUsernameResp: domain.User{
+				Username: "userTest",
+				IsActive: true,
+				Role:     enums.USER,
+			},
+			GetMemberInfoTargetResp: dto.MemberInfo{
+				MemberRole: enums.MEMBER,
+			},
+			expectedResp: usecase_errors.PermissionError{},
+			mustErr:      true,
+		},
+		{
+			testName:          "Moderator gives a role ranked above them",
+			callerId:          1,
+			chatId:            1,
+			memberId:          2,
+			targetName:        "userTest",
+			newRole:           "Senior",
+			GetRoleByNameResp: senior,
+			GetMemberInfoCallerResp: dto.MemberInfo{
+				MemberRole:  enums.MEMBER,
+				RoleName:    moderator.Name,
+				Rank:        &moderatorRank,
+				Permissions: &roleManager,
+			},
+			GetByUsernameResp: domain.User{
+				Username: "userTest",
+				IsActive: true,
+				Role:     enums.USER,
+			},
+			GetMemberInfoTargetResp: dto.MemberInfo{
+				MemberRole: enums.MEMBER,
+			},
+			expectedResp: usecase_errors.PermissionError{},
+			mustErr:      true,
+		},
+		{
+			testName:          "Owner gives a custom role",
+			callerId:          1,
+			chatId:            1,
+			memberId:          2,
+			targetName:        "userTest",
+			newRole:           "moderator",
+			GetRoleByNameResp: moderator,
+			GetMemberInfoCallerResp: dto.MemberInfo{
+				MemberRole: enums.OWNER,
+			},
+			GetByUsernameResp: domain.User{
+				Username: "userTest",
+				IsActive: true,
+				Role:     enums.USER,
+			},
+			GetMemberInfoTargetResp: dto.MemberInfo{
+				MemberRole: enums.CHAT_ADMIN,
+			},
+			roleChanged: true,
+			mustErr:     false,
+		},
 		{
 			testName:   "Success",
 			callerId:   1,
@@ -381,30 +722,49 @@ func TestChangeMemberRole(t *testing.T) {
 			GetMemberInfoTargetResp: dto.MemberInfo{
 				MemberRole: enums.MEMBER,
 			},
-			mustErr: false,
+			roleChanged: true,
+			mustErr:     false,
 		},
 	}
 
 	for _, tc := range testCases {
 		mockChatMemberRepo := new(mocks.IChatMemberRepository)
 		mockUserRepo := new(mocks.IUserRepository)
+		mockChatRoleRepo := new(mocks.IChatRoleRepository)
+		mockMessageRepo := messageSink()
 		service.ChatMemberRepository = mockChatMemberRepo
 		service.UserRepository = mockUserRepo
+		service.ChatRoleRepository = mockChatRoleRepo
+		service.MessageRepository = mockMessageRepo
 
 		t.Run(tc.testName, func(t *testing.T) {
+			if tc.GetRoleByNameResp.ID != 0 {
+				mockChatRoleRepo.EXPECT().GetByName(mockApp.Ctx, tc.chatId, tc.GetRoleByNameResp.Name).Return(tc.GetRoleByNameResp, nil)
+			} else {
+				mockChatRoleRepo.EXPECT().GetByName(mockApp.Ctx, tc.chatId, mock.Anything).Return(domain.ChatRole{}, repositories.ErrRecordNotFound).Maybe()
+			}
 			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, tc.callerId, tc.chatId).Return(tc.GetMemberInfoCallerResp, tc.GetMemberInfoCallerErr)
 			mockUserRepo.EXPECT().GetByUsername(mockApp.Ctx, mock.Anything).Return(tc.GetByUsernameResp, tc.GetByUsernameErr)
 			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, mock.Anything, mock.Anything).Return(tc.GetMemberInfoTargetResp, tc.GetMemberInfoTargetErr)
 
-			mockChatMemberRepo.EXPECT().SetNewRole(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Return(tc.SetNewRoleResp)
+			mockChatMemberRepo.EXPECT().SetNewRole(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.SetNewRoleResp)
 
 			err := service.ChangeMemberRole(mockApp.Ctx, dto.UserDTO{ID: tc.callerId}, tc.chatId, tc.targetName, tc.newRole)
 
 			if tc.mustErr {
 				assert.Error(t, err)
 				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
 			} else {
 				assert.NoError(t, err)
+				if tc.roleChanged {
+					mockMessageRepo.AssertCalled(t, "Create", mockApp.Ctx, mock.MatchedBy(func(message *domain.Message) bool {
+						return message.Type == enums.SYSTEM_MESSAGE && message.Event.Action == enums.SYSTEM_ROLE_CHANGED && message.Event.Value == tc.newRole
+					}))
+				}
+				if tc.GetRoleByNameResp.ID != 0 {
+					mockChatMemberRepo.AssertCalled(t, "SetNewRole", mockApp.Ctx, tc.chatId, mock.Anything, byte(enums.MEMBER), &tc.GetRoleByNameResp.ID, mock.Anything)
+				}
 			}
 		})
 	}
@@ -412,7 +772,8 @@ func TestChangeMemberRole(t *testing.T) {
 func TestGetMemberList(t *testing.T) {
 	mockApp := GetAppMock()
 	service := services.ChatMemberService{
-		App: mockApp,
+		App:               mockApp,
+		MessageRepository: messageSink(),
 	}
 
 	testCases := []struct {
@@ -524,3 +885,898 @@ func TestGetMemberList(t *testing.T) {
 		})
 	}
 }
+
+func TestJoinChat(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App:               mockApp,
+		MessageRepository: messageSink(),
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "joiner", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		caller dto.UserDTO
+
+		GetByIdResp domain.Chat
+		GetByIdErr  error
+
+		CountResp int64
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:     "Anonymous",
+			caller:       dto.UserDTO{Role: enums.ANONYMOUS},
+			expectedResp: usecase_errors.UnauthorizedError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Chat not found",
+			caller:       caller,
+			GetByIdErr:   repositories.ErrRecordNotFound,
+			expectedResp: usecase_errors.NotFoundError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Direct chat",
+			caller:       caller,
+			GetByIdResp:  domain.Chat{Type: enums.DIRECT, Visibility: enums.PUBLIC},
+			expectedResp: usecase_errors.NotFoundError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Private chat",
+			caller:       caller,
+			GetByIdResp:  domain.Chat{Type: enums.GROUP, Visibility: enums.PRIVATE},
+			expectedResp: usecase_errors.PermissionError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Already a member",
+			caller:       caller,
+			GetByIdResp:  domain.Chat{Type: enums.GROUP, Visibility: enums.PUBLIC},
+			CountResp:    1,
+			expectedResp: usecase_errors.AlreadyExistsError{},
+			mustErr:      true,
+		},
+		{
+			testName:    "Success",
+			caller:      caller,
+			GetByIdResp: domain.Chat{Type: enums.GROUP, Visibility: enums.PUBLIC},
+			mustErr:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatRepo := new(mocks.IChatRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.ChatRepository = mockChatRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = unrestricted()
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatRepo.EXPECT().GetById(mockApp.Ctx, int64(1)).Return(tc.GetByIdResp, tc.GetByIdErr).Maybe()
+			mockChatMemberRepo.EXPECT().Count(mockApp.Ctx, mock.Anything, int64(1), caller.ID).Return(tc.CountResp, nil).Maybe()
+			mockChatMemberRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+
+			err := service.JoinChat(mockApp.Ctx, tc.caller, 1)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockChatMemberRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockChatMemberRepo.AssertCalled(t, "Create", mockApp.Ctx, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRequestToJoin(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App:               mockApp,
+		MessageRepository: messageSink(),
+	}
+
+	caller := dto.UserDTO{ID: 2, Username: "requester", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		caller dto.UserDTO
+
+		GetByIdResp domain.Chat
+		GetByIdErr  error
+
+		CountResp int64
+		CreateErr error
+
+		expectedErr error
+		mustErr     bool
+	}{
+		{
+			testName:    "Anonymous",
+			caller:      dto.UserDTO{Role: enums.ANONYMOUS},
+			expectedErr: usecase_errors.UnauthorizedError{},
+			mustErr:     true,
+		},
+		{
+			testName:    "Chat not found",
+			caller:      caller,
+			GetByIdErr:  repositories.ErrRecordNotFound,
+			expectedErr: usecase_errors.NotFoundError{},
+			mustErr:     true,
+		},
+		{
+			testName:    "Direct chat",
+			caller:      caller,
+			GetByIdResp: domain.Chat{Type: enums.DIRECT},
+			expectedErr: usecase_errors.NotFoundError{},
+			mustErr:     true,
+		},
+		{
+			testName:    "Public chat",
+			caller:      caller,
+			GetByIdResp: domain.Chat{Type: enums.GROUP, Visibility: enums.PUBLIC},
+			expectedErr: usecase_errors.BadRequestError{},
+			mustErr:     true,
+		},
+		{
+			testName:    "Already a member",
+			caller:      caller,
+			GetByIdResp: domain.Chat{Type: enums.GROUP, Visibility: enums.PRIVATE},
+			CountResp:   1,
+			expectedErr: usecase_errors.AlreadyExistsError{},
+			mustErr:     true,
+		},
+		{
+			testName:    "Already requested",
+			caller:      caller,
+			GetByIdResp: domain.Chat{Type: enums.GROUP, Visibility: enums.PRIVATE},
+			CreateErr:   repositories.ErrDuplicate,
+			expectedErr: usecase_errors.AlreadyExistsError{},
+			mustErr:     true,
+		},
+		{
+			testName:    "Success",
+			caller:      caller,
+			GetByIdResp: domain.Chat{Type: enums.GROUP, Visibility: enums.PRIVATE, Title: "Private"},
+			mustErr:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatRepo := new(mocks.IChatRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockJoinRequestRepo := new(mocks.IJoinRequestRepository)
+		service.ChatRepository = mockChatRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = unrestricted()
+		service.JoinRequestRepository = mockJoinRequestRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatRepo.EXPECT().GetById(mockApp.Ctx, int64(1)).Return(tc.GetByIdResp, tc.GetByIdErr).Maybe()
+			mockChatMemberRepo.EXPECT().Count(mockApp.Ctx, mock.Anything, int64(1), caller.ID).Return(tc.CountResp, nil).Maybe()
+			mockJoinRequestRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(tc.CreateErr).Maybe()
+
+			resp, err := service.RequestToJoin(mockApp.Ctx, tc.caller, 1)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "pending", resp.Status)
+			assert.Equal(t, "Private", resp.ChatTitle)
+			assert.Equal(t, caller.Username, resp.Username)
+		})
+	}
+}
+
+func TestReviewJoinRequest(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App:               mockApp,
+		MessageRepository: messageSink(),
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "admin", Role: enums.USER, IsActive: true}
+	pending := domain.JoinRequest{BaseModel: domain.BaseModel{ID: 3}, ChatID: 1, UserID: 2, User: domain.User{BaseModel: domain.BaseModel{ID: 2}, Username: "guest"}}
+
+	testCases := []struct {
+		testName string
+
+		approve bool
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		GetPendingErr error
+		ReviewErr     error
+		banned        bool
+
+		expectedErr   error
+		mustErr       bool
+		expectsMember bool
+	}{
+		{
+			testName:         "Not a member",
+			approve:          true,
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedErr:      usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Plain member",
+			approve:           true,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER},
+			expectedErr:       usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Approve missing request",
+			approve:           true,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			GetPendingErr:     repositories.ErrRecordNotFound,
+			expectedErr:       usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Approve",
+			approve:           true,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			mustErr:           false,
+			expectsMember:     true,
+		},
+		{
+			testName:          "Approve banned requester",
+			approve:           true,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			banned:            true,
+			expectedErr:       usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Reject reviewed request",
+			approve:           false,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			ReviewErr:         repositories.ErrRecordNotFound,
+			expectedErr:       usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Reject",
+			approve:           false,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			mustErr:           false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockJoinRequestRepo := new(mocks.IJoinRequestRepository)
+		mockRestrictionRepo := new(mocks.IChatRestrictionRepository)
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = mockRestrictionRepo
+		service.JoinRequestRepository = mockJoinRequestRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			ban, banErr := domain.ChatRestriction{}, repositories.ErrRecordNotFound
+			if tc.banned {
+				ban, banErr = domain.ChatRestriction{ChatID: 1, UserID: 2, Type: enums.BAN}, nil
+			}
+			mockRestrictionRepo.EXPECT().GetActive(mockApp.Ctx, int64(1), int64(2), byte(enums.BAN)).Return(ban, banErr).Maybe()
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr)
+			mockJoinRequestRepo.EXPECT().GetPending(mockApp.Ctx, int64(3), int64(1)).Return(pending, tc.GetPendingErr).Maybe()
+			mockJoinRequestRepo.EXPECT().Review(mockApp.Ctx, int64(3), int64(1), caller.ID, mock.Anything, mock.Anything).Return(tc.ReviewErr).Maybe()
+			mockChatMemberRepo.EXPECT().Count(mockApp.Ctx, mock.Anything, int64(1), int64(2)).Return(0, nil).Maybe()
+			mockChatMemberRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+
+			var err error
+			if tc.approve {
+				err = service.ApproveJoinRequest(mockApp.Ctx, caller, 1, 3)
+			} else {
+				err = service.RejectJoinRequest(mockApp.Ctx, caller, 1, 3, "Not now")
+			}
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			if tc.expectsMember {
+				mockChatMemberRepo.AssertCalled(t, "Create", mockApp.Ctx, mock.Anything)
+				mockJoinRequestRepo.AssertCalled(t, "Review", mockApp.Ctx, int64(3), int64(1), caller.ID, byte(enums.JOIN_REQUEST_APPROVED), "")
+			} else {
+				mockChatMemberRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+			}
+			if !tc.approve && !tc.mustErr {
+				mockJoinRequestRepo.AssertCalled(t, "Review", mockApp.Ctx, int64(3), int64(1), caller.ID, byte(enums.JOIN_REQUEST_REJECTED), "Not now")
+			}
+			if tc.banned {
+				mockJoinRequestRepo.AssertCalled(t, "Review", mockApp.Ctx, int64(3), int64(1), caller.ID, byte(enums.JOIN_REQUEST_REJECTED), "banned")
+			}
+		})
+	}
+}
+
+func TestTransferOwnership(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "owner", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		GetByUsernameResp domain.User
+		GetByUsernameErr  error
+
+		TransferErr error
+
+		expectedErr error
+		mustErr     bool
+	}{
+		{
+			testName:         "Not a member",
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedErr:      usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Direct chat",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.DIRECT, MemberRole: enums.MEMBER},
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Admin is not the owner",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			expectedErr:       usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Target not found",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			GetByUsernameErr:  repositories.ErrRecordNotFound,
+			expectedErr:       usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Transfer to themself",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			GetByUsernameResp: domain.User{BaseModel: domain.BaseModel{ID: 1}, Role: enums.USER, IsActive: true},
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Target not in chat",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			GetByUsernameResp: domain.User{BaseModel: domain.BaseModel{ID: 2}, Role: enums.USER, IsActive: true},
+			TransferErr:       repositories.ErrRecordNotFound,
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Success",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			GetByUsernameResp: domain.User{BaseModel: domain.BaseModel{ID: 2}, Role: enums.USER, IsActive: true},
+			mustErr:           false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatRepo := new(mocks.IChatRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockUserRepo := new(mocks.IUserRepository)
+		mockMessageRepo := new(mocks.IMessageRepository)
+		service.ChatRepository = mockChatRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.UserRepository = mockUserRepo
+		service.MessageRepository = mockMessageRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr)
+			mockUserRepo.EXPECT().GetByUsername(mockApp.Ctx, "heir").Return(tc.GetByUsernameResp, tc.GetByUsernameErr).Maybe()
+			mockChatRepo.EXPECT().TransferOwnership(mockApp.Ctx, int64(1), caller.ID, int64(2), mock.Anything).Return(tc.TransferErr).Maybe()
+			mockMessageRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+
+			err := service.TransferOwnership(mockApp.Ctx, caller, 1, "heir")
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockChatRepo.AssertCalled(t, "TransferOwnership", mockApp.Ctx, int64(1), caller.ID, int64(2), mock.MatchedBy(func(entry *domain.ChatAuditEntry) bool {
+					return entry.Action == enums.AUDIT_OWNER_CHANGED && entry.ActorID == caller.ID
+				}))
+				mockMessageRepo.AssertCalled(t, "Create", mockApp.Ctx, mock.MatchedBy(func(message *domain.Message) bool {
+					return message.Type == enums.SYSTEM_MESSAGE && message.SenderId == caller.ID &&
+						message.Event.Action == enums.SYSTEM_OWNER_CHANGED && message.Event.TargetId == int64(2)
+				}))
+			}
+		})
+	}
+}
+
+func TestLeaveChat(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App:               mockApp,
+		MessageRepository: messageSink(),
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "leaver", Role: enums.USER, IsActive: true}
+	heir := domain.User{BaseModel: domain.BaseModel{ID: 2}, Username: "heir", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		request dto.LeaveChatRequest
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		expectedErr     error
+		mustErr         bool
+		expectsLeave    bool
+		expectsTransfer bool
+		expectsDelete   bool
+	}{
+		{
+			testName:         "Not a member",
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedErr:      usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Direct chat",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.DIRECT, MemberRole: enums.MEMBER},
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Member leaves",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER},
+			mustErr:           false,
+			expectsLeave:      true,
+		},
+		{
+			testName:          "Owner without a choice",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Owner transfers without a new owner",
+			request:           dto.LeaveChatRequest{OwnerAction: "transfer"},
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Owner transfers and leaves",
+			request:           dto.LeaveChatRequest{OwnerAction: "transfer", NewOwner: "heir"},
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			mustErr:           false,
+			expectsLeave:      true,
+			expectsTransfer:   true,
+		},
+		{
+			testName:          "Owner deletes the chat",
+			request:           dto.LeaveChatRequest{OwnerAction: "delete"},
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			mustErr:           false,
+			expectsDelete:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatRepo := new(mocks.IChatRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockUserRepo := new(mocks.IUserRepository)
+		service.ChatRepository = mockChatRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.UserRepository = mockUserRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr)
+			mockChatMemberRepo.EXPECT().DeleteMember(mockApp.Ctx, caller.ID, int64(1), mock.Anything).Return(nil).Maybe()
+			mockUserRepo.EXPECT().GetByUsername(mockApp.Ctx, "heir").Return(heir, nil).Maybe()
+			mockChatRepo.EXPECT().TransferOwnership(mockApp.Ctx, int64(1), caller.ID, heir.ID, mock.Anything).Return(nil).Maybe()
+			mockChatRepo.EXPECT().DeleteChat(mockApp.Ctx, int64(1), mock.Anything).Return(nil).Maybe()
+
+			err := service.LeaveChat(mockApp.Ctx, caller, 1, tc.request)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			if tc.expectsLeave {
+				mockChatMemberRepo.AssertCalled(t, "DeleteMember", mockApp.Ctx, caller.ID, int64(1), mock.Anything)
+			} else {
+				mockChatMemberRepo.AssertNotCalled(t, "DeleteMember", mockApp.Ctx, caller.ID, int64(1), mock.Anything)
+			}
+			if tc.expectsTransfer {
+				mockChatRepo.AssertCalled(t, "TransferOwnership", mockApp.Ctx, int64(1), caller.ID, heir.ID, mock.Anything)
+			} else {
+				mockChatRepo.AssertNotCalled(t, "TransferOwnership", mockApp.Ctx, int64(1), caller.ID, heir.ID, mock.Anything)
+			}
+			if tc.expectsDelete {
+				mockChatRepo.AssertCalled(t, "DeleteChat", mockApp.Ctx, int64(1), mock.Anything)
+			} else {
+				mockChatRepo.AssertNotCalled(t, "DeleteChat", mockApp.Ctx, int64(1), mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRestrict(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App:               mockApp,
+		MessageRepository: messageSink(),
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "admin", Role: enums.USER, IsActive: true}
+	target := domain.User{BaseModel: domain.BaseModel{ID: 2}, Username: "target", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		restrictionType byte
+		request         dto.RestrictMemberRequest
+
+		CallerInfoResp dto.MemberInfo
+		CallerInfoErr  error
+
+		TargetInfoResp dto.MemberInfo
+		TargetInfoErr  error
+
+		expectedRemoval bool
+		expectedErr     error
+		mustErr         bool
+	}{
+		{
+			testName:        "Caller is a member",
+			restrictionType: enums.BAN,
+			CallerInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER},
+			expectedErr:     usecase_errors.PermissionError{},
+			mustErr:         true,
+		},
+		{
+			testName:        "Direct chat",
+			restrictionType: enums.BAN,
+			CallerInfoResp:  dto.MemberInfo{ChatType: enums.DIRECT, MemberRole: enums.MEMBER},
+			expectedErr:     usecase_errors.BadRequestError{},
+			mustErr:         true,
+		},
+		{
+			testName:        "Mute without duration",
+			restrictionType: enums.MUTE,
+			CallerInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			expectedErr:     usecase_errors.BadRequestError{},
+			mustErr:         true,
+		},
+		{
+			testName:        "Mute of a user not in chat",
+			restrictionType: enums.MUTE,
+			request:         dto.RestrictMemberRequest{Duration: 3600},
+			CallerInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			TargetInfoErr:   repositories.ErrRecordNotFound,
+			expectedErr:     usecase_errors.BadRequestError{},
+			mustErr:         true,
+		},
+		{
+			testName:        "Admin bans another admin",
+			restrictionType: enums.BAN,
+			CallerInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			TargetInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			expectedErr:     usecase_errors.PermissionError{},
+			mustErr:         true,
+		},
+		{
+			testName:        "Ban of a user not in chat",
+			restrictionType: enums.BAN,
+			CallerInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			TargetInfoErr:   repositories.ErrRecordNotFound,
+			mustErr:         false,
+		},
+		{
+			testName:        "Ban removes the member",
+			restrictionType: enums.BAN,
+			request:         dto.RestrictMemberRequest{Reason: "spam", Duration: 86400},
+			CallerInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			TargetInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			expectedRemoval: true,
+			mustErr:         false,
+		},
+		{
+			testName:        "Mute",
+			restrictionType: enums.MUTE,
+			request:         dto.RestrictMemberRequest{Duration: 3600},
+			CallerInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			TargetInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER},
+			mustErr:         false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockUserRepo := new(mocks.IUserRepository)
+		mockRestrictionRepo := new(mocks.IChatRestrictionRepository)
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.UserRepository = mockUserRepo
+		service.RestrictionRepository = mockRestrictionRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.CallerInfoResp, tc.CallerInfoErr)
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, target.ID, int64(1)).Return(tc.TargetInfoResp, tc.TargetInfoErr).Maybe()
+			mockChatMemberRepo.EXPECT().DeleteMember(mockApp.Ctx, target.ID, int64(1), mock.Anything).Return(nil).Maybe()
+			mockUserRepo.EXPECT().GetByUsername(mockApp.Ctx, target.Username).Return(target, nil).Maybe()
+			mockRestrictionRepo.EXPECT().Upsert(mockApp.Ctx, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			resp, err := service.Restrict(mockApp.Ctx, caller, 1, target.Username, tc.restrictionType, tc.request)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				mockRestrictionRepo.AssertNotCalled(t, "Upsert", mockApp.Ctx, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, enums.RestrictionTypesToLabels[int(tc.restrictionType)], resp.Type)
+				assert.Equal(t, tc.request.Duration != 0, resp.ExpiresAt != nil)
+				expectedAction := byte(enums.AUDIT_MEMBER_MUTED)
+				if tc.restrictionType == enums.BAN {
+					expectedAction = enums.AUDIT_MEMBER_BANNED
+				}
+				mockRestrictionRepo.AssertCalled(t, "Upsert", mockApp.Ctx, mock.Anything, mock.MatchedBy(func(entry *domain.ChatAuditEntry) bool {
+					return entry.Action == expectedAction && *entry.TargetID == target.ID && entry.After["reason"] == tc.request.Reason
+				}))
+				if tc.expectedRemoval {
+					mockChatMemberRepo.AssertCalled(t, "DeleteMember", mockApp.Ctx, target.ID, int64(1), (*domain.ChatAuditEntry)(nil))
+				} else {
+					mockChatMemberRepo.AssertNotCalled(t, "DeleteMember", mockApp.Ctx, target.ID, int64(1), mock.Anything)
+				}
+			}
+		})
+	}
+}
+
+func TestLiftRestriction(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "admin", Role: enums.USER, IsActive: true}
+	target := domain.User{BaseModel: domain.BaseModel{ID: 2}, Username: "target", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		CallerInfoResp dto.MemberInfo
+		LiftErr        error
+
+		expectedErr error
+		mustErr     bool
+	}{
+		{
+			testName:       "Caller is a member",
+			CallerInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER},
+			expectedErr:    usecase_errors.PermissionError{},
+			mustErr:        true,
+		},
+		{
+			testName:       "Not restricted",
+			CallerInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			LiftErr:        repositories.ErrRecordNotFound,
+			expectedErr:    usecase_errors.NotFoundError{},
+			mustErr:        true,
+		},
+		{
+			testName:       "Success",
+			CallerInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			mustErr:        false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockUserRepo := new(mocks.IUserRepository)
+		mockRestrictionRepo := new(mocks.IChatRestrictionRepository)
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.UserRepository = mockUserRepo
+		service.RestrictionRepository = mockRestrictionRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.CallerInfoResp, nil)
+			mockUserRepo.EXPECT().GetByUsername(mockApp.Ctx, target.Username).Return(target, nil).Maybe()
+			mockRestrictionRepo.EXPECT().Lift(mockApp.Ctx, int64(1), target.ID, byte(enums.MUTE), mock.Anything).Return(tc.LiftErr).Maybe()
+
+			err := service.LiftRestriction(mockApp.Ctx, caller, 1, target.Username, enums.MUTE)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				mockRestrictionRepo.AssertCalled(t, "Lift", mockApp.Ctx, int64(1), target.ID, byte(enums.MUTE), mock.MatchedBy(func(entry *domain.ChatAuditEntry) bool {
+					return entry.Action == enums.AUDIT_RESTRICTION_LIFTED && entry.Before["type"] == "mute"
+				}))
+			}
+		})
+	}
+}
+
+func TestChangeRolePermissions(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App:               mockApp,
+		MessageRepository: messageSink(),
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "owner", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		role    string
+		request dto.ChangeRolePermissionsRequest
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		expectedBefore      int
+		expectedPermissions int
+		expectedErr         error
+		mustErr             bool
+	}{
+		{
+			testName:    "Unknown role",
+			role:        "moderator",
+			expectedErr: usecase_errors.BadRequestError{},
+			mustErr:     true,
+		},
+		{
+			testName:    "Owner permissions",
+			role:        "owner",
+			expectedErr: usecase_errors.BadRequestError{},
+			mustErr:     true,
+		},
+		{
+			testName:          "Admin is not the owner",
+			role:              "member",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			expectedErr:       usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Direct chat",
+			role:              "member",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.DIRECT, MemberRole: enums.MEMBER},
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Unknown permission",
+			role:              "member",
+			request:           dto.ChangeRolePermissionsRequest{Permissions: []string{"fly"}},
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:            "Read-only members",
+			role:                "member",
+			request:             dto.ChangeRolePermissionsRequest{Permissions: []string{}},
+			GetMemberInfoResp:   dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			expectedBefore:      enums.SEND_MESSAGES,
+			expectedPermissions: 0,
+			mustErr:             false,
+		},
+		{
+			testName:            "Admins edit the chat",
+			role:                "Admin",
+			request:             dto.ChangeRolePermissionsRequest{Permissions: []string{"send_messages", "edit_chat", "pin"}},
+			GetMemberInfoResp:   dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			expectedBefore:      enums.DefaultRolePermissions[enums.CHAT_ADMIN],
+			expectedPermissions: enums.SEND_MESSAGES | enums.EDIT_CHAT | enums.PIN_MESSAGES,
+			mustErr:             false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockRolePermissionRepo := new(mocks.IChatRolePermissionRepository)
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RolePermissionRepository = mockRolePermissionRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr).Maybe()
+			mockRolePermissionRepo.EXPECT().GetForChat(mockApp.Ctx, int64(1)).Return([]domain.ChatRolePermission{{ChatID: 1, Role: enums.MEMBER, Permissions: enums.SEND_MESSAGES}}, nil).Maybe()
+			mockRolePermissionRepo.EXPECT().Upsert(mockApp.Ctx, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			err := service.ChangeRolePermissions(mockApp.Ctx, caller, 1, tc.role, tc.request)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				mockRolePermissionRepo.AssertNotCalled(t, "Upsert", mockApp.Ctx, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockRolePermissionRepo.AssertCalled(t, "Upsert", mockApp.Ctx, mock.MatchedBy(func(permission *domain.ChatRolePermission) bool {
+					return permission.ChatID == 1 && permission.Permissions == tc.expectedPermissions
+				}), mock.MatchedBy(func(entry *domain.ChatAuditEntry) bool {
+					return entry.Action == enums.AUDIT_PERMISSIONS_CHANGED && entry.Before["permissions"] == tc.expectedBefore && entry.After["permissions"] == tc.expectedPermissions
+				}))
+			}
+		})
+	}
+}
+
+func TestResetRolePermissions(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatMemberService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "owner", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		GetForChatResp []domain.ChatRolePermission
+		ResetErr       error
+
+		expectedBefore int
+	}{
+		{
+			testName:       "Not customised",
+			ResetErr:       repositories.ErrRecordNotFound,
+			expectedBefore: enums.DefaultRolePermissions[enums.MEMBER],
+		},
+		{
+			testName:       "Customised",
+			GetForChatResp: []domain.ChatRolePermission{{ChatID: 1, Role: enums.MEMBER, Permissions: 0}},
+			expectedBefore: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockRolePermissionRepo := new(mocks.IChatRolePermissionRepository)
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RolePermissionRepository = mockRolePermissionRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER}, nil)
+			mockRolePermissionRepo.EXPECT().GetForChat(mockApp.Ctx, int64(1)).Return(tc.GetForChatResp, nil)
+			mockRolePermissionRepo.EXPECT().Reset(mockApp.Ctx, int64(1), byte(enums.MEMBER), mock.Anything).Return(tc.ResetErr)
+
+			err := service.ResetRolePermissions(mockApp.Ctx, caller, 1, "member")
+
+			assert.NoError(t, err)
+			mockRolePermissionRepo.AssertCalled(t, "Reset", mockApp.Ctx, int64(1), byte(enums.MEMBER), mock.MatchedBy(func(entry *domain.ChatAuditEntry) bool {
+				return entry.Action == enums.AUDIT_PERMISSIONS_CHANGED && entry.Before["permissions"] == tc.expectedBefore && entry.After["permissions"] == enums.DefaultRolePermissions[enums.MEMBER]
+			}))
+		})
+	}
+}
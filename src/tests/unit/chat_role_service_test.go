@@ -0,0 +1,241 @@
+package unit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"libs/src/internal/domain/enums"
+	domain "libs/src/internal/domain/models"
+	"libs/src/internal/dto"
+	"libs/src/internal/mocks"
+	"libs/src/internal/repositories"
+	services "libs/src/internal/usecase"
+	usecase_errors "libs/src/internal/usecase/errors"
+	"reflect"
+	"testing"
+)
+
+func TestCreateChatRole(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatRoleService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "owner", Role: enums.USER, IsActive: true}
+	owner := dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER}
+
+	testCases := []struct {
+		testName string
+
+		request dto.CreateChatRoleRequest
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		CreateErr error
+
+		expectedName string
+		expectedErr  error
+		mustErr      bool
+	}{
+		{
+			testName:         "Not a member",
+			request:          dto.CreateChatRoleRequest{Name: "moderator", Rank: 50},
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedErr:      usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Admin is not the owner",
+			request:           dto.CreateChatRoleRequest{Name: "moderator", Rank: 50},
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			expectedErr:       usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Name of a built-in role",
+			request:           dto.CreateChatRoleRequest{Name: " Admin ", Rank: 50},
+			GetMemberInfoResp: owner,
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Unknown permission",
+			request:           dto.CreateChatRoleRequest{Name: "moderator", Rank: 50, Permissions: []string{"fly"}},
+			GetMemberInfoResp: owner,
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Name already taken",
+			request:           dto.CreateChatRoleRequest{Name: "moderator", Rank: 50},
+			GetMemberInfoResp: owner,
+			CreateErr:         repositories.ErrDuplicate,
+			expectedErr:       usecase_errors.AlreadyExistsError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Success",
+			request:           dto.CreateChatRoleRequest{Name: "Moderator", Rank: 50, Permissions: []string{"send_messages", "delete_messages"}},
+			GetMemberInfoResp: owner,
+			expectedName:      "moderator",
+			mustErr:           false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatRoleRepo := new(mocks.IChatRoleRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.ChatRoleRepository = mockChatRoleRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr)
+			mockChatRoleRepo.EXPECT().CreateRole(mockApp.Ctx, mock.Anything, mock.Anything).Return(tc.CreateErr).Maybe()
+
+			resp, err := service.CreateRole(mockApp.Ctx, caller, 1, tc.request)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedName, resp.Name)
+				assert.Equal(t, []string{"send_messages", "delete_messages"}, resp.Permissions)
+				mockChatRoleRepo.AssertCalled(t, "CreateRole", mockApp.Ctx, mock.MatchedBy(func(role *domain.ChatRole) bool {
+					return role.ChatID == 1 && role.Name == tc.expectedName && role.Permissions == enums.SEND_MESSAGES|enums.DELETE_MESSAGES
+				}), mock.MatchedBy(func(entry *domain.ChatAuditEntry) bool {
+					return entry.Action == enums.AUDIT_ROLE_CREATED && entry.After["name"] == tc.expectedName
+				}))
+			}
+		})
+	}
+}
+
+func TestChangeChatRole(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatRoleService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "owner", Role: enums.USER, IsActive: true}
+	role := domain.ChatRole{BaseModel: domain.BaseModel{ID: 7}, ChatID: 1, Name: "moderator", Rank: 50, Permissions: enums.SEND_MESSAGES}
+	newRank := 150
+
+	testCases := []struct {
+		testName string
+
+		request dto.ChangeChatRoleRequest
+
+		GetChatRoleErr error
+
+		expectUpdate bool
+		expectedErr  error
+		mustErr      bool
+	}{
+		{
+			testName:       "Role of another chat",
+			request:        dto.ChangeChatRoleRequest{Rank: &newRank},
+			GetChatRoleErr: repositories.ErrRecordNotFound,
+			expectedErr:    usecase_errors.NotFoundError{},
+			mustErr:        true,
+		},
+		{
+			testName:     "Nothing to change",
+			expectUpdate: false,
+			mustErr:      false,
+		},
+		{
+			testName:     "Success",
+			request:      dto.ChangeChatRoleRequest{Rank: &newRank, Permissions: []string{}},
+			expectUpdate: true,
+			mustErr:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatRoleRepo := new(mocks.IChatRoleRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.ChatRoleRepository = mockChatRoleRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER}, nil)
+			mockChatRoleRepo.EXPECT().GetChatRole(mockApp.Ctx, int64(1), role.ID).Return(role, tc.GetChatRoleErr)
+			mockChatRoleRepo.EXPECT().UpdateRole(mockApp.Ctx, role.ID, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			resp, err := service.ChangeRole(mockApp.Ctx, caller, 1, role.ID, tc.request)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				if tc.expectUpdate {
+					assert.Equal(t, newRank, resp.Rank)
+					assert.Empty(t, resp.Permissions)
+					mockChatRoleRepo.AssertCalled(t, "UpdateRole", mockApp.Ctx, role.ID, map[string]any{"rank": newRank, "permissions": 0}, mock.MatchedBy(func(entry *domain.ChatAuditEntry) bool {
+						return entry.Action == enums.AUDIT_ROLE_EDITED && entry.Before["rank"] == role.Rank && entry.After["rank"] == newRank && entry.After["permissions"] == 0
+					}))
+				} else {
+					mockChatRoleRepo.AssertNotCalled(t, "UpdateRole", mockApp.Ctx, role.ID, mock.Anything, mock.Anything)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteChatRole(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatRoleService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "owner", Role: enums.USER, IsActive: true}
+	role := domain.ChatRole{BaseModel: domain.BaseModel{ID: 7}, ChatID: 1, Name: "moderator", Rank: 50, Permissions: enums.SEND_MESSAGES}
+
+	testCases := []struct {
+		testName string
+
+		GetChatRoleErr error
+
+		expectedErr error
+		mustErr     bool
+	}{
+		{
+			testName:       "Role of another chat",
+			GetChatRoleErr: repositories.ErrRecordNotFound,
+			expectedErr:    usecase_errors.NotFoundError{},
+			mustErr:        true,
+		},
+		{
+			testName: "Success",
+			mustErr:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatRoleRepo := new(mocks.IChatRoleRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.ChatRoleRepository = mockChatRoleRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER}, nil)
+			mockChatRoleRepo.EXPECT().GetChatRole(mockApp.Ctx, int64(1), role.ID).Return(role, tc.GetChatRoleErr)
+			mockChatRoleRepo.EXPECT().DeleteRole(mockApp.Ctx, role.ID, mock.Anything).Return(nil).Maybe()
+
+			err := service.DeleteRole(mockApp.Ctx, caller, 1, role.ID)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				mockChatRoleRepo.AssertNotCalled(t, "DeleteRole", mockApp.Ctx, role.ID, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockChatRoleRepo.AssertCalled(t, "DeleteRole", mockApp.Ctx, role.ID, mock.MatchedBy(func(entry *domain.ChatAuditEntry) bool {
+					return entry.Action == enums.AUDIT_ROLE_DELETED && entry.Before["name"] == role.Name && entry.Before["rank"] == role.Rank && entry.Before["permissions"] == role.Permissions
+				}))
+			}
+		})
+	}
+}
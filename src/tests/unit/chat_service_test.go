@@ -1,8 +1,10 @@
 package unit
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"libs/src/internal/domain/enums"
 	domain "libs/src/internal/domain/models"
 	"libs/src/internal/dto"
@@ -143,6 +145,21 @@ func TestDeleteChat(t *testing.T) {
 			expectErr: usecase_errors.PermissionError{},
 			mustErr:   true,
 		},
+		{
+			testName: "TestDeleteChatDirect",
+			caller: dto.UserDTO{
+				ID:       1,
+				Role:     enums.USER,
+				IsActive: true,
+			},
+			chatID: 1,
+			GetByIdResp: domain.Chat{
+				OwnerID: 1,
+				Type:    enums.DIRECT,
+			},
+			expectErr: usecase_errors.PermissionError{},
+			mustErr:   true,
+		},
 		{
 			testName: "TestDeleteChatSuccess",
 			caller: dto.UserDTO{
@@ -164,7 +181,7 @@ func TestDeleteChat(t *testing.T) {
 
 		t.Run(tc.testName, func(t *testing.T) {
 			mockChatRepository.EXPECT().GetById(mockApp.Ctx, tc.chatID).Return(tc.GetByIdResp, tc.GetByIdErr)
-			mockChatRepository.EXPECT().DeleteById(mockApp.Ctx, tc.chatID).Maybe().Return(tc.DeleteResp)
+			mockChatRepository.EXPECT().DeleteChat(mockApp.Ctx, tc.chatID, mock.Anything).Maybe().Return(tc.DeleteResp)
 
 			err := service.DeleteChat(mockApp.Ctx, tc.caller, tc.chatID)
 
@@ -178,6 +195,92 @@ func TestDeleteChat(t *testing.T) {
 
 	}
 }
+func TestChangeChat(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true}
+	newTitle := "New title"
+	editor := enums.SEND_MESSAGES | enums.EDIT_CHAT
+
+	testCases := []struct {
+		testName string
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:         "Not a member",
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedResp:     usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Direct chat",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.DIRECT, MemberRole: enums.MEMBER},
+			expectedResp:      usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Admin without the permission",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			expectedResp:      usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Member allowed to edit the chat",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER, Permissions: &editor},
+			mustErr:           false,
+		},
+		{
+			testName:          "Owner",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.OWNER},
+			mustErr:           false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatRepository := new(mocks.IChatRepository)
+		mockChatMemberRepository := new(mocks.IChatMemberRepository)
+		mockMessageRepository := messageSink()
+		service.ChatRepository = mockChatRepository
+		service.ChatMemberRepository = mockChatMemberRepository
+		service.MessageRepository = mockMessageRepository
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepository.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr)
+			mockChatRepository.EXPECT().GetById(mockApp.Ctx, int64(1)).Return(domain.Chat{Title: "Old title"}, nil).Maybe()
+			mockChatRepository.EXPECT().UpdateChat(mockApp.Ctx, int64(1), mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			err := service.ChangeChat(mockApp.Ctx, caller, 1, dto.ChangeChatRequest{NewTitle: &newTitle})
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockChatRepository.AssertNotCalled(t, "UpdateChat", mockApp.Ctx, int64(1), mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockChatRepository.AssertCalled(t, "UpdateChat", mockApp.Ctx, int64(1), mock.Anything, &domain.ChatAuditEntry{
+					ChatID:  1,
+					ActorID: caller.ID,
+					Action:  enums.AUDIT_CHAT_EDITED,
+					Before:  map[string]any{"title": "Old title"},
+					After:   map[string]any{"title": newTitle},
+				})
+				mockMessageRepository.AssertCalled(t, "Create", mockApp.Ctx, mock.MatchedBy(func(message *domain.Message) bool {
+					return message.Type == enums.SYSTEM_MESSAGE && message.SenderId == caller.ID &&
+						message.Event.Action == enums.SYSTEM_TITLE_CHANGED && message.Event.Value == newTitle
+				}))
+			}
+		})
+	}
+}
+
 func TestGetChatListForUser(t *testing.T) {
 	mockApp := GetAppMock()
 	chatService := services.ChatService{
@@ -190,7 +293,8 @@ func TestGetChatListForUser(t *testing.T) {
 		page       int
 		RepoResp   []domain.Chat
 		RepoErr    error
-		expectResp []dto.ChatDTO
+		UnreadResp map[int64]int64
+		expectResp []dto.ChatListItemDTO
 		expectErr  error
 		mustErr    bool
 	}{
@@ -242,6 +346,7 @@ func TestGetChatListForUser(t *testing.T) {
 			page: 1,
 			RepoResp: []domain.Chat{
 				{
+					BaseModel:   domain.BaseModel{ID: 1},
 					Title:       "Test Chat 1",
 					Description: "Test Description 1",
 					OwnerID:     1,
@@ -260,26 +365,20 @@ func TestGetChatListForUser(t *testing.T) {
 					OwnerID:     18,
 				},
 			},
-			expectResp: []dto.ChatDTO{
+			UnreadResp: map[int64]int64{1: 5},
+			expectResp: []dto.ChatListItemDTO{
 				{
-					Title:       "Test Chat 1",
-					Description: "Test Description 1",
-					OwnerID:     1,
+					ChatDTO:     dto.ChatDTO{ID: 1, Title: "Test Chat 1", Description: "Test Description 1", OwnerID: 1},
+					UnreadCount: 5,
 				},
 				{
-					Title:       "Test Chat 2",
-					Description: "Test Description 1",
-					OwnerID:     2,
+					ChatDTO: dto.ChatDTO{Title: "Test Chat 2", Description: "Test Description 1", OwnerID: 2},
 				},
 				{
-					Title:       "Test Chat 3",
-					Description: "Test Description 1",
-					OwnerID:     123,
+					ChatDTO: dto.ChatDTO{Title: "Test Chat 3", Description: "Test Description 1", OwnerID: 123},
 				},
 				{
-					Title:       "Test Chat 4",
-					Description: "Test Description 1",
-					OwnerID:     18,
+					ChatDTO: dto.ChatDTO{Title: "Test Chat 4", Description: "Test Description 1", OwnerID: 18},
 				},
 			},
 			mustErr: false,
@@ -288,10 +387,16 @@ func TestGetChatListForUser(t *testing.T) {
 
 	for _, tc := range testCases {
 		mockChatRepository := new(mocks.IChatRepository)
+		mockChatMemberRepository := new(mocks.IChatMemberRepository)
+		mockMessageRepository := new(mocks.IMessageRepository)
 		chatService.ChatRepository = mockChatRepository
+		chatService.ChatMemberRepository = mockChatMemberRepository
+		chatService.MessageRepository = mockMessageRepository
 
 		t.Run(tc.testName, func(t *testing.T) {
 			mockChatRepository.EXPECT().GetListForUser(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Return(tc.RepoResp, tc.RepoErr)
+			mockChatMemberRepository.EXPECT().Filter(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Maybe().Return([]domain.ChatMember{}, nil)
+			mockMessageRepository.EXPECT().CountUnread(mockApp.Ctx, mock.Anything, mock.Anything).Maybe().Return(tc.UnreadResp, nil)
 
 			chats, err := chatService.GetListForUser(mockApp.Ctx, tc.caller, tc.page)
 
@@ -301,6 +406,9 @@ func TestGetChatListForUser(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, len(chats), len(tc.expectResp))
+				for i := range tc.expectResp {
+					assert.Equal(t, tc.expectResp[i].UnreadCount, chats[i].UnreadCount)
+				}
 			}
 		})
 	}
@@ -421,9 +529,15 @@ func TestSearchChat(t *testing.T) {
 
 	for _, tc := range testCases {
 		mockChatRepository := new(mocks.IChatRepository)
+		mockChatMemberRepository := new(mocks.IChatMemberRepository)
+		mockMessageRepository := new(mocks.IMessageRepository)
 		service.ChatRepository = mockChatRepository
+		service.ChatMemberRepository = mockChatMemberRepository
+		service.MessageRepository = mockMessageRepository
 
 		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepository.EXPECT().Filter(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Maybe().Return([]domain.ChatMember{}, nil)
+			mockMessageRepository.EXPECT().CountUnread(mockApp.Ctx, mock.Anything, mock.Anything).Maybe().Return(map[int64]int64{}, nil)
 			mockChatRepository.EXPECT().SearchForUser(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return(tc.RepoResp, tc.RepoErr)
 
 			chats, err := service.Search(mockApp.Ctx, tc.caller, tc.query, tc.page)
@@ -444,6 +558,12 @@ func TestGetChatById(t *testing.T) {
 		App: mockApp,
 	}
 
+	pinned := domain.NewMessageObject(2, 1, "Read the rules")
+	deleted := domain.NewMessageObject(2, 1, "Old rules")
+	deleted.IsDeleted = true
+	pinnedPreview := pinned.ToPreview("author")
+	pinnedPreview.Reactions = []dto.ReactionDTO{}
+
 	testCases := []struct {
 		testName    string
 		caller      dto.UserDTO
@@ -452,6 +572,8 @@ func TestGetChatById(t *testing.T) {
 		FilterErr   error
 		GetByIdResp domain.Chat
 		GetByIdErr  error
+		GetPinsResp []domain.ChatPin
+		GetAllResp  []domain.Message
 		expectResp  dto.ChatDTO
 		expectErr   error
 		mustErr     bool
@@ -522,9 +644,48 @@ func TestGetChatById(t *testing.T) {
 				OwnerID:     1,
 			},
 			expectResp: dto.ChatDTO{
+				Type:        "group",
+				Visibility:  "private",
 				Title:       "Test Chat 1",
 				Description: "Test Description 1",
 				OwnerID:     1,
+
+				PinnedMessages: []dto.PinnedMessageDTO{},
+			},
+			mustErr: false,
+		},
+		{
+			testName: "TestGetChatByIdWithPins",
+			caller: dto.UserDTO{
+				Role:     enums.USER,
+				IsActive: true,
+			},
+			chatId: 1,
+			FilterResp: []domain.ChatMember{
+				{
+					ChatID: 1,
+					UserID: 1,
+				},
+			},
+			GetByIdResp: domain.Chat{
+				Title:   "Test Chat 1",
+				OwnerID: 1,
+			},
+			GetPinsResp: []domain.ChatPin{
+				{MessageID: pinned.Id.Hex(), PinnedBy: domain.User{Username: "admin"}},
+				{MessageID: deleted.Id.Hex(), PinnedBy: domain.User{Username: "admin"}},
+				{MessageID: primitive.NewObjectID().Hex(), PinnedBy: domain.User{Username: "admin"}},
+			},
+			GetAllResp: []domain.Message{*pinned, *deleted},
+			expectResp: dto.ChatDTO{
+				Type:       "group",
+				Visibility: "private",
+				Title:      "Test Chat 1",
+				OwnerID:    1,
+
+				PinnedMessages: []dto.PinnedMessageDTO{
+					{Message: pinnedPreview, PinnedBy: "admin"},
+				},
 			},
 			mustErr: false,
 		},
@@ -533,12 +694,21 @@ func TestGetChatById(t *testing.T) {
 	for _, tc := range testCases {
 		mockChatRepository := new(mocks.IChatRepository)
 		mockChatMemberRepository := new(mocks.IChatMemberRepository)
+		mockPinRepository := new(mocks.IChatPinRepository)
+		mockMessageRepository := new(mocks.IMessageRepository)
+		mockUserRepository := new(mocks.IUserRepository)
 		service.ChatRepository = mockChatRepository
 		service.ChatMemberRepository = mockChatMemberRepository
+		service.PinRepository = mockPinRepository
+		service.MessageRepository = mockMessageRepository
+		service.UserRepository = mockUserRepository
 
 		t.Run(tc.testName, func(t *testing.T) {
 			mockChatMemberRepository.EXPECT().Filter(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Maybe().Return(tc.FilterResp, tc.FilterErr)
 			mockChatRepository.EXPECT().GetById(mockApp.Ctx, mock.Anything).Maybe().Return(tc.GetByIdResp, tc.GetByIdErr)
+			mockPinRepository.EXPECT().GetForChat(mockApp.Ctx, tc.chatId).Maybe().Return(tc.GetPinsResp, nil)
+			mockMessageRepository.EXPECT().GetAll(mockApp.Ctx, mock.Anything, int64(0), int64(len(tc.GetPinsResp))).Maybe().Return(tc.GetAllResp, nil)
+			mockUserRepository.EXPECT().Filter(mockApp.Ctx, "id IN ?", mock.Anything).Maybe().Return([]domain.User{{BaseModel: domain.BaseModel{ID: 2}, Username: "author"}}, nil)
 
 			chat, err := service.GetById(mockApp.Ctx, tc.caller, tc.chatId)
 
@@ -552,3 +722,303 @@ func TestGetChatById(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenDirect(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true}
+	peer := domain.User{BaseModel: domain.BaseModel{ID: 2}, Username: "peer", Role: enums.USER, IsActive: true}
+	directKey := domain.DirectChatKey(1, 2)
+	existing := domain.Chat{BaseModel: domain.BaseModel{ID: 10}, OwnerID: 2, Type: enums.DIRECT, DirectKey: &directKey}
+
+	testCases := []struct {
+		testName string
+
+		username string
+
+		GetByUsernameResp domain.User
+		GetByUsernameErr  error
+
+		FilterResp []domain.Chat
+
+		CreateDirectErr error
+
+		expectCreate bool
+		expectErr    error
+		mustErr      bool
+	}{
+		{
+			testName:         "TestOpenDirectUserNotFound",
+			username:         "ghost",
+			GetByUsernameErr: repositories.ErrRecordNotFound,
+			expectErr:        usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "TestOpenDirectUserNotActive",
+			username:          "peer",
+			GetByUsernameResp: domain.User{BaseModel: domain.BaseModel{ID: 2}, Role: enums.USER, IsActive: false},
+			expectErr:         usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "TestOpenDirectWithYourself",
+			username:          "me",
+			GetByUsernameResp: domain.User{BaseModel: domain.BaseModel{ID: 1}, Role: enums.USER, IsActive: true},
+			expectErr:         usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "TestOpenDirectExisting",
+			username:          "peer",
+			GetByUsernameResp: peer,
+			FilterResp:        []domain.Chat{existing},
+			expectCreate:      false,
+			mustErr:           false,
+		},
+		{
+			testName:          "TestOpenDirectCreate",
+			username:          "peer",
+			GetByUsernameResp: peer,
+			FilterResp:        []domain.Chat{},
+			expectCreate:      true,
+			mustErr:           false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockUserRepository := new(mocks.IUserRepository)
+		mockChatRepository := new(mocks.IChatRepository)
+		mockChatMemberRepository := new(mocks.IChatMemberRepository)
+		mockMessageRepository := new(mocks.IMessageRepository)
+		service.UserRepository = mockUserRepository
+		service.ChatRepository = mockChatRepository
+		service.ChatMemberRepository = mockChatMemberRepository
+		service.MessageRepository = mockMessageRepository
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockUserRepository.EXPECT().GetByUsername(mockApp.Ctx, tc.username).Return(tc.GetByUsernameResp, tc.GetByUsernameErr)
+			mockChatRepository.EXPECT().Filter(mockApp.Ctx, "direct_key = ?", directKey).Maybe().Return(tc.FilterResp, nil)
+			mockChatRepository.EXPECT().CreateDirect(mockApp.Ctx, mock.Anything, peer.ID).Maybe().Return(tc.CreateDirectErr)
+			mockChatMemberRepository.EXPECT().Filter(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Maybe().Return([]domain.ChatMember{}, nil)
+			mockChatMemberRepository.EXPECT().GetDirectPeers(mockApp.Ctx, caller.ID, mock.Anything).Maybe().Return(map[int64]dto.ChatPeerDTO{existing.ID: {Username: "peer"}}, nil)
+			mockMessageRepository.EXPECT().CountUnread(mockApp.Ctx, mock.Anything, mock.Anything).Maybe().Return(map[int64]int64{}, nil)
+
+			chat, err := service.OpenDirect(mockApp.Ctx, caller, tc.username)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectErr), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "direct", chat.Type)
+				if tc.expectCreate {
+					mockChatRepository.AssertCalled(t, "CreateDirect", mockApp.Ctx, mock.Anything, peer.ID)
+				} else {
+					mockChatRepository.AssertNotCalled(t, "CreateDirect", mockApp.Ctx, mock.Anything, peer.ID)
+					assert.Equal(t, existing.ID, chat.ID)
+					assert.Equal(t, "peer", chat.Peer.Username)
+				}
+			}
+		})
+	}
+}
+
+func TestDiscoverChats(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatService{
+		App: mockApp,
+	}
+
+	dbErr := errors.New("internal db err")
+	caller := dto.UserDTO{ID: 1, Username: "explorer", Role: enums.USER, IsActive: true}
+	limit := mockApp.Config.Pagination.GlobalChatList
+
+	testCases := []struct {
+		testName string
+
+		caller dto.UserDTO
+		page   int
+
+		DiscoverResp []dto.ChatPreview
+		DiscoverErr  error
+
+		expectedOffset int
+		expectedResp   dto.FilterChatsResponse
+		expectedErr    error
+		mustErr        bool
+	}{
+		{
+			testName:    "Anonymous",
+			caller:      dto.UserDTO{Role: enums.ANONYMOUS},
+			page:        1,
+			expectedErr: usecase_errors.UnauthorizedError{},
+			mustErr:     true,
+		},
+		{
+			testName:    "Invalid page",
+			caller:      caller,
+			page:        0,
+			expectedErr: usecase_errors.BadRequestError{},
+			mustErr:     true,
+		},
+		{
+			testName:       "DataBase error",
+			caller:         caller,
+			page:           1,
+			DiscoverErr:    dbErr,
+			expectedOffset: 0,
+			expectedErr:    dbErr,
+			mustErr:        true,
+		},
+		{
+			testName:       "Nothing found",
+			caller:         caller,
+			page:           1,
+			expectedOffset: 0,
+			expectedResp:   dto.FilterChatsResponse{Chats: []dto.ChatPreview{}},
+			mustErr:        false,
+		},
+		{
+			testName:       "Second page",
+			caller:         caller,
+			page:           2,
+			DiscoverResp:   []dto.ChatPreview{{ID: 3, Title: "Gophers", Owner: "owner", MembersCount: 12}},
+			expectedOffset: limit,
+			expectedResp:   dto.FilterChatsResponse{Chats: []dto.ChatPreview{{ID: 3, Title: "Gophers", Owner: "owner", MembersCount: 12}}},
+			mustErr:        false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatRepo := new(mocks.IChatRepository)
+		service.ChatRepository = mockChatRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatRepo.EXPECT().DiscoverPublic(mockApp.Ctx, "go", limit, tc.expectedOffset).Return(tc.DiscoverResp, tc.DiscoverErr).Maybe()
+
+			resp, err := service.Discover(mockApp.Ctx, tc.caller, "go", tc.page)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func TestGetAuditLog(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.ChatService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "admin", Role: enums.USER, IsActive: true}
+	limit := mockApp.Config.Pagination.AuditLog
+	moderatorRank := 50
+	adminRank := enums.CHAT_ADMIN_RANK
+	entry := domain.ChatAuditEntry{
+		ChatID:  1,
+		ActorID: caller.ID,
+		Action:  enums.AUDIT_MEMBER_KICKED,
+		Actor:   domain.User{Username: "admin"},
+		Target:  &domain.User{Username: "spammer"},
+		Before:  map[string]any{"role": "member"},
+	}
+
+	testCases := []struct {
+		testName string
+
+		page int
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		GetForChatResp []domain.ChatAuditEntry
+
+		expectedOffset int
+		expectedErr    error
+		mustErr        bool
+	}{
+		{
+			testName:    "Invalid page",
+			page:        0,
+			expectedErr: usecase_errors.BadRequestError{},
+			mustErr:     true,
+		},
+		{
+			testName:         "Not a member",
+			page:             1,
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedErr:      usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Direct chat",
+			page:              1,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.DIRECT, MemberRole: enums.MEMBER},
+			expectedErr:       usecase_errors.BadRequestError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Member",
+			page:              1,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER},
+			expectedErr:       usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Custom role ranked below admins",
+			page:              1,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER, Rank: &moderatorRank},
+			expectedErr:       usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Custom role ranked as admins",
+			page:              2,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER, Rank: &adminRank},
+			GetForChatResp:    []domain.ChatAuditEntry{entry},
+			expectedOffset:    limit,
+			mustErr:           false,
+		},
+		{
+			testName:          "Admin",
+			page:              1,
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			GetForChatResp:    []domain.ChatAuditEntry{entry},
+			mustErr:           false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockChatAuditRepo := new(mocks.IChatAuditRepository)
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.ChatAuditRepository = mockChatAuditRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr).Maybe()
+			mockChatAuditRepo.EXPECT().GetForChat(mockApp.Ctx, int64(1), limit, tc.expectedOffset).Return(tc.GetForChatResp, nil).Maybe()
+
+			resp, err := service.GetAuditLog(mockApp.Ctx, caller, 1, tc.page)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				mockChatAuditRepo.AssertNotCalled(t, "GetForChat", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, resp.Entries, 1)
+				assert.Equal(t, "member_kicked", resp.Entries[0].Action)
+				assert.Equal(t, "spammer", resp.Entries[0].Target)
+			}
+		})
+	}
+}
@@ -2,7 +2,12 @@ package unit
 
 import (
 	"context"
+	"github.com/stretchr/testify/mock"
 	"gopkg.in/gomail.v2"
+	domain "libs/src/internal/domain/models"
+	"libs/src/internal/mocks"
+	"libs/src/internal/realtime"
+	"libs/src/internal/repositories"
 	"libs/src/settings"
 	"libs/src/tests/integration"
 )
@@ -14,11 +19,30 @@ func GetAppMock() *settings.App {
 	if err != nil {
 		panic(err)
 	}
+	hub := settings.NewHub(cfg, logger)
 	return &settings.App{
-		Ctx:    ctx,
-		Cancel: cancel,
-		Config: cfg,
-		Logger: logger,
-		Mail:   &gomail.Dialer{},
+		Ctx:     ctx,
+		Cancel:  cancel,
+		Config:  cfg,
+		Logger:  logger,
+		Mail:    &gomail.Dialer{},
+		Hub:     hub,
+		Broker:  realtime.NewMemoryBroker(hub),
+		Storage: settings.NewStorage(cfg),
 	}
 }
+
+// unrestricted is a restriction repository where nobody is banned or muted
+func unrestricted() *mocks.IChatRestrictionRepository {
+	repository := new(mocks.IChatRestrictionRepository)
+	repository.EXPECT().GetActive(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.ChatRestriction{}, repositories.ErrRecordNotFound).Maybe()
+	return repository
+}
+
+// messageSink is a message repository accepting the system messages the
+// services post along the way
+func messageSink() *mocks.IMessageRepository {
+	repository := new(mocks.IMessageRepository)
+	repository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Maybe()
+	return repository
+}
@@ -0,0 +1,123 @@
+package unit
+
+import (
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"libs/src/internal/domain/enums"
+	"libs/src/internal/dto"
+	"libs/src/internal/realtime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func connectToHub(t *testing.T, hub *realtime.Hub, userId int64, chatIds []int64) *websocket.Conn {
+	upgrader := websocket.Upgrader{}
+	registered := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		client := realtime.NewClient(hub, conn, userId)
+		hub.Register(client, chatIds)
+		client.Run()
+		close(registered)
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	<-registered
+	return conn
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn) (dto.EventDTO, error) {
+	var event dto.EventDTO
+	conn.SetReadDeadline(time.Now().Add(300 * time.Millisecond))
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return event, err
+	}
+	require.NoError(t, json.Unmarshal(data, &event))
+	return event, nil
+}
+
+func TestHubPublish(t *testing.T) {
+	mockApp := GetAppMock()
+	hub := mockApp.Hub
+
+	member := connectToHub(t, hub, 1, []int64{10})
+	stranger := connectToHub(t, hub, 2, []int64{20})
+
+	hub.Publish(dto.EventDTO{Type: enums.EventTypesToLabels[enums.MESSAGE_CREATED], ChatId: 10})
+
+	event, err := readEvent(t, member)
+	assert.NoError(t, err)
+	assert.Equal(t, enums.EventTypesToLabels[enums.MESSAGE_CREATED], event.Type)
+	assert.Equal(t, int64(10), event.ChatId)
+
+	_, err = readEvent(t, stranger)
+	assert.Error(t, err, "user outside of the chat must not receive its events")
+}
+
+func TestHubMembershipEvents(t *testing.T) {
+	mockApp := GetAppMock()
+	hub := mockApp.Hub
+
+	conn := connectToHub(t, hub, 1, []int64{})
+
+	hub.Publish(dto.EventDTO{Type: enums.EventTypesToLabels[enums.MEMBER_JOINED], ChatId: 10, UserId: 1})
+	event, err := readEvent(t, conn)
+	assert.NoError(t, err)
+	assert.Equal(t, enums.EventTypesToLabels[enums.MEMBER_JOINED], event.Type)
+
+	hub.Publish(dto.EventDTO{Type: enums.EventTypesToLabels[enums.MESSAGE_CREATED], ChatId: 10})
+	event, err = readEvent(t, conn)
+	assert.NoError(t, err)
+	assert.Equal(t, enums.EventTypesToLabels[enums.MESSAGE_CREATED], event.Type)
+
+	hub.Publish(dto.EventDTO{Type: enums.EventTypesToLabels[enums.MEMBER_LEFT], ChatId: 10, UserId: 1})
+	event, err = readEvent(t, conn)
+	assert.NoError(t, err)
+	assert.Equal(t, enums.EventTypesToLabels[enums.MEMBER_LEFT], event.Type)
+
+	hub.Publish(dto.EventDTO{Type: enums.EventTypesToLabels[enums.MESSAGE_CREATED], ChatId: 10})
+	_, err = readEvent(t, conn)
+	assert.Error(t, err, "user who left the chat must not receive its events")
+}
+
+func TestHubMentionEvents(t *testing.T) {
+	mockApp := GetAppMock()
+	hub := mockApp.Hub
+
+	mentioned := connectToHub(t, hub, 1, []int64{10})
+	member := connectToHub(t, hub, 2, []int64{10})
+
+	hub.Publish(dto.EventDTO{Type: enums.EventTypesToLabels[enums.MENTIONED], ChatId: 10, UserId: 1})
+
+	event, err := readEvent(t, mentioned)
+	assert.NoError(t, err)
+	assert.Equal(t, enums.EventTypesToLabels[enums.MENTIONED], event.Type)
+
+	_, err = readEvent(t, member)
+	assert.Error(t, err, "mentions must reach only the mentioned user")
+}
+
+func TestMemoryBrokerPublish(t *testing.T) {
+	mockApp := GetAppMock()
+
+	conn := connectToHub(t, mockApp.Hub, 1, []int64{10})
+
+	err := mockApp.Broker.Publish(mockApp.Ctx, dto.EventDTO{Type: enums.EventTypesToLabels[enums.MESSAGE_CREATED], ChatId: 10})
+	assert.NoError(t, err)
+
+	event, err := readEvent(t, conn)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), event.ChatId)
+}
@@ -0,0 +1,1502 @@
+package unit
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"libs/src/internal/domain/enums"
+	domain "libs/src/internal/domain/models"
+	"libs/src/internal/dto"
+	"libs/src/internal/mocks"
+	"libs/src/internal/repositories"
+	"libs/src/internal/storage"
+	services "libs/src/internal/usecase"
+	usecase_errors "libs/src/internal/usecase/errors"
+	"libs/src/pkg/utils"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetHistory(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	dbErr := errors.New("internal db err")
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	validCursor := utils.EncodeCursor(now, primitive.NewObjectID())
+
+	// GetOlder is asked for one message more than the limit to detect further history
+	fullPage := make([]domain.Message, 3)
+	for i := range fullPage {
+		fullPage[i] = domain.Message{
+			BaseMongo: domain.BaseMongo{Id: primitive.NewObjectID(), CreatedAt: now.Add(time.Duration(i) * time.Second)},
+			ChatId:    1,
+			SenderId:  1,
+		}
+	}
+
+	testCases := []struct {
+		testName string
+
+		caller dto.UserDTO
+		cursor string
+		mode   string
+		limit  int
+
+		FilterMembersResp []domain.ChatMember
+		FilterMembersErr  error
+
+		GetOlderResp []domain.Message
+		GetOlderErr  error
+
+		expectedMessages int
+		expectOlder      bool
+		expectNewer      bool
+		expectedResp     error
+		mustErr          bool
+	}{
+		{
+			testName:     "Anonymous user",
+			caller:       dto.UserDTO{ID: 1, Role: enums.ANONYMOUS, IsActive: true},
+			mode:         "before",
+			expectedResp: usecase_errors.UnauthorizedError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Invalid mode",
+			caller:       dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true},
+			mode:         "sideways",
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Invalid cursor",
+			caller:       dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true},
+			cursor:       "not a cursor",
+			mode:         "before",
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Cursor is required for around",
+			caller:       dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true},
+			mode:         "around",
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Not a member",
+			caller:       dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true},
+			mode:         "before",
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:          "DataBase error",
+			caller:            dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true},
+			mode:              "before",
+			FilterMembersResp: []domain.ChatMember{{UserID: 1, ChatID: 1}},
+			GetOlderErr:       dbErr,
+			expectedResp:      dbErr,
+			mustErr:           true,
+		},
+		{
+			testName:          "Latest page with older history",
+			caller:            dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true},
+			mode:              "before",
+			limit:             2,
+			FilterMembersResp: []domain.ChatMember{{UserID: 1, ChatID: 1}},
+			GetOlderResp:      fullPage,
+			expectedMessages:  2,
+			expectOlder:       true,
+			expectNewer:       false,
+		},
+		{
+			testName:          "Page before cursor reaches the start",
+			caller:            dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true},
+			cursor:            validCursor,
+			mode:              "before",
+			limit:             5,
+			FilterMembersResp: []domain.ChatMember{{UserID: 1, ChatID: 1}},
+			GetOlderResp:      fullPage,
+			expectedMessages:  3,
+			expectOlder:       false,
+			expectNewer:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockUserRepo := new(mocks.IUserRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.UserRepository = mockUserRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().Filter(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything).Return(tc.FilterMembersResp, tc.FilterMembersErr).Maybe()
+			mockMessageRepo.EXPECT().GetOlder(mockApp.Ctx, int64(1), primitive.NilObjectID, mock.Anything, mock.Anything, mock.Anything).Return(tc.GetOlderResp, tc.GetOlderErr).Maybe()
+			mockUserRepo.EXPECT().Filter(mockApp.Ctx, mock.Anything, mock.Anything).Return([]domain.User{{BaseModel: domain.BaseModel{ID: 1}, Username: "sender"}}, nil).Maybe()
+
+			resp, err := service.GetHistory(mockApp.Ctx, tc.caller, 1, tc.cursor, tc.mode, tc.limit)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, resp.Messages, tc.expectedMessages)
+				assert.Equal(t, "sender", resp.Messages[0].SenderUsername)
+				assert.Equal(t, tc.expectOlder, resp.OlderCursor != "")
+				assert.Equal(t, tc.expectNewer, resp.NewerCursor != "")
+			}
+		})
+	}
+}
+
+func TestEditMessage(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	dbErr := errors.New("internal db err")
+	caller := dto.UserDTO{ID: 1, Username: "sender", Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		content string
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		GetChatMessageResp domain.Message
+		GetChatMessageErr  error
+
+		EditErr error
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:     "Empty content",
+			content:      "  ",
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:         "Not a member",
+			content:          "edited",
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedResp:     usecase_errors.BadRequestError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Message not found",
+			content:           "edited",
+			GetChatMessageErr: repositories.ErrRecordNotFound,
+			expectedResp:      usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:           "Someone else's message",
+			content:            "edited",
+			GetMemberInfoResp:  dto.MemberInfo{MemberRole: enums.OWNER},
+			GetChatMessageResp: domain.Message{SenderId: 2, Content: "original"},
+			expectedResp:       usecase_errors.PermissionError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "Deleted message",
+			content:            "edited",
+			GetChatMessageResp: domain.Message{SenderId: 1, IsDeleted: true},
+			expectedResp:       usecase_errors.NotFoundError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "DataBase error",
+			content:            "edited",
+			GetChatMessageResp: domain.Message{SenderId: 1, Content: "original"},
+			EditErr:            dbErr,
+			expectedResp:       dbErr,
+			mustErr:            true,
+		},
+		{
+			testName:           "Success",
+			content:            "edited",
+			GetChatMessageResp: domain.Message{SenderId: 1, Content: "original"},
+			mustErr:            false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = unrestricted()
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr).Maybe()
+			mockMessageRepo.EXPECT().GetChatMessage(mockApp.Ctx, int64(1), mock.Anything).Return(tc.GetChatMessageResp, tc.GetChatMessageErr).Maybe()
+			mockMessageRepo.EXPECT().Edit(mockApp.Ctx, mock.Anything, tc.content, mock.Anything, mock.Anything).Return(tc.EditErr).Maybe()
+
+			resp, err := service.EditMessage(mockApp.Ctx, caller, 1, primitive.NewObjectID().Hex(), dto.EditMessageRequest{Message: tc.content})
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.content, resp.Content)
+				assert.True(t, resp.IsEdited)
+			}
+		})
+	}
+}
+
+func TestDeleteMessage(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true}
+
+	moderator := enums.SEND_MESSAGES | enums.DELETE_MESSAGES
+	writer := enums.SEND_MESSAGES
+	root := primitive.NewObjectID()
+
+	testCases := []struct {
+		testName string
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		GetChatMessageResp domain.Message
+		GetChatMessageErr  error
+
+		expectDelete bool
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:         "Not a member",
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedResp:     usecase_errors.BadRequestError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Message not found",
+			GetChatMessageErr: repositories.ErrRecordNotFound,
+			expectedResp:      usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:           "Member deletes someone else's message",
+			GetMemberInfoResp:  dto.MemberInfo{MemberRole: enums.MEMBER},
+			GetChatMessageResp: domain.Message{SenderId: 2},
+			expectedResp:       usecase_errors.PermissionError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "Already deleted",
+			GetMemberInfoResp:  dto.MemberInfo{MemberRole: enums.MEMBER},
+			GetChatMessageResp: domain.Message{SenderId: 1, IsDeleted: true},
+			expectDelete:       false,
+			mustErr:            false,
+		},
+		{
+			testName:           "Sender deletes own message",
+			GetMemberInfoResp:  dto.MemberInfo{MemberRole: enums.MEMBER},
+			GetChatMessageResp: domain.Message{SenderId: 1},
+			expectDelete:       true,
+			mustErr:            false,
+		},
+		{
+			testName:           "Sender deletes own reply",
+			GetMemberInfoResp:  dto.MemberInfo{MemberRole: enums.MEMBER},
+			GetChatMessageResp: domain.Message{SenderId: 1, ReplyTo: &root},
+			expectDelete:       true,
+			mustErr:            false,
+		},
+		{
+			testName:           "Admin deletes someone else's message",
+			GetMemberInfoResp:  dto.MemberInfo{MemberRole: enums.CHAT_ADMIN},
+			GetChatMessageResp: domain.Message{SenderId: 2},
+			expectDelete:       true,
+			mustErr:            false,
+		},
+		{
+			testName:           "Member allowed to delete messages",
+			GetMemberInfoResp:  dto.MemberInfo{MemberRole: enums.MEMBER, Permissions: &moderator},
+			GetChatMessageResp: domain.Message{SenderId: 2},
+			expectDelete:       true,
+			mustErr:            false,
+		},
+		{
+			testName:           "Admin not allowed to delete messages",
+			GetMemberInfoResp:  dto.MemberInfo{MemberRole: enums.CHAT_ADMIN, Permissions: &writer},
+			GetChatMessageResp: domain.Message{SenderId: 2},
+			expectedResp:       usecase_errors.PermissionError{},
+			mustErr:            true,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockPinRepo := new(mocks.IChatPinRepository)
+		mockMentionRepo := new(mocks.IMentionRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.PinRepository = mockPinRepo
+		service.MentionRepository = mockMentionRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr).Maybe()
+			mockMessageRepo.EXPECT().GetChatMessage(mockApp.Ctx, int64(1), mock.Anything).Return(tc.GetChatMessageResp, tc.GetChatMessageErr).Maybe()
+			mockMessageRepo.EXPECT().SoftDelete(mockApp.Ctx, mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockPinRepo.EXPECT().Unpin(mockApp.Ctx, int64(1), mock.Anything).Return(repositories.ErrRecordNotFound).Maybe()
+			mockMentionRepo.EXPECT().RemoveForMessage(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+			mockMessageRepo.EXPECT().RemoveReply(mockApp.Ctx, root).Return(nil).Maybe()
+
+			err := service.DeleteMessage(mockApp.Ctx, caller, 1, primitive.NewObjectID().Hex())
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				if tc.expectDelete {
+					mockMessageRepo.AssertCalled(t, "SoftDelete", mockApp.Ctx, mock.Anything, mock.Anything)
+					mockPinRepo.AssertCalled(t, "Unpin", mockApp.Ctx, int64(1), mock.Anything)
+					mockMentionRepo.AssertCalled(t, "RemoveForMessage", mockApp.Ctx, mock.Anything)
+					if tc.GetChatMessageResp.ReplyTo != nil {
+						mockMessageRepo.AssertCalled(t, "RemoveReply", mockApp.Ctx, root)
+					} else {
+						mockMessageRepo.AssertNotCalled(t, "RemoveReply", mockApp.Ctx, mock.Anything)
+					}
+				} else {
+					mockMessageRepo.AssertNotCalled(t, "SoftDelete", mockApp.Ctx, mock.Anything, mock.Anything)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	dbErr := errors.New("internal db err")
+	caller := dto.UserDTO{ID: 1, Role: enums.USER, IsActive: true}
+
+	testCases := []struct {
+		testName string
+
+		caller dto.UserDTO
+
+		GetMemberInfoErr  error
+		GetChatMessageErr error
+
+		AdvanceResp bool
+		AdvanceErr  error
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:     "Anonymous user",
+			caller:       dto.UserDTO{ID: 1, Role: enums.ANONYMOUS, IsActive: true},
+			expectedResp: usecase_errors.UnauthorizedError{},
+			mustErr:      true,
+		},
+		{
+			testName:         "Not a member",
+			caller:           caller,
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedResp:     usecase_errors.BadRequestError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Message from another chat",
+			caller:            caller,
+			GetChatMessageErr: repositories.ErrRecordNotFound,
+			expectedResp:      usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:     "DataBase error",
+			caller:       caller,
+			AdvanceErr:   dbErr,
+			expectedResp: dbErr,
+			mustErr:      true,
+		},
+		{
+			testName:    "Cursor already past the message",
+			caller:      caller,
+			AdvanceResp: false,
+			mustErr:     false,
+		},
+		{
+			testName:    "Success",
+			caller:      caller,
+			AdvanceResp: true,
+			mustErr:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+
+		message := domain.Message{BaseMongo: domain.BaseMongo{Id: primitive.NewObjectID(), CreatedAt: time.Now()}, ChatId: 1, SenderId: 2}
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, tc.caller.ID, int64(1)).Return(dto.MemberInfo{}, tc.GetMemberInfoErr).Maybe()
+			mockMessageRepo.EXPECT().GetChatMessage(mockApp.Ctx, int64(1), message.Id.Hex()).Return(message, tc.GetChatMessageErr).Maybe()
+			mockChatMemberRepo.EXPECT().AdvanceReadCursor(mockApp.Ctx, int64(1), tc.caller.ID, message.Id.Hex(), message.CreatedAt).Return(tc.AdvanceResp, tc.AdvanceErr).Maybe()
+
+			err := service.MarkRead(mockApp.Ctx, tc.caller, 1, message.Id.Hex())
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSendReply(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	sender := dto.UserDTO{ID: 1, Username: "sender", Role: enums.USER, IsActive: true}
+	root := primitive.NewObjectID()
+	replyId := primitive.NewObjectID()
+
+	testCases := []struct {
+		testName string
+
+		GetChatMessageResp domain.Message
+		GetChatMessageErr  error
+
+		AddReplyErr error
+
+		expectedThread primitive.ObjectID
+		expectedResp   error
+		mustErr        bool
+	}{
+		{
+			testName:          "Parent not found",
+			GetChatMessageErr: repositories.ErrRecordNotFound,
+			expectedResp:      usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:           "Parent deleted",
+			GetChatMessageResp: domain.Message{BaseMongo: domain.BaseMongo{Id: root}, IsDeleted: true},
+			expectedResp:       usecase_errors.NotFoundError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "Reply to a message",
+			GetChatMessageResp: domain.Message{BaseMongo: domain.BaseMongo{Id: root}},
+			expectedThread:     root,
+			mustErr:            false,
+		},
+		{
+			testName:           "Reply to a reply goes to the same thread",
+			GetChatMessageResp: domain.Message{BaseMongo: domain.BaseMongo{Id: replyId}, ReplyTo: &root},
+			expectedThread:     root,
+			mustErr:            false,
+		},
+		{
+			testName:           "Thread summary update fails",
+			GetChatMessageResp: domain.Message{BaseMongo: domain.BaseMongo{Id: root}},
+			AddReplyErr:        errors.New("mongo is down"),
+			expectedThread:     root,
+			mustErr:            false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = unrestricted()
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, sender.ID, int64(1)).Return(dto.MemberInfo{ChatID: 1, MemberID: 1, ChatType: enums.GROUP, MemberRole: enums.MEMBER}, nil)
+			mockMessageRepo.EXPECT().GetChatMessage(mockApp.Ctx, int64(1), tc.GetChatMessageResp.Id.Hex()).Return(tc.GetChatMessageResp, tc.GetChatMessageErr)
+			mockMessageRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+			mockMessageRepo.EXPECT().AddReply(mockApp.Ctx, tc.expectedThread, mock.Anything, sender.ID).Return(tc.AddReplyErr).Maybe()
+
+			request := dto.SendMessageRequest{Message: "reply", ReplyTo: tc.GetChatMessageResp.Id.Hex()}
+			resp, err := service.SendMessage(mockApp.Ctx, sender, request, 1)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedThread.Hex(), resp.ReplyTo)
+				mockMessageRepo.AssertCalled(t, "AddReply", mockApp.Ctx, tc.expectedThread, mock.Anything, sender.ID)
+			}
+		})
+	}
+}
+
+func TestSendWhileMuted(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	sender := dto.UserDTO{ID: 1, Username: "sender", Role: enums.USER, IsActive: true}
+	expiresAt := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		testName string
+
+		GetActiveResp domain.ChatRestriction
+		GetActiveErr  error
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:      "Muted",
+			GetActiveResp: domain.ChatRestriction{Type: enums.MUTE, ExpiresAt: &expiresAt},
+			expectedResp:  usecase_errors.PermissionError{},
+			mustErr:       true,
+		},
+		{
+			testName:     "Not muted",
+			GetActiveErr: repositories.ErrRecordNotFound,
+			mustErr:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockRestrictionRepo := new(mocks.IChatRestrictionRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = mockRestrictionRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, sender.ID, int64(1)).Return(dto.MemberInfo{ChatID: 1, MemberID: 1, ChatType: enums.GROUP, MemberRole: enums.MEMBER}, nil)
+			mockRestrictionRepo.EXPECT().GetActive(mockApp.Ctx, int64(1), sender.ID, byte(enums.MUTE)).Return(tc.GetActiveResp, tc.GetActiveErr)
+			mockMessageRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+
+			_, err := service.SendMessage(mockApp.Ctx, sender, dto.SendMessageRequest{Message: "hello"}, 1)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockMessageRepo.AssertCalled(t, "Create", mockApp.Ctx, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestEditWhileMuted(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "sender", Role: enums.USER, IsActive: true}
+	expiresAt := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		testName string
+
+		GetActiveResp domain.ChatRestriction
+		GetActiveErr  error
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:      "Muted",
+			GetActiveResp: domain.ChatRestriction{Type: enums.MUTE, ExpiresAt: &expiresAt},
+			expectedResp:  usecase_errors.PermissionError{},
+			mustErr:       true,
+		},
+		{
+			testName:     "Not muted",
+			GetActiveErr: repositories.ErrRecordNotFound,
+			mustErr:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockRestrictionRepo := new(mocks.IChatRestrictionRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = mockRestrictionRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(dto.MemberInfo{ChatID: 1, MemberID: 1, ChatType: enums.GROUP, MemberRole: enums.MEMBER}, nil)
+			mockMessageRepo.EXPECT().GetChatMessage(mockApp.Ctx, int64(1), mock.Anything).Return(domain.Message{SenderId: caller.ID, Content: "original"}, nil)
+			mockRestrictionRepo.EXPECT().GetActive(mockApp.Ctx, int64(1), caller.ID, byte(enums.MUTE)).Return(tc.GetActiveResp, tc.GetActiveErr)
+			mockMessageRepo.EXPECT().Edit(mockApp.Ctx, mock.Anything, "edited", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			_, err := service.EditMessage(mockApp.Ctx, caller, 1, primitive.NewObjectID().Hex(), dto.EditMessageRequest{Message: "edited"})
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "Edit", mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockMessageRepo.AssertCalled(t, "Edit", mockApp.Ctx, mock.Anything, "edited", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestReactWhileMuted(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "reactor", Role: enums.USER, IsActive: true}
+	expiresAt := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		testName string
+
+		GetActiveResp domain.ChatRestriction
+		GetActiveErr  error
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:      "Muted",
+			GetActiveResp: domain.ChatRestriction{Type: enums.MUTE, ExpiresAt: &expiresAt},
+			expectedResp:  usecase_errors.PermissionError{},
+			mustErr:       true,
+		},
+		{
+			testName:     "Not muted",
+			GetActiveErr: repositories.ErrRecordNotFound,
+			mustErr:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockRestrictionRepo := new(mocks.IChatRestrictionRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = mockRestrictionRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(dto.MemberInfo{ChatID: 1, MemberID: 1, ChatType: enums.GROUP, MemberRole: enums.MEMBER}, nil)
+			mockMessageRepo.EXPECT().GetChatMessage(mockApp.Ctx, int64(1), mock.Anything).Return(domain.Message{SenderId: 2}, nil)
+			mockRestrictionRepo.EXPECT().GetActive(mockApp.Ctx, int64(1), caller.ID, byte(enums.MUTE)).Return(tc.GetActiveResp, tc.GetActiveErr)
+			mockMessageRepo.EXPECT().AddReaction(mockApp.Ctx, mock.Anything, "👍", caller.ID, mockApp.Config.MessagesConfig.MaxDistinctReactions).Return(nil).Maybe()
+
+			_, err := service.AddReaction(mockApp.Ctx, caller, 1, primitive.NewObjectID().Hex(), "👍")
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "AddReaction", mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockMessageRepo.AssertCalled(t, "AddReaction", mockApp.Ctx, mock.Anything, "👍", caller.ID, mockApp.Config.MessagesConfig.MaxDistinctReactions)
+			}
+		})
+	}
+}
+
+func TestAddReaction(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	dbErr := errors.New("internal db err")
+	caller := dto.UserDTO{ID: 1, Username: "reactor", Role: enums.USER, IsActive: true}
+	reacted := domain.Message{SenderId: 2, Reactions: []domain.MessageReaction{
+		{Emoji: "👍", UserIds: []int64{2, 1}},
+		{Emoji: "🎉", UserIds: []int64{2}},
+	}}
+
+	testCases := []struct {
+		testName string
+
+		emoji string
+
+		GetMemberInfoErr error
+
+		GetChatMessageResp domain.Message
+		GetChatMessageErr  error
+
+		AddReactionErr error
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:     "Not an emoji",
+			emoji:        "ok",
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Several emojis",
+			emoji:        "👍👍",
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:         "Not a member",
+			emoji:            "👍",
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedResp:     usecase_errors.BadRequestError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Message not found",
+			emoji:             "👍",
+			GetChatMessageErr: repositories.ErrRecordNotFound,
+			expectedResp:      usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:           "Deleted message",
+			emoji:              "👍",
+			GetChatMessageResp: domain.Message{SenderId: 2, IsDeleted: true},
+			expectedResp:       usecase_errors.NotFoundError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "Too many reactions",
+			emoji:              "👍",
+			GetChatMessageResp: reacted,
+			AddReactionErr:     repositories.ErrTooManyReactions,
+			expectedResp:       usecase_errors.BadRequestError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "DataBase error",
+			emoji:              "👍",
+			GetChatMessageResp: reacted,
+			AddReactionErr:     dbErr,
+			expectedResp:       dbErr,
+			mustErr:            true,
+		},
+		{
+			testName:           "Success",
+			emoji:              "👍",
+			GetChatMessageResp: reacted,
+			mustErr:            false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = unrestricted()
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(dto.MemberInfo{}, tc.GetMemberInfoErr).Maybe()
+			mockMessageRepo.EXPECT().GetChatMessage(mockApp.Ctx, int64(1), mock.Anything).Return(tc.GetChatMessageResp, tc.GetChatMessageErr).Maybe()
+			mockMessageRepo.EXPECT().AddReaction(mockApp.Ctx, mock.Anything, tc.emoji, caller.ID, mockApp.Config.MessagesConfig.MaxDistinctReactions).Return(tc.AddReactionErr).Maybe()
+
+			resp, err := service.AddReaction(mockApp.Ctx, caller, 1, primitive.NewObjectID().Hex(), tc.emoji)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []dto.ReactionDTO{
+					{Emoji: "👍", Count: 2, ReactedByMe: true},
+					{Emoji: "🎉", Count: 1, ReactedByMe: false},
+				}, resp)
+			}
+		})
+	}
+}
+
+// formFiles builds the headers a multipart upload of the files would produce
+func formFiles(t *testing.T, files map[string][]byte) []*multipart.FileHeader {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	for name, content := range files {
+		part, err := writer.CreateFormFile("attachments", name)
+		assert.NoError(t, err)
+		_, err = part.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	assert.NoError(t, err)
+	return form.File["attachments"]
+}
+
+func TestSendAttachments(t *testing.T) {
+	mockApp := GetAppMock()
+	config := *mockApp.Config
+	config.MessagesConfig.MaxAttachments = 2
+	config.MessagesConfig.MaxAttachmentSize = 64
+	mockApp.Config = &config
+	uploadDir := t.TempDir()
+	mockApp.Storage = storage.NewLocalStorage(uploadDir, "/media", "secret")
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	dbErr := errors.New("internal db err")
+	sender := dto.UserDTO{ID: 1, Username: "sender", Role: enums.USER, IsActive: true}
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...)
+
+	testCases := []struct {
+		testName string
+
+		files map[string][]byte
+
+		CreateErr error
+
+		expectedTypes []string
+		expectedResp  error
+		mustErr       bool
+	}{
+		{
+			testName:     "Too many attachments",
+			files:        map[string][]byte{"a.txt": []byte("a"), "b.txt": []byte("b"), "c.txt": []byte("c")},
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Attachment too large",
+			files:        map[string][]byte{"big.txt": bytes.Repeat([]byte("a"), 65)},
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "DataBase error",
+			files:        map[string][]byte{"note.txt": []byte("hello")},
+			CreateErr:    dbErr,
+			expectedResp: dbErr,
+			mustErr:      true,
+		},
+		{
+			testName:      "Type is sniffed from the content",
+			files:         map[string][]byte{"image.txt": png},
+			expectedTypes: []string{"image/png"},
+			mustErr:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.RestrictionRepository = unrestricted()
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, sender.ID, int64(1)).Return(dto.MemberInfo{ChatID: 1, MemberID: 1, ChatType: enums.GROUP, MemberRole: enums.MEMBER}, nil)
+			mockMessageRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(tc.CreateErr).Maybe()
+
+			request := dto.SendMessageRequest{Attachments: formFiles(t, tc.files)}
+			resp, err := service.SendMessage(mockApp.Ctx, sender, request, 1)
+
+			// Nothing is left in the storage when the message is not sent
+			stored, _ := filepath.Glob(filepath.Join(uploadDir, "attachments", "1", "*"))
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				assert.Empty(t, stored)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, stored, len(tc.expectedTypes))
+				for _, file := range stored {
+					assert.NoError(t, os.Remove(file))
+				}
+				for i, attachment := range resp.Attachments {
+					assert.Equal(t, tc.expectedTypes[i], attachment.MimeType)
+				}
+			}
+		})
+	}
+}
+
+func TestGetAttachment(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "member", Role: enums.USER, IsActive: true}
+	attachment := domain.MessageAttachment{Id: "file", Key: "attachments/1/file", OriginalName: "report.pdf", MimeType: "application/pdf"}
+
+	testCases := []struct {
+		testName string
+
+		caller       dto.UserDTO
+		attachmentId string
+
+		GetMemberInfoErr error
+
+		GetChatMessageResp domain.Message
+		GetChatMessageErr  error
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:     "Anonymous",
+			caller:       dto.UserDTO{ID: 1, Role: enums.ANONYMOUS},
+			attachmentId: "file",
+			expectedResp: usecase_errors.UnauthorizedError{},
+			mustErr:      true,
+		},
+		{
+			testName:         "Not a member",
+			caller:           caller,
+			attachmentId:     "file",
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedResp:     usecase_errors.BadRequestError{},
+			mustErr:          true,
+		},
+		{
+			testName:           "Deleted message",
+			caller:             caller,
+			attachmentId:       "file",
+			GetChatMessageResp: domain.Message{IsDeleted: true, Attachments: []domain.MessageAttachment{attachment}},
+			expectedResp:       usecase_errors.NotFoundError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "Unknown attachment",
+			caller:             caller,
+			attachmentId:       "other",
+			GetChatMessageResp: domain.Message{Attachments: []domain.MessageAttachment{attachment}},
+			expectedResp:       usecase_errors.NotFoundError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "Success",
+			caller:             caller,
+			attachmentId:       "file",
+			GetChatMessageResp: domain.Message{Attachments: []domain.MessageAttachment{attachment}},
+			mustErr:            false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(dto.MemberInfo{}, tc.GetMemberInfoErr).Maybe()
+			mockMessageRepo.EXPECT().GetChatMessage(mockApp.Ctx, int64(1), mock.Anything).Return(tc.GetChatMessageResp, tc.GetChatMessageErr).Maybe()
+
+			resp, err := service.GetAttachmentURL(mockApp.Ctx, tc.caller, 1, primitive.NewObjectID().Hex(), tc.attachmentId)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Contains(t, resp, "/media/attachments/1/file?")
+				assert.Contains(t, resp, "name=report.pdf")
+			}
+		})
+	}
+}
+
+func TestPinMessage(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "admin", Role: enums.USER, IsActive: true}
+	message := domain.NewMessageObject(2, 1, "Read the rules")
+	system := domain.NewSystemMessage(2, 1, "author pinned a message", domain.SystemEvent{Action: enums.SYSTEM_MESSAGE_PINNED})
+	pinner := enums.SEND_MESSAGES | enums.PIN_MESSAGES
+
+	testCases := []struct {
+		testName string
+
+		GetMemberInfoResp dto.MemberInfo
+		GetMemberInfoErr  error
+
+		GetChatMessageResp domain.Message
+		GetChatMessageErr  error
+
+		PinErr error
+
+		expectedResp error
+		mustErr      bool
+	}{
+		{
+			testName:         "Not a member",
+			GetMemberInfoErr: repositories.ErrRecordNotFound,
+			expectedResp:     usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:          "Direct chat",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.DIRECT, MemberRole: enums.MEMBER},
+			expectedResp:      usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Member without the permission",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER},
+			expectedResp:      usecase_errors.PermissionError{},
+			mustErr:           true,
+		},
+		{
+			testName:          "Message not found",
+			GetMemberInfoResp: dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			GetChatMessageErr: repositories.ErrRecordNotFound,
+			expectedResp:      usecase_errors.NotFoundError{},
+			mustErr:           true,
+		},
+		{
+			testName:           "System message",
+			GetMemberInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			GetChatMessageResp: *system,
+			expectedResp:       usecase_errors.BadRequestError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "Already pinned",
+			GetMemberInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			GetChatMessageResp: *message,
+			PinErr:             repositories.ErrDuplicate,
+			expectedResp:       usecase_errors.AlreadyExistsError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "Too many pins",
+			GetMemberInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.CHAT_ADMIN},
+			GetChatMessageResp: *message,
+			PinErr:             repositories.ErrTooManyPins,
+			expectedResp:       usecase_errors.BadRequestError{},
+			mustErr:            true,
+		},
+		{
+			testName:           "Member allowed to pin",
+			GetMemberInfoResp:  dto.MemberInfo{ChatType: enums.GROUP, MemberRole: enums.MEMBER, Permissions: &pinner},
+			GetChatMessageResp: *message,
+			mustErr:            false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockPinRepo := new(mocks.IChatPinRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.PinRepository = mockPinRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, caller.ID, int64(1)).Return(tc.GetMemberInfoResp, tc.GetMemberInfoErr).Maybe()
+			mockMessageRepo.EXPECT().GetChatMessage(mockApp.Ctx, int64(1), message.Id.Hex()).Return(tc.GetChatMessageResp, tc.GetChatMessageErr).Maybe()
+			mockPinRepo.EXPECT().Pin(mockApp.Ctx, mock.Anything, mockApp.Config.MessagesConfig.MaxPinned).Return(tc.PinErr).Maybe()
+			mockMessageRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+
+			err := service.PinMessage(mockApp.Ctx, caller, 1, message.Id.Hex())
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockPinRepo.AssertCalled(t, "Pin", mockApp.Ctx, &domain.ChatPin{ChatID: 1, MessageID: message.Id.Hex(), PinnedByID: caller.ID}, mockApp.Config.MessagesConfig.MaxPinned)
+				mockMessageRepo.AssertCalled(t, "Create", mockApp.Ctx, mock.MatchedBy(func(posted *domain.Message) bool {
+					return posted.Type == enums.SYSTEM_MESSAGE && posted.SenderId == caller.ID &&
+						posted.Event.Action == enums.SYSTEM_MESSAGE_PINNED && posted.Event.MessageId == message.Id.Hex()
+				}))
+			}
+		})
+	}
+}
+
+func TestSendMentions(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	sender := dto.UserDTO{ID: 1, Username: "sender", Role: enums.USER, IsActive: true}
+	members := []domain.ChatMember{{UserID: 1}, {UserID: 2}, {UserID: 3}}
+
+	testCases := []struct {
+		testName string
+
+		content    string
+		senderRole byte
+
+		FilterUsersResp []domain.User
+
+		expectedMentions []dto.MentionEntityDTO
+		expectedNotified []int64
+		expectedResp     error
+		mustErr          bool
+	}{
+		{
+			testName:         "No mentions",
+			content:          "write to mail@bob.com",
+			senderRole:       enums.MEMBER,
+			expectedMentions: nil,
+			mustErr:          false,
+		},
+		{
+			testName:         "Mentions a member and a stranger",
+			content:          "hi @bob and @ghost",
+			senderRole:       enums.MEMBER,
+			FilterUsersResp:  []domain.User{{BaseModel: domain.BaseModel{ID: 2}, Username: "bob"}},
+			expectedMentions: []dto.MentionEntityDTO{{Username: "bob", Offset: 3, Length: 4}},
+			expectedNotified: []int64{2},
+			mustErr:          false,
+		},
+		{
+			testName:         "Mentions themselves",
+			content:          "@sender note to self",
+			senderRole:       enums.MEMBER,
+			FilterUsersResp:  []domain.User{{BaseModel: domain.BaseModel{ID: 1}, Username: "sender"}},
+			expectedMentions: []dto.MentionEntityDTO{{Username: "sender", Offset: 0, Length: 7}},
+			mustErr:          false,
+		},
+		{
+			testName:     "Member mentions everyone",
+			content:      "@all meeting now",
+			senderRole:   enums.MEMBER,
+			expectedResp: usecase_errors.PermissionError{},
+			mustErr:      true,
+		},
+		{
+			testName:         "Admin mentions everyone",
+			content:          "@all meeting now, @bob too",
+			senderRole:       enums.CHAT_ADMIN,
+			FilterUsersResp:  []domain.User{{BaseModel: domain.BaseModel{ID: 2}, Username: "bob"}},
+			expectedMentions: []dto.MentionEntityDTO{{Username: "all", Offset: 0, Length: 4}, {Username: "bob", Offset: 18, Length: 4}},
+			expectedNotified: []int64{2, 3},
+			mustErr:          false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockUserRepo := new(mocks.IUserRepository)
+		mockMentionRepo := new(mocks.IMentionRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.UserRepository = mockUserRepo
+		service.MentionRepository = mockMentionRepo
+		service.RestrictionRepository = unrestricted()
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().GetMemberInfo(mockApp.Ctx, sender.ID, int64(1)).Return(dto.MemberInfo{ChatID: 1, MemberID: 1, ChatType: enums.GROUP, MemberRole: tc.senderRole}, nil)
+			mockChatMemberRepo.EXPECT().Filter(mockApp.Ctx, "chat_id = ?", int64(1)).Return(members, nil).Maybe()
+			mockUserRepo.EXPECT().Filter(mockApp.Ctx, mock.Anything, mock.Anything, int64(1)).Return(tc.FilterUsersResp, nil).Maybe()
+			mockMessageRepo.EXPECT().Create(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+			mockMentionRepo.EXPECT().AddMany(mockApp.Ctx, mock.Anything).Return(nil).Maybe()
+
+			resp, err := service.SendMessage(mockApp.Ctx, sender, dto.SendMessageRequest{Message: tc.content}, 1)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "Create", mockApp.Ctx, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedMentions, resp.Mentions)
+			if len(tc.expectedNotified) == 0 {
+				mockMentionRepo.AssertNotCalled(t, "AddMany", mockApp.Ctx, mock.Anything)
+				return
+			}
+			mockMentionRepo.AssertCalled(t, "AddMany", mockApp.Ctx, mock.MatchedBy(func(mentions []domain.Mention) bool {
+				notified := make([]int64, len(mentions))
+				for i, mention := range mentions {
+					if mention.MessageID != resp.Id || mention.SenderID != sender.ID {
+						return false
+					}
+					notified[i] = mention.UserID
+				}
+				return reflect.DeepEqual(tc.expectedNotified, notified)
+			}))
+		})
+	}
+}
+
+func TestGetMentions(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "bob", Role: enums.USER, IsActive: true}
+	alive := domain.Message{BaseMongo: domain.BaseMongo{Id: primitive.NewObjectID()}, SenderId: 2, ChatId: 1, Content: "hi @bob"}
+	deleted := domain.Message{BaseMongo: domain.BaseMongo{Id: primitive.NewObjectID()}, SenderId: 2, ChatId: 1, IsDeleted: true}
+
+	testCases := []struct {
+		testName string
+
+		page int
+
+		GetForUserResp []domain.Mention
+		GetForUserErr  error
+
+		expectedCount int
+		expectedResp  error
+		mustErr       bool
+	}{
+		{
+			testName:     "Invalid page",
+			page:         0,
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:      "Empty inbox",
+			page:          1,
+			expectedCount: 0,
+			mustErr:       false,
+		},
+		{
+			testName: "Deleted messages are left out",
+			page:     1,
+			GetForUserResp: []domain.Mention{
+				{BaseModel: domain.BaseModel{ID: 2}, ChatID: 1, MessageID: deleted.Id.Hex(), Chat: domain.Chat{Title: "team"}},
+				{BaseModel: domain.BaseModel{ID: 1}, ChatID: 1, MessageID: alive.Id.Hex(), Chat: domain.Chat{Title: "team"}},
+			},
+			expectedCount: 1,
+			mustErr:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockUserRepo := new(mocks.IUserRepository)
+		mockMentionRepo := new(mocks.IMentionRepository)
+		service.MessageRepository = mockMessageRepo
+		service.UserRepository = mockUserRepo
+		service.MentionRepository = mockMentionRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockMentionRepo.EXPECT().GetForUser(mockApp.Ctx, caller.ID, false, mockApp.Config.Pagination.Mentions, 0).Return(tc.GetForUserResp, tc.GetForUserErr).Maybe()
+			mockMentionRepo.EXPECT().CountUnread(mockApp.Ctx, caller.ID).Return(int64(len(tc.GetForUserResp)), nil).Maybe()
+			mockMessageRepo.EXPECT().GetAll(mockApp.Ctx, mock.Anything, int64(0), mock.Anything).Return([]domain.Message{deleted, alive}, nil).Maybe()
+			mockUserRepo.EXPECT().Filter(mockApp.Ctx, "id IN ?", mock.Anything).Return([]domain.User{{BaseModel: domain.BaseModel{ID: 2}, Username: "alice"}}, nil).Maybe()
+
+			resp, err := service.GetMentions(mockApp.Ctx, caller, false, tc.page)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, resp.Mentions, tc.expectedCount)
+				for _, mention := range resp.Mentions {
+					assert.Equal(t, alive.Id.Hex(), mention.Message.Id)
+					assert.Equal(t, "alice", mention.Message.SenderUsername)
+					assert.Equal(t, "team", mention.ChatTitle)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchMessages(t *testing.T) {
+	mockApp := GetAppMock()
+	service := services.MessageService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "bob", Role: enums.USER, IsActive: true}
+	members := []domain.ChatMember{{UserID: 1, ChatID: 10}, {UserID: 1, ChatID: 20}}
+	now := time.Now()
+	long := strings.Repeat("filler ", 30) + "the Release is out " + strings.Repeat("filler ", 30)
+	cut := mockApp.Config.MessagesConfig.SnippetLength
+	cutFrom := strings.Index(long, "Release") - cut/4
+
+	found := func(n int) []domain.Message {
+		messages := make([]domain.Message, n)
+		for i := range messages {
+			messages[i] = domain.Message{BaseMongo: domain.BaseMongo{Id: primitive.NewObjectID(), CreatedAt: now}, SenderId: 2, ChatId: 10, Content: "release notes"}
+		}
+		return messages
+	}
+
+	testCases := []struct {
+		testName string
+
+		request dto.SearchMessagesRequest
+
+		GetByUsernameResp domain.User
+		GetByUsernameErr  error
+
+		SearchResp []domain.Message
+
+		expectedChats      []int64
+		expectedSnippet    string
+		expectedHighlights []dto.TextRangeDTO
+		expectedNext       bool
+		expectedResp       error
+		mustErr            bool
+	}{
+		{
+			testName:     "Empty query",
+			request:      dto.SearchMessagesRequest{Query: "  "},
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Period ends before it starts",
+			request:      dto.SearchMessagesRequest{Query: "release", From: now, To: now.Add(-time.Hour)},
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Invalid cursor",
+			request:      dto.SearchMessagesRequest{Query: "release", Cursor: "nope"},
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:     "Chat of someone else",
+			request:      dto.SearchMessagesRequest{Query: "release", ChatId: 30},
+			expectedResp: usecase_errors.BadRequestError{},
+			mustErr:      true,
+		},
+		{
+			testName:         "Unknown sender",
+			request:          dto.SearchMessagesRequest{Query: "release", Sender: "ghost"},
+			GetByUsernameErr: repositories.ErrRecordNotFound,
+			expectedResp:     usecase_errors.NotFoundError{},
+			mustErr:          true,
+		},
+		{
+			testName:           "Searches every chat of the caller",
+			request:            dto.SearchMessagesRequest{Query: "release -draft"},
+			SearchResp:         found(1),
+			expectedChats:      []int64{10, 20},
+			expectedSnippet:    "release notes",
+			expectedHighlights: []dto.TextRangeDTO{{Offset: 0, Length: 7}},
+			mustErr:            false,
+		},
+		{
+			testName:           "Filters by chat and sender",
+			request:            dto.SearchMessagesRequest{Query: "release", ChatId: 10, Sender: "alice"},
+			GetByUsernameResp:  domain.User{BaseModel: domain.BaseModel{ID: 2}, Username: "alice"},
+			SearchResp:         found(1),
+			expectedChats:      []int64{10},
+			expectedSnippet:    "release notes",
+			expectedHighlights: []dto.TextRangeDTO{{Offset: 0, Length: 7}},
+			mustErr:            false,
+		},
+		{
+			testName:           "Long message is cut around the match",
+			request:            dto.SearchMessagesRequest{Query: "release"},
+			SearchResp:         []domain.Message{{BaseMongo: domain.BaseMongo{Id: primitive.NewObjectID(), CreatedAt: now}, SenderId: 2, ChatId: 10, Content: long}},
+			expectedChats:      []int64{10, 20},
+			expectedSnippet:    "…" + long[cutFrom:cutFrom+cut] + "…",
+			expectedHighlights: []dto.TextRangeDTO{{Offset: 1 + cut/4, Length: 7}},
+			mustErr:            false,
+		},
+		{
+			testName:           "Next page",
+			request:            dto.SearchMessagesRequest{Query: "release", Limit: 2},
+			SearchResp:         found(3),
+			expectedChats:      []int64{10, 20},
+			expectedSnippet:    "release notes",
+			expectedHighlights: []dto.TextRangeDTO{{Offset: 0, Length: 7}},
+			expectedNext:       true,
+			mustErr:            false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMessageRepo := new(mocks.IMessageRepository)
+		mockChatMemberRepo := new(mocks.IChatMemberRepository)
+		mockUserRepo := new(mocks.IUserRepository)
+		mockChatRepo := new(mocks.IChatRepository)
+		service.MessageRepository = mockMessageRepo
+		service.ChatMemberRepository = mockChatMemberRepo
+		service.UserRepository = mockUserRepo
+		service.ChatRepository = mockChatRepo
+
+		t.Run(tc.testName, func(t *testing.T) {
+			mockChatMemberRepo.EXPECT().Filter(mockApp.Ctx, "user_id = ?", caller.ID).Return(members, nil).Maybe()
+			mockUserRepo.EXPECT().GetByUsername(mockApp.Ctx, tc.request.Sender).Return(tc.GetByUsernameResp, tc.GetByUsernameErr).Maybe()
+			mockUserRepo.EXPECT().Filter(mockApp.Ctx, "id IN ?", mock.Anything).Return([]domain.User{{BaseModel: domain.BaseModel{ID: 2}, Username: "alice"}}, nil).Maybe()
+			mockChatRepo.EXPECT().Filter(mockApp.Ctx, "id IN ?", mock.Anything).Return([]domain.Chat{{BaseModel: domain.BaseModel{ID: 10}, Title: "team"}}, nil).Maybe()
+			mockMessageRepo.EXPECT().Search(mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.SearchResp, nil).Maybe()
+
+			resp, err := service.SearchMessages(mockApp.Ctx, caller, tc.request)
+
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedResp), reflect.TypeOf(err))
+				mockMessageRepo.AssertNotCalled(t, "Search", mockApp.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			mockMessageRepo.AssertCalled(t, "Search", mockApp.Ctx, mock.MatchedBy(func(search repositories.MessageSearch) bool {
+				return reflect.DeepEqual(tc.expectedChats, search.ChatIds) && search.SenderId == tc.GetByUsernameResp.ID
+			}), mock.Anything, mock.Anything, mock.Anything)
+			assert.Equal(t, tc.expectedNext, resp.NextCursor != "")
+			for _, result := range resp.Results {
+				assert.Equal(t, "team", result.ChatTitle)
+				assert.Equal(t, "alice", result.Message.SenderUsername)
+				assert.Equal(t, tc.expectedSnippet, result.Snippet)
+				assert.Equal(t, tc.expectedHighlights, result.Highlights)
+			}
+		})
+	}
+}
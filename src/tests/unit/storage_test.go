@@ -0,0 +1,217 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"libs/src/internal/storage"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a stand-in for an S3 compatible service keeping objects in memory,
+// it ignores the signatures.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+			content = decodeAwsChunked(content)
+		}
+		f.objects[r.URL.Path] = content
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet, http.MethodHead:
+		content, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		if r.Method == http.MethodGet {
+			w.Write(content)
+		}
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// decodeAwsChunked strips the signed chunk framing clients use to stream
+// uploads over plain HTTP: "<hex size>;chunk-signature=<sig>\r\n<data>\r\n"
+func decodeAwsChunked(body []byte) []byte {
+	var content []byte
+	for len(body) > 0 {
+		header, rest, _ := bytes.Cut(body, []byte("\r\n"))
+		sizeHex, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(sizeHex), 16, 64)
+		if err != nil || size == 0 {
+			break
+		}
+		content = append(content, rest[:size]...)
+		body = rest[size+2:]
+	}
+	return content
+}
+
+func newStorages(t *testing.T) map[string]storage.IStorage {
+	server := httptest.NewServer(&fakeS3{objects: map[string][]byte{}})
+	t.Cleanup(server.Close)
+
+	s3Storage, err := storage.NewS3Storage(strings.TrimPrefix(server.URL, "http://"), "access", "secret", "bucket", "us-east-1", false)
+	require.NoError(t, err)
+
+	return map[string]storage.IStorage{
+		"local": storage.NewLocalStorage(t.TempDir(), "/media", "secret"),
+		"s3":    s3Storage,
+	}
+}
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+
+	for name, store := range newStorages(t) {
+		t.Run(name, func(t *testing.T) {
+			content := []byte("file content")
+			require.NoError(t, store.Save(ctx, "attachments/1/file", bytes.NewReader(content), int64(len(content)), "text/plain"))
+
+			info, err := store.Stat(ctx, "attachments/1/file")
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(content)), info.Size)
+			assert.NotEmpty(t, info.ETag)
+
+			reader, err := store.Open(ctx, "attachments/1/file")
+			require.NoError(t, err)
+			stored, err := io.ReadAll(reader)
+			reader.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, content, stored)
+
+			signed, err := store.SignedURL(ctx, "attachments/1/file", "report.txt", time.Minute)
+			assert.NoError(t, err)
+			assert.Contains(t, signed, "attachments/1/file")
+			assert.Contains(t, signed, "report.txt")
+
+			assert.NoError(t, store.Delete(ctx, "attachments/1/file"))
+			_, err = store.Open(ctx, "attachments/1/file")
+			assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+			_, err = store.Stat(ctx, "attachments/1/file")
+			assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+			// Deleting twice is fine
+			assert.NoError(t, store.Delete(ctx, "attachments/1/file"))
+		})
+	}
+}
+
+func TestLocalStorageSignedURL(t *testing.T) {
+	store := storage.NewLocalStorage(t.TempDir(), "/media", "secret")
+
+	signed, err := store.SignedURL(context.Background(), "attachments/1/file", "report.txt", time.Minute)
+	require.NoError(t, err)
+	link, err := url.Parse(signed)
+	require.NoError(t, err)
+	query := link.Query()
+	expiresAt, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		testName string
+
+		key       string
+		name      string
+		expiresAt int64
+		signature string
+
+		expected bool
+	}{
+		{
+			testName:  "Valid link",
+			key:       strings.TrimPrefix(link.Path, "/media/"),
+			name:      query.Get("name"),
+			expiresAt: expiresAt,
+			signature: query.Get("signature"),
+			expected:  true,
+		},
+		{
+			testName:  "Another file",
+			key:       "attachments/2/file",
+			name:      query.Get("name"),
+			expiresAt: expiresAt,
+			signature: query.Get("signature"),
+			expected:  false,
+		},
+		{
+			testName:  "Another name",
+			key:       "attachments/1/file",
+			name:      "report.html",
+			expiresAt: expiresAt,
+			signature: query.Get("signature"),
+			expected:  false,
+		},
+		{
+			testName:  "Extended expiration",
+			key:       "attachments/1/file",
+			name:      query.Get("name"),
+			expiresAt: expiresAt + 3600,
+			signature: query.Get("signature"),
+			expected:  false,
+		},
+		{
+			testName:  "Garbage signature",
+			key:       "attachments/1/file",
+			name:      query.Get("name"),
+			expiresAt: expiresAt,
+			signature: "not hex",
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, store.Verify(tc.key, tc.name, tc.expiresAt, tc.signature))
+		})
+	}
+
+	expired, err := store.SignedURL(context.Background(), "attachments/1/file", "", -time.Minute)
+	require.NoError(t, err)
+	link, err = url.Parse(expired)
+	require.NoError(t, err)
+	expiresAt, err = strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+	require.NoError(t, err)
+	assert.False(t, store.Verify("attachments/1/file", "", expiresAt, link.Query().Get("signature")))
+}
+
+func TestLocalStorageStaysInRoot(t *testing.T) {
+	root := t.TempDir()
+	store := storage.NewLocalStorage(root+"/uploads", "/media", "secret")
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, "../../escaped", strings.NewReader("x"), 1, "text/plain"))
+
+	_, err := storage.NewLocalStorage(root, "/media", "secret").Open(ctx, "escaped")
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	_, err = storage.NewLocalStorage(root, "/media", "secret").Open(ctx, "uploads/escaped")
+	assert.NoError(t, err)
+}
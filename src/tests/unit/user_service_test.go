@@ -1,18 +1,28 @@
 package unit
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
 	"libs/src/internal/domain/enums"
 	domain "libs/src/internal/domain/models"
 	"libs/src/internal/dto"
 	"libs/src/internal/mocks"
 	"libs/src/internal/repositories"
+	"libs/src/internal/storage"
 	services "libs/src/internal/usecase"
 	usecase_errors "libs/src/internal/usecase/errors"
 	"libs/src/pkg/utils"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -473,3 +483,170 @@ func TestChangePassword(t *testing.T) {
 		})
 	}
 }
+
+// encodeImage paints the top half of a width x height image red and the bottom
+// half blue
+func encodeImage(t *testing.T, format string, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if y < height/2 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	switch format {
+	case "png":
+		assert.NoError(t, png.Encode(&out, img))
+	case "gif":
+		assert.NoError(t, gif.Encode(&out, img, nil))
+	default:
+		assert.NoError(t, jpeg.Encode(&out, img, nil))
+	}
+	return out.Bytes()
+}
+
+// withOrientation adds an EXIF segment with the orientation tag to a JPEG
+func withOrientation(content []byte, orientation byte) []byte {
+	exif := []byte{
+		0xFF, 0xE1, 0x00, 0x22,
+		'E', 'x', 'i', 'f', 0x00, 0x00,
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08,
+		0x00, 0x01,
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, orientation, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	return append(append(append([]byte{}, content[:2]...), exif...), content[2:]...)
+}
+
+func TestResizeAvatar(t *testing.T) {
+	testCases := []struct {
+		testName string
+
+		content []byte
+
+		expectedFormat string
+		// expectedRight is the color of the right middle of the result
+		expectedRight color.RGBA
+		mustErr       bool
+	}{
+		{
+			testName: "Not an image",
+			content:  []byte("<?php system($_GET['cmd']); ?>"),
+			mustErr:  true,
+		},
+		{
+			testName: "GIF",
+			content:  encodeImage(t, "gif", 20, 10),
+			mustErr:  true,
+		},
+		{
+			testName:       "PNG",
+			content:        encodeImage(t, "png", 20, 10),
+			expectedFormat: "png",
+			expectedRight:  color.RGBA{B: 255, A: 255},
+		},
+		{
+			testName:       "JPEG turned upright",
+			content:        withOrientation(encodeImage(t, "jpeg", 20, 10), 6),
+			expectedFormat: "jpeg",
+			expectedRight:  color.RGBA{R: 255, A: 255},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			format, thumbnails, err := utils.ResizeAvatar(tc.content, []int{8, 64})
+
+			if tc.mustErr {
+				assert.ErrorIs(t, err, utils.ErrInvalidImage)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFormat, format)
+
+			small, _, err := image.Decode(bytes.NewReader(thumbnails[8]))
+			assert.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, 8, 8), small.Bounds())
+
+			// Not scaled up past the source, and the center square is kept
+			large, _, err := image.Decode(bytes.NewReader(thumbnails[64]))
+			assert.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, 10, 10), large.Bounds())
+
+			r, g, b, _ := large.At(8, 6).RGBA()
+			expectedR, expectedG, expectedB, _ := tc.expectedRight.RGBA()
+			assert.InDelta(t, expectedR>>8, r>>8, 60)
+			assert.InDelta(t, expectedG>>8, g>>8, 60)
+			assert.InDelta(t, expectedB>>8, b>>8, 60)
+		})
+	}
+}
+
+func TestChangeUserAvatar(t *testing.T) {
+	mockApp := GetAppMock()
+	uploadDir := t.TempDir()
+	mockApp.Storage = storage.NewLocalStorage(uploadDir, "/media", "secret")
+	service := services.UserService{
+		App: mockApp,
+	}
+
+	caller := dto.UserDTO{ID: 1, Username: "testuser", Role: enums.USER, IsActive: true, Image: "avatars/old.png"}
+	for _, size := range enums.AvatarSizes {
+		assert.NoError(t, mockApp.Storage.Save(mockApp.Ctx, domain.AvatarKey(caller.Image, size), strings.NewReader("old"), 3, "image/png"))
+	}
+
+	testCases := []struct {
+		testName string
+
+		content []byte
+
+		expectedErr error
+		mustErr     bool
+	}{
+		{
+			testName:    "Not an image",
+			content:     []byte("not an image"),
+			expectedErr: usecase_errors.BadRequestError{},
+			mustErr:     true,
+		},
+		{
+			testName: "Success",
+			content:  encodeImage(t, "png", 600, 400),
+			mustErr:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		mockUserRepository := new(mocks.IUserRepository)
+		service.UserRepository = mockUserRepository
+
+		t.Run(tc.testName, func(t *testing.T) {
+			var newImage string
+			mockUserRepository.EXPECT().UpdateById(mockApp.Ctx, caller.ID, mock.Anything).
+				Run(func(_ context.Context, _ int64, data map[string]any) { newImage = *data["image"].(*string) }).
+				Return(nil).Maybe()
+
+			files := formFiles(t, map[string][]byte{"avatar.png": tc.content})
+			err := service.ChangeUserProfile(mockApp.Ctx, caller, dto.ChangeUserProfileRequest{NewImage: files[0]})
+
+			stored, _ := filepath.Glob(filepath.Join(uploadDir, "avatars", "*"))
+			if tc.mustErr {
+				assert.Error(t, err)
+				assert.Equal(t, reflect.TypeOf(tc.expectedErr), reflect.TypeOf(err))
+				assert.Len(t, stored, len(enums.AvatarSizes))
+			} else {
+				assert.NoError(t, err)
+				// The old thumbnails are replaced by the new ones
+				assert.Len(t, stored, len(enums.AvatarSizes))
+				for _, size := range enums.AvatarSizes {
+					assert.FileExists(t, filepath.Join(uploadDir, filepath.FromSlash(domain.AvatarKey(newImage, size))))
+				}
+			}
+		})
+	}
+}